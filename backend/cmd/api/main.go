@@ -1,11 +1,16 @@
 package main
 
 import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"fmt"
 	"log"
 	"net/http"
 
 	"github.com/joho/godotenv"
 	"github.com/thielel/voca/internal/config"
+	"github.com/thielel/voca/internal/domain"
 	"github.com/thielel/voca/internal/handler"
 	"github.com/thielel/voca/internal/repository"
 	"github.com/thielel/voca/internal/service"
@@ -27,8 +32,22 @@ func main() {
 	}
 	defer db.Close()
 
+	// Initialize the result store
+	resultStore, closeResultStore, err := openResultStore(cfg, db)
+	if err != nil {
+		log.Fatalf("Failed to initialize result store: %v", err)
+	}
+	defer closeResultStore()
+
 	// Initialize repositories
-	resultRepo := repository.NewResultRepository(db)
+	normSnapshotRepo := repository.NewNormSnapshotRepository(db)
+	draftRepo := repository.NewDraftRepository(db)
+	shareRepo := repository.NewShareRepository(db)
+	authRepo := repository.NewAuthRepository(db)
+	cohortRepo := repository.NewCohortRepository(db)
+	consentRepo := repository.NewConsentRepository(db)
+	erasureRepo := repository.NewErasureRepository(db)
+	retentionRepo := repository.NewRetentionRepository(db)
 
 	// Initialize OpenAI service
 	var openaiService *service.OpenAIService
@@ -39,23 +58,194 @@ func main() {
 		log.Println("Warning: OPENAI_API_KEY not set, AI interpretations will be disabled")
 	}
 
+	// Load occupation catalog
+	occupationCatalog, err := repository.LoadOccupationCatalog(cfg.OccupationsPath)
+	if err != nil {
+		log.Fatalf("Failed to load occupation catalog: %v", err)
+	}
+	log.Printf("Occupation catalog %s (%s): %d occupations", occupationCatalog.ID, occupationCatalog.Version, len(occupationCatalog.Occupations))
+
+	// Load norm tables
+	normSets, err := repository.LoadNormSets(cfg.NormsDir)
+	if err != nil {
+		log.Fatalf("Failed to load norm tables: %v", err)
+	}
+	for _, set := range normSets {
+		log.Printf("Norm table %s for %s", set.Key(), set.InstrumentID)
+	}
+
+	// Load item translations
+	translations, err := repository.LoadTranslations(cfg.TranslationsDir)
+	if err != nil {
+		log.Fatalf("Failed to load translations: %v", err)
+	}
+
+	// Load consent texts
+	consentTexts, err := repository.LoadConsentTexts(cfg.ConsentDir)
+	if err != nil {
+		log.Fatalf("Failed to load consent texts: %v", err)
+	}
+
+	// Load tenant configuration
+	tenants, err := repository.LoadTenants(cfg.TenantsPath)
+	if err != nil {
+		log.Fatalf("Failed to load tenants: %v", err)
+	}
+
+	// Session token signing key
+	sessionSecret := []byte(cfg.SessionSecret)
+	if len(sessionSecret) == 0 {
+		if cfg.Environment == "production" {
+			log.Fatal("SESSION_SECRET must be set in production")
+		}
+		sessionSecret = make([]byte, 32)
+		if _, err := rand.Read(sessionSecret); err != nil {
+			log.Fatalf("Failed to generate session secret: %v", err)
+		}
+		log.Println("Warning: SESSION_SECRET not set, session tokens will not survive a restart")
+	}
+
 	// Initialize services
-	personalityService := service.NewPersonalityService(resultRepo, openaiService)
+	tokenService := service.NewTokenService(sessionSecret, cfg.SessionTokenTTL)
+	authService := service.NewAuthService(authRepo, cfg.AdminSessionTTL)
+	instrumentRegistry := service.NewDefaultInstrumentRegistry()
+	normRegistry := service.NewNormRegistry(normSets...)
+	translationRegistry, err := service.NewTranslationRegistry(instrumentRegistry, translations...)
+	if err != nil {
+		log.Fatalf("Failed to register translations: %v", err)
+	}
+	log.Printf("Loaded %d item translations", len(translations))
+	tenantRegistry, err := service.NewTenantRegistry(instrumentRegistry, tenants...)
+	if err != nil {
+		log.Fatalf("Failed to register tenants: %v", err)
+	}
+	for _, tenant := range tenantRegistry.List() {
+		log.Printf("Tenant %s (%s): hosts %v", tenant.ID, tenant.Name, tenant.Hosts)
+	}
+	consentRegistry, err := service.NewConsentRegistry(consentTexts...)
+	if err != nil {
+		log.Fatalf("Failed to register consent texts: %v", err)
+	}
+	log.Printf("Loaded %d consent texts", len(consentTexts))
+	if !cfg.RequireConsent {
+		log.Println("Warning: REQUIRE_CONSENT not set, results of sessions without a consent record are stored without interpretations")
+	}
+	consentService := service.NewConsentService(consentRepo, resultStore, draftRepo, shareRepo, consentRegistry, cfg.RequireConsent, cfg.GuardianConsentAge, cfg.GuardianCodeTTL)
+	personalityService := service.NewPersonalityService(resultStore, openaiService, instrumentRegistry, normRegistry, translationRegistry, consentService, cfg.SuppressInvalidInterpretations)
+	careerService := service.NewCareerService(resultStore, occupationCatalog)
+	shareService := service.NewShareService(shareRepo, personalityService)
+	draftService := service.NewDraftService(draftRepo, personalityService, instrumentRegistry, cfg.DraftTTL)
+	cohortService := service.NewCohortService(cohortRepo, resultStore, draftRepo, instrumentRegistry, cfg.CohortMinGroupSize)
+	normSnapshotService := service.NewNormSnapshotService(resultStore, normSnapshotRepo, instrumentRegistry, cfg.NormMinSample)
+	privacyService := service.NewPrivacyService(personalityService, resultStore, draftRepo, shareRepo, consentRepo, erasureRepo)
+	retentionService := service.NewRetentionService(retentionRepo, resultStore, draftRepo, shareRepo, tenantRegistry, cfg.RetentionDryRun)
+
+	// Keep empirical norm snapshots current
+	go normSnapshotService.RunPeriodicRefresh(context.Background(), cfg.NormSnapshotInterval)
+
+	// Apply the tenants' retention rules
+	if cfg.RetentionDryRun {
+		log.Println("Warning: RETENTION_DRY_RUN set, retention rules only report what they would delete")
+	}
+	go retentionService.RunPeriodicPurge(context.Background(), cfg.RetentionInterval)
 
 	// Initialize handlers
-	questionnaireHandler := handler.NewQuestionnaireHandler(personalityService)
+	questionnaireHandler := handler.NewQuestionnaireHandler(personalityService, tokenService, cohortService)
+	careerHandler := handler.NewCareerHandler(careerService)
+	normHandler := handler.NewNormHandler(normSnapshotService)
+	draftHandler := handler.NewDraftHandler(draftService, tokenService, cohortService)
+	sessionHandler := handler.NewSessionHandler(tokenService, personalityService)
+	shareHandler := handler.NewShareHandler(shareService)
+	cohortHandler := handler.NewCohortHandler(cohortService)
+	consentHandler := handler.NewConsentHandler(consentService, tokenService)
+	privacyHandler := handler.NewPrivacyHandler(privacyService, tokenService)
+	retentionHandler := handler.NewRetentionHandler(retentionService)
+	authHandler := handler.NewAuthHandler(authService, cfg.Environment == "production", cfg.CORSOrigins)
+	owner := sessionHandler.RequireOwner
+	viewer := func(next http.HandlerFunc) http.HandlerFunc { return authHandler.Require(domain.RoleViewer, next) }
+	teacher := func(next http.HandlerFunc) http.HandlerFunc { return authHandler.Require(domain.RoleTeacher, next) }
+	counselor := func(next http.HandlerFunc) http.HandlerFunc { return authHandler.Require(domain.RoleCounselor, next) }
+	admin := func(next http.HandlerFunc) http.HandlerFunc { return authHandler.Require(domain.RoleAdmin, next) }
 
 	// Setup routes
 	mux := http.NewServeMux()
 
 	// API routes
 	mux.HandleFunc("GET /api/questions", questionnaireHandler.GetQuestions)
+	mux.HandleFunc("GET /api/instruments", questionnaireHandler.GetInstruments)
+	mux.HandleFunc("GET /api/instruments/{id}/questions", questionnaireHandler.GetInstrumentQuestions)
 	mux.HandleFunc("POST /api/results", questionnaireHandler.SubmitAnswers)
-	mux.HandleFunc("GET /api/results/{id}", questionnaireHandler.GetResult)
-	mux.HandleFunc("POST /api/results/{id}/regenerate", questionnaireHandler.RegenerateInterpretations)
+	mux.HandleFunc("GET /api/shared/{token}", shareHandler.GetSharedResult)
+	mux.HandleFunc("GET /api/join/{code}", cohortHandler.Join)
+	mux.HandleFunc("POST /api/session-tokens", sessionHandler.CreateToken)
+	mux.HandleFunc("GET /api/session/results", sessionHandler.GetResults)
+
+	// Result routes, restricted to the session that created the result
+	mux.HandleFunc("GET /api/results/{id}", owner(questionnaireHandler.GetResult))
+	mux.HandleFunc("POST /api/results/{id}/regenerate", owner(questionnaireHandler.RegenerateInterpretations))
+	mux.HandleFunc("GET /api/results/{id}/careers", owner(careerHandler.GetCareers))
+	mux.HandleFunc("GET /api/results/{id}/compare", owner(normHandler.Compare))
+	mux.HandleFunc("POST /api/results/{id}/shares", owner(shareHandler.CreateShare))
+	mux.HandleFunc("GET /api/results/{id}/shares", owner(shareHandler.GetShares))
+	mux.HandleFunc("DELETE /api/results/{id}/shares/{shareID}", owner(shareHandler.RevokeShare))
+	mux.HandleFunc("PUT /api/results/{id}/cohort-consent", owner(cohortHandler.SetConsent))
+
+	// Consent routes: respondents grant and withdraw consent with their
+	// session token, guardians confirm a minor's consent with its code
+	mux.HandleFunc("GET /api/consent/text", consentHandler.GetText)
+	mux.HandleFunc("GET /api/consent", consentHandler.Get)
+	mux.HandleFunc("POST /api/consent", consentHandler.Grant)
+	mux.HandleFunc("POST /api/consent/withdraw", consentHandler.Withdraw)
+	mux.HandleFunc("GET /api/consent/guardian/{code}", consentHandler.GetGuardianConsent)
+	mux.HandleFunc("POST /api/consent/guardian/{code}/confirm", consentHandler.ConfirmGuardian)
+	mux.HandleFunc("POST /api/consent/guardian/{code}/withdraw", consentHandler.WithdrawGuardian)
+
+	// Data subject routes: respondents export or erase everything stored
+	// for their session with its token
+	mux.HandleFunc("GET /api/me/export", privacyHandler.Export)
+	mux.HandleFunc("DELETE /api/me", privacyHandler.Erase)
+
+	// Cohort routes: teachers see their own cohorts, counselors all
+	mux.HandleFunc("GET /api/organizations", teacher(cohortHandler.GetOrganizations))
+	mux.HandleFunc("POST /api/organizations", admin(cohortHandler.CreateOrganization))
+	mux.HandleFunc("GET /api/cohorts", teacher(cohortHandler.GetCohorts))
+	mux.HandleFunc("POST /api/cohorts", teacher(cohortHandler.CreateCohort))
+	mux.HandleFunc("GET /api/cohorts/{id}/summary", teacher(cohortHandler.GetSummary))
 
-	// Admin routes
-	mux.HandleFunc("GET /api/admin/results", questionnaireHandler.GetAllResults)
+	// Draft session routes
+	mux.HandleFunc("POST /api/sessions", draftHandler.CreateDraft)
+	mux.HandleFunc("GET /api/sessions/{id}", draftHandler.GetDraft)
+	mux.HandleFunc("GET /api/sessions/resume/{code}", draftHandler.ResumeDraft)
+	mux.HandleFunc("PUT /api/sessions/{id}/answers", draftHandler.SaveAnswers)
+	mux.HandleFunc("POST /api/sessions/{id}/finalize", draftHandler.Finalize)
+
+	// Admin authentication
+	mux.HandleFunc("POST /api/admin/login", authHandler.Login)
+	mux.HandleFunc("POST /api/admin/logout", authHandler.Logout)
+	mux.HandleFunc("GET /api/admin/me", viewer(authHandler.Me))
+
+	// Admin routes: viewers see aggregates, counselors individual results,
+	// admins manage snapshots, users and API keys, erase and purge data
+	mux.HandleFunc("GET /api/admin/sessions/stats", viewer(draftHandler.GetStats))
+	mux.HandleFunc("GET /api/admin/norms/snapshots", viewer(normHandler.GetSnapshots))
+	mux.HandleFunc("GET /api/admin/results", counselor(questionnaireHandler.ListResults))
+	mux.HandleFunc("GET /api/admin/results/{id}", counselor(questionnaireHandler.GetAdminResult))
+	mux.HandleFunc("GET /api/admin/results/{id}/answers", counselor(questionnaireHandler.GetResultAnswers))
+	mux.HandleFunc("GET /api/admin/results/{id}/interpretations/history", counselor(questionnaireHandler.GetInterpretationHistory))
+	mux.HandleFunc("POST /api/admin/results/{id}/interpretations/rollback", counselor(questionnaireHandler.RollbackInterpretations))
+	mux.HandleFunc("GET /api/admin/sessions/{id}/results", counselor(sessionHandler.GetAdminResults))
+	mux.HandleFunc("POST /api/admin/norms/snapshots", admin(normHandler.CreateSnapshot))
+	mux.HandleFunc("POST /api/admin/norms/snapshots/{name}/refresh", admin(normHandler.RefreshSnapshot))
+	mux.HandleFunc("GET /api/admin/users", admin(authHandler.GetUsers))
+	mux.HandleFunc("POST /api/admin/users", admin(authHandler.CreateUser))
+	mux.HandleFunc("GET /api/admin/api-keys", admin(authHandler.GetAPIKeys))
+	mux.HandleFunc("POST /api/admin/api-keys", admin(authHandler.CreateAPIKey))
+	mux.HandleFunc("DELETE /api/admin/api-keys/{id}", admin(authHandler.RevokeAPIKey))
+	mux.HandleFunc("DELETE /api/admin/results/{id}", admin(privacyHandler.EraseResult))
+	mux.HandleFunc("DELETE /api/admin/sessions/{id}", admin(privacyHandler.EraseSession))
+	mux.HandleFunc("GET /api/admin/erasures", admin(privacyHandler.GetErasures))
+	mux.HandleFunc("GET /api/admin/purges", admin(retentionHandler.GetPurges))
+	mux.HandleFunc("POST /api/admin/purges", admin(retentionHandler.Purge))
 
 	// Health check
 	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
@@ -65,8 +255,9 @@ func main() {
 
 	// Apply middleware
 	var httpHandler http.Handler = mux
+	httpHandler = handler.Tenant(httpHandler, tenantRegistry)
 	httpHandler = handler.Logger(httpHandler)
-	httpHandler = handler.CORS(httpHandler)
+	httpHandler = handler.CORS(httpHandler, cfg.CORSOrigins...)
 
 	// Start server
 	addr := ":" + cfg.Port
@@ -78,3 +269,28 @@ func main() {
 		log.Fatal(err)
 	}
 }
+
+// openResultStore opens the result store selected by RESULT_STORE. Results in
+// SQLite share the database with everything else. The returned function
+// closes a separate database.
+func openResultStore(cfg *config.Config, db *sql.DB) (service.ResultStore, func() error, error) {
+	noop := func() error { return nil }
+	switch cfg.ResultStore {
+	case "sqlite":
+		return repository.NewResultRepository(db), noop, nil
+	case "postgres":
+		if cfg.PostgresURL == "" {
+			return nil, nil, fmt.Errorf("POSTGRES_URL must be set for the postgres result store")
+		}
+		pg, err := repository.InitPostgres(cfg.PostgresURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		return repository.NewPostgresResultRepository(pg), pg.Close, nil
+	case "memory":
+		log.Println("Warning: RESULT_STORE is memory, results will not survive a restart")
+		return repository.NewMemoryResultRepository(), noop, nil
+	default:
+		return nil, nil, fmt.Errorf("unknown RESULT_STORE %q, use sqlite, postgres or memory", cfg.ResultStore)
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/thielel/voca/internal/config"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// Creates an admin user of a tenant. The password is read from ADMIN_PASSWORD
+// or, when that is unset, from the first line of standard input, so it never
+// appears in the process list or shell history.
+//
+//	echo "$PASSWORD" | go run ./cmd/create-admin -username alice -role admin -tenant school-a
+func main() {
+	username := flag.String("username", "", "username of the new admin user")
+	role := flag.String("role", string(domain.RoleAdmin), "role of the new user: viewer, teacher, counselor or admin")
+	tenantID := flag.String("tenant", domain.DefaultTenantID, "tenant the user administers, as configured in TENANTS_PATH")
+	flag.Parse()
+
+	if *username == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	password := os.Getenv("ADMIN_PASSWORD")
+	if password == "" {
+		fmt.Fprint(os.Stderr, "Password: ")
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			log.Fatalf("Failed to read password: %v", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	// Check the tenant against the tenant configuration
+	tenants, err := repository.LoadTenants(cfg.TenantsPath)
+	if err != nil {
+		log.Fatalf("Failed to load tenants: %v", err)
+	}
+	tenantRegistry, err := service.NewTenantRegistry(service.NewDefaultInstrumentRegistry(), tenants...)
+	if err != nil {
+		log.Fatalf("Failed to register tenants: %v", err)
+	}
+	tenant, err := tenantRegistry.Get(*tenantID)
+	if err != nil {
+		log.Fatalf("Failed to create admin user: %v %q", err, *tenantID)
+	}
+
+	// Initialize database
+	db, err := repository.InitDB(cfg.DatabasePath)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	authService := service.NewAuthService(repository.NewAuthRepository(db), cfg.AdminSessionTTL)
+	user, err := authService.CreateUser(tenant.ID, domain.CreateAdminUserRequest{
+		Username: *username,
+		Password: password,
+		Role:     domain.Role(*role),
+	})
+	if err != nil {
+		log.Fatalf("Failed to create admin user: %v", err)
+	}
+
+	log.Printf("Created %s user %q of tenant %s (%s)", user.Role, user.Username, user.TenantID, user.ID)
+}
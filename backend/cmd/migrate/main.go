@@ -0,0 +1,113 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/thielel/voca/internal/config"
+	"github.com/thielel/voca/internal/repository"
+)
+
+// Shows and changes the schema version of the database at DATABASE_PATH.
+// The API applies pending migrations on startup; this command is for
+// inspecting a database and for rolling back.
+//
+//	go run ./cmd/migrate status
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down [steps]
+//	go run ./cmd/migrate to <version>
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "usage: migrate status | up | down [steps] | to <version>")
+	}
+	flag.Parse()
+	if flag.NArg() == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	// Load configuration
+	cfg := config.Load()
+
+	db, err := repository.OpenDB(cfg.DatabasePath)
+	if err != nil {
+		log.Fatalf("Failed to open database: %v", err)
+	}
+	defer db.Close()
+
+	migrator, err := repository.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Failed to read migrations: %v", err)
+	}
+
+	var changed int
+	switch command := flag.Arg(0); command {
+	case "status":
+		if err := printStatus(migrator); err != nil {
+			log.Fatalf("Failed to read migration status: %v", err)
+		}
+		return
+	case "up":
+		changed, err = migrator.Up()
+	case "down":
+		steps := 1
+		if flag.NArg() > 1 {
+			if steps, err = strconv.Atoi(flag.Arg(1)); err != nil || steps < 1 {
+				log.Fatalf("Invalid number of steps %q", flag.Arg(1))
+			}
+		}
+		changed, err = migrator.Down(steps)
+	case "to":
+		if flag.NArg() < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		version, convErr := strconv.Atoi(flag.Arg(1))
+		if convErr != nil {
+			log.Fatalf("Invalid version %q", flag.Arg(1))
+		}
+		changed, err = migrator.To(version)
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+	if err != nil {
+		log.Fatalf("Failed to migrate: %v", err)
+	}
+
+	version, err := migrator.Version()
+	if err != nil {
+		log.Fatalf("Failed to read schema version: %v", err)
+	}
+	log.Printf("Changed %d migrations, schema version is %d of %d", changed, version, migrator.Latest())
+}
+
+// printStatus lists every migration with whether and when it was applied
+func printStatus(migrator *repository.Migrator) error {
+	statuses, err := migrator.Status()
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "VERSION\tNAME\tSTATUS\tAPPLIED AT")
+	for _, status := range statuses {
+		state, appliedAt := "pending", ""
+		if status.AppliedAt != nil {
+			state, appliedAt = "applied", status.AppliedAt.Format(time.DateTime)
+		}
+		switch {
+		case status.Unknown:
+			state = "unknown (newer build)"
+		case status.Modified:
+			state = "modified"
+		}
+		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\n", status.Version, status.Name, state, appliedAt)
+	}
+	return w.Flush()
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"log"
+	"os"
+
+	"github.com/thielel/voca/internal/config"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// Applies the retention rules of the tenants configured in TENANTS_PATH once
+// and prints the purge reports as JSON, e.g. from cron instead of the API's
+// scheduler. Use -dry-run to see what would be deleted.
+//
+//	go run ./cmd/purge -dry-run -tenant school-a
+func main() {
+	dryRun := flag.Bool("dry-run", false, "only report what the retention rules would delete")
+	tenantID := flag.String("tenant", "", "tenant to purge; empty purges every tenant with a retention policy")
+	flag.Parse()
+
+	// Load configuration
+	cfg := config.Load()
+
+	tenants, err := repository.LoadTenants(cfg.TenantsPath)
+	if err != nil {
+		log.Fatalf("Failed to load tenants: %v", err)
+	}
+	tenantRegistry, err := service.NewTenantRegistry(service.NewDefaultInstrumentRegistry(), tenants...)
+	if err != nil {
+		log.Fatalf("Failed to register tenants: %v", err)
+	}
+
+	// Initialize database
+	db, err := repository.InitDB(cfg.DatabasePath)
+	if err != nil {
+		log.Fatalf("Failed to initialize database: %v", err)
+	}
+	defer db.Close()
+
+	// Results may be kept apart from the rest of the database
+	var results service.ResultStore
+	switch cfg.ResultStore {
+	case "sqlite":
+		results = repository.NewResultRepository(db)
+	case "postgres":
+		pg, err := repository.InitPostgres(cfg.PostgresURL)
+		if err != nil {
+			log.Fatalf("Failed to initialize result store: %v", err)
+		}
+		defer pg.Close()
+		results = repository.NewPostgresResultRepository(pg)
+	default:
+		log.Fatalf("Cannot purge the %q result store", cfg.ResultStore)
+	}
+
+	retentionService := service.NewRetentionService(
+		repository.NewRetentionRepository(db),
+		results,
+		repository.NewDraftRepository(db),
+		repository.NewShareRepository(db),
+		tenantRegistry,
+		cfg.RetentionDryRun,
+	)
+
+	var reports []*domain.PurgeReport
+	if *tenantID == "" {
+		reports, err = retentionService.PurgeAll(*dryRun)
+	} else {
+		var tenant *domain.Tenant
+		tenant, err = tenantRegistry.Get(*tenantID)
+		if err != nil {
+			log.Fatalf("Failed to purge: %v %q", err, *tenantID)
+		}
+		var report *domain.PurgeReport
+		if report, err = retentionService.Purge(tenant, *dryRun); err == nil {
+			reports = append(reports, report)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Failed to purge: %v", err)
+	}
+
+	if reports == nil {
+		reports = []*domain.PurgeReport{}
+	}
+
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(reports); err != nil {
+		log.Fatalf("Failed to write purge reports: %v", err)
+	}
+}
@@ -79,8 +79,18 @@ func main() {
 
 	for i, data := range seedData {
 		result := &domain.PersonalityResult{
-			ID:                 uuid.New().String(),
-			SessionID:          "seed-session-" + uuid.New().String()[:8],
+			ID:                uuid.New().String(),
+			TenantID:          domain.DefaultTenantID,
+			SessionID:         "seed-session-" + uuid.New().String()[:8],
+			InstrumentID:      domain.InstrumentIPIP50,
+			InstrumentVersion: domain.IPIP50().Version,
+			Scores: map[domain.Trait]float64{
+				domain.TraitExtraversion:       data.extraversion,
+				domain.TraitAgreeableness:      data.agreeableness,
+				domain.TraitConscientiousness:  data.conscientiousness,
+				domain.TraitEmotionalStability: data.emotionalStability,
+				domain.TraitOpenness:           data.openness,
+			},
 			Extraversion:       data.extraversion,
 			Agreeableness:      data.agreeableness,
 			Conscientiousness:  data.conscientiousness,
@@ -89,7 +99,7 @@ func main() {
 			CreatedAt:          time.Now().Add(-time.Duration(i) * time.Hour), // Stagger creation times
 		}
 
-		if err := resultRepo.Save(result); err != nil {
+		if err := resultRepo.Save(result, nil); err != nil {
 			log.Printf("Failed to save result for %s: %v", data.name, err)
 			continue
 		}
@@ -0,0 +1,24 @@
+// Package data embeds the reference data files shipped with the application.
+package data
+
+import "embed"
+
+// FS holds the bundled data files
+//
+//go:embed *.json consent/*.json norms/*.json translations/*.json
+var FS embed.FS
+
+// OccupationsFile is the bundled occupation catalog
+const OccupationsFile = "occupations.json"
+
+// TenantsFile is the bundled tenant configuration with a single default tenant
+const TenantsFile = "tenants.json"
+
+// NormsDir holds the bundled norm tables, one file per norm set version
+const NormsDir = "norms"
+
+// TranslationsDir holds the bundled item translations, one file per instrument and language
+const TranslationsDir = "translations"
+
+// ConsentDir holds the bundled consent texts, one file per language
+const ConsentDir = "consent"
@@ -9,3 +9,7 @@ require github.com/mattn/go-sqlite3 v1.14.33
 require github.com/sashabaranov/go-openai v1.41.2
 
 require github.com/joho/godotenv v1.5.1 // indirect
+
+require golang.org/x/crypto v0.33.0
+
+require github.com/lib/pq v1.10.9
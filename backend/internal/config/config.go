@@ -2,23 +2,96 @@ package config
 
 import (
 	"os"
+	"strconv"
+	"strings"
+	"time"
 )
 
 // Config holds the application configuration
 type Config struct {
 	Port         string
 	DatabasePath string
+	// ResultStore selects where results are kept: sqlite (in DatabasePath),
+	// postgres (at PostgresURL) or memory (lost on restart)
+	ResultStore  string
+	PostgresURL  string
 	Environment  string
 	OpenAIAPIKey string
+	// OccupationsPath points to a JSON or CSV occupation catalog; empty uses the bundled one
+	OccupationsPath string
+	// NormsDir holds additional norm tables (*.json) next to the bundled ones
+	NormsDir string
+	// TranslationsDir holds additional item translations (*.json) next to the bundled ones
+	TranslationsDir string
+	// ConsentDir holds additional consent texts (*.json) next to the bundled ones
+	ConsentDir string
+	// TenantsPath points to a JSON tenant configuration; empty serves only the default tenant
+	TenantsPath string
+	// NormSnapshotInterval is how often empirical norm snapshots are recomputed
+	NormSnapshotInterval time.Duration
+	// NormMinSample is the smallest group a norm snapshot exposes or compares against
+	NormMinSample int
+	// SessionSecret signs session tokens; a random secret is used when empty
+	SessionSecret string
+	// SessionTokenTTL is how long a session token stays valid after it is issued
+	SessionTokenTTL time.Duration
+	// AdminSessionTTL is how long an admin stays signed in
+	AdminSessionTTL time.Duration
+	// CORSOrigins are the frontend origins allowed to send credentials
+	CORSOrigins []string
+	// CohortMinGroupSize is the smallest cohort whose score distributions are shown
+	CohortMinGroupSize int
+	// DraftTTL is how long an unfinished questionnaire can be resumed after its last change
+	DraftTTL time.Duration
+	// RequireConsent blocks storing results of sessions without a consent
+	// record. Interpretations always need consent.
+	RequireConsent bool
+	// GuardianConsentAge is the age below which a guardian has to confirm consent
+	GuardianConsentAge int
+	// GuardianCodeTTL is how long a guardian has to confirm a minor's consent
+	GuardianCodeTTL time.Duration
+	// RetentionInterval is how often the tenants' retention rules are applied
+	RetentionInterval time.Duration
+	// RetentionDryRun makes every purge only report what it would delete
+	RetentionDryRun bool
+	// SuppressInvalidInterpretations skips AI interpretations for protocols
+	// flagged invalid by the careless-responding checks
+	SuppressInvalidInterpretations bool
 }
 
 // Load reads configuration from environment variables
 func Load() *Config {
 	return &Config{
-		Port:         getEnv("PORT", "8080"),
-		DatabasePath: getEnv("DATABASE_PATH", "./voca.db"),
-		Environment:  getEnv("ENVIRONMENT", "development"),
-		OpenAIAPIKey: getEnv("OPENAI_API_KEY", ""),
+		Port:            getEnv("PORT", "8080"),
+		DatabasePath:    getEnv("DATABASE_PATH", "./voca.db"),
+		ResultStore:     getEnv("RESULT_STORE", "sqlite"),
+		PostgresURL:     getEnv("POSTGRES_URL", ""),
+		Environment:     getEnv("ENVIRONMENT", "development"),
+		OpenAIAPIKey:    getEnv("OPENAI_API_KEY", ""),
+		OccupationsPath: getEnv("OCCUPATIONS_PATH", ""),
+		NormsDir:        getEnv("NORMS_DIR", ""),
+		TranslationsDir: getEnv("TRANSLATIONS_DIR", ""),
+		ConsentDir:      getEnv("CONSENT_DIR", ""),
+		TenantsPath:     getEnv("TENANTS_PATH", ""),
+
+		NormSnapshotInterval: getEnvDuration("NORM_SNAPSHOT_INTERVAL", 24*time.Hour),
+		NormMinSample:        getEnvInt("NORM_MIN_SAMPLE", 30),
+		CohortMinGroupSize:   getEnvInt("COHORT_MIN_GROUP_SIZE", 5),
+
+		DraftTTL:        getEnvDuration("DRAFT_TTL", 7*24*time.Hour),
+		SessionSecret:   getEnv("SESSION_SECRET", ""),
+		SessionTokenTTL: getEnvDuration("SESSION_TOKEN_TTL", 30*24*time.Hour),
+		AdminSessionTTL: getEnvDuration("ADMIN_SESSION_TTL", 12*time.Hour),
+		CORSOrigins:     getEnvList("CORS_ORIGINS", []string{"http://localhost:3000"}),
+
+		RequireConsent:     getEnvBool("REQUIRE_CONSENT", false),
+		GuardianConsentAge: getEnvInt("GUARDIAN_CONSENT_AGE", 16),
+		GuardianCodeTTL:    getEnvDuration("GUARDIAN_CODE_TTL", 14*24*time.Hour),
+
+		RetentionInterval: getEnvDuration("RETENTION_INTERVAL", 24*time.Hour),
+		RetentionDryRun:   getEnvBool("RETENTION_DRY_RUN", false),
+
+		SuppressInvalidInterpretations: getEnvBool("SUPPRESS_INVALID_INTERPRETATIONS", true),
 	}
 }
 
@@ -28,3 +101,37 @@ func getEnv(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	if value, err := time.ParseDuration(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvBool(key string, defaultValue bool) bool {
+	if value, err := strconv.ParseBool(os.Getenv(key)); err == nil {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvList(key string, defaultValue []string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	if len(values) == 0 {
+		return defaultValue
+	}
+	return values
+}
+
+func getEnvInt(key string, defaultValue int) int {
+	if value, err := strconv.Atoi(os.Getenv(key)); err == nil && value > 0 {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,115 @@
+package domain
+
+import "time"
+
+// Role is the access level of an admin user or API key. Each role includes
+// the permissions of the roles below it.
+type Role string
+
+const (
+	// RoleViewer sees aggregate statistics and norm snapshots
+	RoleViewer Role = "viewer"
+	// RoleTeacher additionally runs cohorts and sees their summaries
+	RoleTeacher Role = "teacher"
+	// RoleCounselor additionally sees individual results, raw answers and
+	// the summaries of all cohorts
+	RoleCounselor Role = "counselor"
+	// RoleAdmin additionally manages norm snapshots, users and API keys
+	RoleAdmin Role = "admin"
+)
+
+// roleRanks orders the roles from least to most privileged
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleTeacher:   2,
+	RoleCounselor: 3,
+	RoleAdmin:     4,
+}
+
+// Roles lists all roles from least to most privileged
+var Roles = []Role{RoleViewer, RoleTeacher, RoleCounselor, RoleAdmin}
+
+// Valid reports whether r is a known role
+func (r Role) Valid() bool {
+	return roleRanks[r] > 0
+}
+
+// Allows reports whether r grants the permissions of required
+func (r Role) Allows(required Role) bool {
+	return r.Valid() && roleRanks[r] >= roleRanks[required]
+}
+
+// Authentication methods of a principal
+const (
+	AuthMethodSession = "session"
+	AuthMethodAPIKey  = "api_key"
+)
+
+// AdminUser is a person who can sign in to the admin area
+type AdminUser struct {
+	ID          string     `json:"id"`
+	TenantID    string     `json:"tenant_id"`
+	Username    string     `json:"username"`
+	Role        Role       `json:"role"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+// APIKey grants a role to non-interactive clients. Only a hash of the key is
+// stored; the key itself is returned once when it is created.
+type APIKey struct {
+	ID         string     `json:"id"`
+	TenantID   string     `json:"tenant_id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Role       Role       `json:"role"`
+	Key        string     `json:"key,omitempty"`
+	CreatedBy  string     `json:"created_by,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+}
+
+// Principal is the authenticated caller of an admin route
+type Principal struct {
+	ID       string `json:"id"`
+	TenantID string `json:"tenant_id"`
+	Name     string `json:"name"`
+	Role     Role   `json:"role"`
+	Method   string `json:"method"`
+}
+
+// LoginRequest is the request body for signing in to the admin area
+type LoginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+// LoginResponse is returned after a successful sign-in
+type LoginResponse struct {
+	User      *AdminUser `json:"user"`
+	ExpiresAt time.Time  `json:"expires_at"`
+}
+
+// CreateAdminUserRequest is the request body for creating an admin user
+type CreateAdminUserRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+// GetAdminUsersResponse lists the admin users
+type GetAdminUsersResponse struct {
+	Users []*AdminUser `json:"users"`
+}
+
+// CreateAPIKeyRequest is the request body for creating an API key
+type CreateAPIKeyRequest struct {
+	Name string `json:"name"`
+	Role Role   `json:"role"`
+}
+
+// GetAPIKeysResponse lists the API keys
+type GetAPIKeysResponse struct {
+	Keys []*APIKey `json:"keys"`
+}
@@ -0,0 +1,92 @@
+package domain
+
+import "time"
+
+// Organization is a school or other institution that runs cohorts
+type Organization struct {
+	ID        string    `json:"id"`
+	TenantID  string    `json:"-"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Cohort is a group of respondents, such as a school class, who take an
+// instrument together. Respondents join with the cohort's join code.
+type Cohort struct {
+	ID             string `json:"id"`
+	TenantID       string `json:"-"`
+	OrganizationID string `json:"organization_id"`
+	Name           string `json:"name"`
+	InstrumentID   string `json:"instrument_id"`
+	JoinCode       string `json:"join_code"`
+	// CreatedBy is the ID of the admin user or API key that created the cohort
+	CreatedBy string    `json:"created_by"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CohortInfo is what respondents see of a cohort they are about to join
+type CohortInfo struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	InstrumentID string `json:"instrument_id"`
+}
+
+// CreateOrganizationRequest is the request body for creating an organization
+type CreateOrganizationRequest struct {
+	Name string `json:"name"`
+}
+
+// GetOrganizationsResponse lists the organizations
+type GetOrganizationsResponse struct {
+	Organizations []*Organization `json:"organizations"`
+}
+
+// CreateCohortRequest is the request body for creating a cohort
+type CreateCohortRequest struct {
+	OrganizationID string `json:"organization_id"`
+	Name           string `json:"name"`
+	InstrumentID   string `json:"instrument_id,omitempty"` // Optional: defaults to "ipip-50"
+}
+
+// GetCohortsResponse lists cohorts
+type GetCohortsResponse struct {
+	Cohorts []*Cohort `json:"cohorts"`
+}
+
+// SetCohortConsentRequest is the request body for changing whether a
+// cohort's teacher may see a result's interpretations
+type SetCohortConsentRequest struct {
+	Consent bool `json:"consent"`
+}
+
+// ScoreDistribution summarizes how a group scored on one scale. Bins count
+// scores in equal-width bands of the 0-100 range, lowest band first.
+type ScoreDistribution struct {
+	Mean float64 `json:"mean"`
+	SD   float64 `json:"sd"`
+	Bins []int   `json:"bins"`
+}
+
+// CohortMember is a consenting respondent's result as shown to the teacher
+type CohortMember struct {
+	ResultID        string            `json:"result_id"`
+	Scores          map[Trait]float64 `json:"scores"`
+	Interpretations map[Trait]string  `json:"interpretations,omitempty"`
+	CreatedAt       time.Time         `json:"created_at"`
+}
+
+// CohortSummary is the anonymized overview of a cohort. Distributions are
+// only reported once at least MinGroupSize valid results are in, and
+// individual results only for respondents who consented.
+type CohortSummary struct {
+	Cohort       *Cohort `json:"cohort"`
+	MinGroupSize int     `json:"min_group_size"`
+	// Completed counts submitted results, InProgress unfinished draft sessions
+	Completed  int `json:"completed"`
+	InProgress int `json:"in_progress"`
+	// Valid counts the results not flagged invalid, which the distributions use
+	Valid         int                         `json:"valid"`
+	Suppressed    bool                        `json:"suppressed"`
+	Distributions map[Trait]ScoreDistribution `json:"distributions,omitempty"`
+	Consented     []*CohortMember             `json:"consented"`
+}
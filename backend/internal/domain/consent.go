@@ -0,0 +1,126 @@
+package domain
+
+import (
+	"slices"
+	"time"
+)
+
+// Consent purposes a respondent can agree to
+const (
+	// ConsentPurposeStorage covers storing answers, results and draft sessions
+	ConsentPurposeStorage = "storage"
+	// ConsentPurposeInterpretation covers sending scores to the external
+	// language model that writes the interpretations
+	ConsentPurposeInterpretation = "ai_interpretation"
+)
+
+// ConsentPurposes lists all consent purposes
+var ConsentPurposes = []string{ConsentPurposeStorage, ConsentPurposeInterpretation}
+
+// Consent record statuses
+const (
+	// ConsentStatusActive means the consent is in effect
+	ConsentStatusActive = "active"
+	// ConsentStatusPendingGuardian means a guardian has yet to confirm the consent
+	ConsentStatusPendingGuardian = "pending_guardian"
+	// ConsentStatusExpired means the guardian did not confirm in time
+	ConsentStatusExpired = "expired"
+	// ConsentStatusWithdrawn means the consent was withdrawn or replaced
+	ConsentStatusWithdrawn = "withdrawn"
+)
+
+// ConsentText is a versioned wording of the consent form in one language
+type ConsentText struct {
+	Language string `json:"language"`
+	Version  string `json:"version"`
+	Title    string `json:"title"`
+	// Purposes explains each consent purpose to the respondent
+	Purposes map[string]string `json:"purposes"`
+	// Guardian is shown to guardians asked to confirm a minor's consent
+	Guardian string `json:"guardian"`
+}
+
+// Key identifies a consent text version as "language@version"
+func (t *ConsentText) Key() string {
+	return t.Language + "@" + t.Version
+}
+
+// ConsentRecord is what a session's respondent agreed to, in which wording,
+// and whether a guardian confirmed it. Records are never changed except to
+// confirm or withdraw them; withdrawing some purposes replaces the record with
+// one for the remaining purposes.
+type ConsentRecord struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"-"`
+	SessionID string `json:"session_id,omitempty"`
+	// Text is the key of the consent text the respondent was shown
+	Text     string   `json:"text"`
+	Purposes []string `json:"purposes"`
+	Age      int      `json:"age"`
+	// GuardianRequired is set for respondents below the guardian consent age
+	GuardianRequired    bool       `json:"guardian_required"`
+	GuardianExpiresAt   *time.Time `json:"guardian_expires_at,omitempty"`
+	GuardianConfirmedAt *time.Time `json:"guardian_confirmed_at,omitempty"`
+	// GuardianCode is returned once, when a guardian's confirmation is required
+	GuardianCode string     `json:"guardian_code,omitempty"`
+	CreatedAt    time.Time  `json:"created_at"`
+	WithdrawnAt  *time.Time `json:"withdrawn_at,omitempty"`
+	Status       string     `json:"status"`
+}
+
+// StatusAt returns the status of a consent record at the given time
+func (c *ConsentRecord) StatusAt(now time.Time) string {
+	switch {
+	case c.WithdrawnAt != nil:
+		return ConsentStatusWithdrawn
+	case !c.GuardianRequired || c.GuardianConfirmedAt != nil:
+		return ConsentStatusActive
+	case c.GuardianExpiresAt != nil && !now.Before(*c.GuardianExpiresAt):
+		return ConsentStatusExpired
+	default:
+		return ConsentStatusPendingGuardian
+	}
+}
+
+// Allows reports whether a consent record is in effect for a purpose at the given time
+func (c *ConsentRecord) Allows(purpose string, now time.Time) bool {
+	return c.StatusAt(now) == ConsentStatusActive && slices.Contains(c.Purposes, purpose)
+}
+
+// GetConsentTextResponse is the consent form shown before the questionnaire
+type GetConsentTextResponse struct {
+	ConsentText
+	Key string `json:"key"`
+	// GuardianConsentAge is the age below which a guardian has to confirm
+	GuardianConsentAge int `json:"guardian_consent_age"`
+}
+
+// GrantConsentRequest is the request body for recording a respondent's consent
+type GrantConsentRequest struct {
+	Text     string   `json:"text,omitempty"`     // Optional: key of the text shown; the current one for language when omitted
+	Language string   `json:"language,omitempty"` // Optional: used when text is omitted
+	Purposes []string `json:"purposes"`
+	Age      int      `json:"age"`
+}
+
+// WithdrawConsentRequest is the request body for withdrawing consent
+type WithdrawConsentRequest struct {
+	Purposes []string `json:"purposes,omitempty"` // Optional: all purposes when omitted
+}
+
+// WithdrawConsentResponse reports a withdrawal and the data it deleted
+type WithdrawConsentResponse struct {
+	// Consent is the record for the remaining purposes, if any
+	Consent                *ConsentRecord `json:"consent,omitempty"`
+	Withdrawn              []string       `json:"withdrawn"`
+	DeletedResults         int            `json:"deleted_results"`
+	DeletedDrafts          int            `json:"deleted_drafts"`
+	DeletedInterpretations int            `json:"deleted_interpretations"`
+}
+
+// GuardianConsentResponse is what a guardian's confirmation code shows: the
+// consent the minor gave and the text it was given in
+type GuardianConsentResponse struct {
+	Consent *ConsentRecord `json:"consent"`
+	Text    *ConsentText   `json:"text"`
+}
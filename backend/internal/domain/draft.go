@@ -0,0 +1,98 @@
+package domain
+
+import "time"
+
+// Draft session statuses
+const (
+	DraftStatusInProgress = "in_progress"
+	DraftStatusCompleted  = "completed"
+)
+
+// DraftSession holds the answers of a questionnaire that has not been
+// submitted yet, so it can be resumed later or on another device
+type DraftSession struct {
+	ID           string `json:"id"`
+	TenantID     string `json:"-"`
+	ResumeCode   string `json:"resume_code"`
+	SessionID    string `json:"session_id"`
+	InstrumentID string `json:"instrument_id"`
+	Language     string `json:"language,omitempty"`
+	Translation  string `json:"translation,omitempty"`
+	Age          int    `json:"age,omitempty"`
+	Country      string `json:"country,omitempty"`
+	// CohortID and CohortConsent are passed on to the result when finalized
+	CohortID      string `json:"cohort_id,omitempty"`
+	CohortConsent bool   `json:"cohort_consent,omitempty"`
+	Status        string `json:"status"`
+	// ResultID is set once the draft has been finalized
+	ResultID  string        `json:"result_id,omitempty"`
+	Answers   []Answer      `json:"answers"`
+	Progress  DraftProgress `json:"progress"`
+	CreatedAt time.Time     `json:"created_at"`
+	UpdatedAt time.Time     `json:"updated_at"`
+	ExpiresAt time.Time     `json:"expires_at"`
+	// Token is returned once, when the draft started a new session
+	Token *SessionToken `json:"token,omitempty"`
+}
+
+// DraftProgress describes how far a draft session has got
+type DraftProgress struct {
+	Answered int     `json:"answered"`
+	Total    int     `json:"total"`
+	Percent  float64 `json:"percent"`
+	// NextQuestionID is the first unanswered item in questionnaire order
+	NextQuestionID int `json:"next_question_id,omitempty"`
+}
+
+// CreateDraftSessionRequest is the request body for starting a draft session
+type CreateDraftSessionRequest struct {
+	InstrumentID string `json:"instrument_id,omitempty"` // Optional: defaults to "ipip-50"
+	Language     string `json:"language,omitempty"`
+	Translation  string `json:"translation,omitempty"`
+	Age          int    `json:"age,omitempty"`
+	Country      string `json:"country,omitempty"`
+	// Optional: join code of the cohort the respondent takes part in
+	CohortCode    string `json:"cohort_code,omitempty"`
+	CohortConsent bool   `json:"cohort_consent,omitempty"`
+	// CohortID is resolved from CohortCode, never taken from the body
+	CohortID string `json:"-"`
+}
+
+// SaveDraftAnswersRequest is the request body for saving partial answers.
+// Answers replace earlier answers to the same questions.
+type SaveDraftAnswersRequest struct {
+	Answers []Answer `json:"answers"`
+}
+
+// DraftSummary is the per-session data draft statistics are computed from
+type DraftSummary struct {
+	InstrumentID string
+	Status       string
+	Answered     int
+	UpdatedAt    time.Time
+	ExpiresAt    time.Time
+}
+
+// DraftDropOff counts abandoned sessions that stopped after a number of answers
+type DraftDropOff struct {
+	Answered int `json:"answered"`
+	Count    int `json:"count"`
+}
+
+// DraftSessionStats summarises draft sessions of one instrument
+type DraftSessionStats struct {
+	InstrumentID string `json:"instrument_id"`
+	Total        int    `json:"total"`
+	InProgress   int    `json:"in_progress"`
+	Completed    int    `json:"completed"`
+	// Abandoned sessions are unfinished and idle for longer than the abandonment window
+	Abandoned      int            `json:"abandoned"`
+	CompletionRate float64        `json:"completion_rate"`
+	DropOffs       []DraftDropOff `json:"drop_offs"`
+}
+
+// GetDraftStatsResponse lists draft session statistics per instrument
+type GetDraftStatsResponse struct {
+	AbandonAfterHours float64             `json:"abandon_after_hours"`
+	Instruments       []DraftSessionStats `json:"instruments"`
+}
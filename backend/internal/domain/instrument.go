@@ -0,0 +1,201 @@
+package domain
+
+// Instrument identifiers
+const (
+	InstrumentIPIP50     = "ipip-50"
+	InstrumentIPIPNEO120 = "ipip-neo-120"
+	InstrumentBFI2       = "bfi-2"
+	InstrumentONETIP60   = "onet-ip-60"
+)
+
+// InstrumentKind distinguishes what an instrument measures
+type InstrumentKind string
+
+const (
+	InstrumentKindPersonality InstrumentKind = "personality"
+	InstrumentKindInterests   InstrumentKind = "interests"
+)
+
+// DefaultInstrumentID is used when a client does not request a specific instrument
+const DefaultInstrumentID = InstrumentIPIP50
+
+// Scale is a dimension an instrument produces a score for
+type Scale struct {
+	ID   Trait  `json:"id"`
+	Name string `json:"name"`
+}
+
+// Facet is a sub-dimension of a scale, scored from a subset of its items
+type Facet struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Trait Trait  `json:"trait"`
+	// Inverted marks facets named after the opposite pole of their trait,
+	// e.g. Anxiety within Emotional Stability
+	Inverted bool `json:"inverted,omitempty"`
+}
+
+// Instrument describes a questionnaire: its items, their scoring keys
+// (each item's Trait and Reversed flag) and the scales it measures
+type Instrument struct {
+	ID       string         `json:"id"`
+	Version  string         `json:"version"`
+	Kind     InstrumentKind `json:"kind"`
+	Name     string         `json:"name"`
+	Source   string         `json:"source"`
+	License  string         `json:"license"`
+	MinValue int            `json:"min_value"`
+	MaxValue int            `json:"max_value"`
+	Scales   []Scale        `json:"scales"`
+	Facets   []Facet        `json:"facets,omitempty"`
+	Items    []Question     `json:"-"`
+	// Antonyms lists item pairs with opposite meaning, used to detect
+	// inconsistent responding; see AntonymPairs
+	Antonyms []ItemPair `json:"-"`
+}
+
+// ItemPair references two items of an instrument by ID
+type ItemPair [2]int
+
+// ItemCount returns the number of items in the instrument
+func (i *Instrument) ItemCount() int {
+	return len(i.Items)
+}
+
+// FacetsOf returns the facets belonging to a trait, in instrument order
+func (i *Instrument) FacetsOf(trait Trait) []Facet {
+	var facets []Facet
+	for _, facet := range i.Facets {
+		if facet.Trait == trait {
+			facets = append(facets, facet)
+		}
+	}
+	return facets
+}
+
+// AntonymPairs returns the instrument's psychometric antonyms. Without a
+// curated list, positively and negatively keyed items of the same facet (or
+// scale, for instruments without facets) are paired in item order.
+func (i *Instrument) AntonymPairs() []ItemPair {
+	if len(i.Antonyms) > 0 {
+		return i.Antonyms
+	}
+
+	positive := make(map[string][]int)
+	reversed := make(map[string][]int)
+	seen := make(map[string]bool)
+	var groups []string
+	for _, item := range i.Items {
+		group := item.Facet
+		if group == "" {
+			group = string(item.Trait)
+		}
+		if !seen[group] {
+			seen[group] = true
+			groups = append(groups, group)
+		}
+		if item.Reversed {
+			reversed[group] = append(reversed[group], item.ID)
+		} else {
+			positive[group] = append(positive[group], item.ID)
+		}
+	}
+
+	var pairs []ItemPair
+	for _, group := range groups {
+		for k := 0; k < len(positive[group]) && k < len(reversed[group]); k++ {
+			pairs = append(pairs, ItemPair{positive[group][k], reversed[group][k]})
+		}
+	}
+	return pairs
+}
+
+// InstrumentSummary describes an instrument without its items
+type InstrumentSummary struct {
+	*Instrument
+	ItemCount int `json:"item_count"`
+}
+
+// GetInstrumentsResponse lists the available instruments
+type GetInstrumentsResponse struct {
+	Instruments []InstrumentSummary `json:"instruments"`
+}
+
+// GetInstrumentQuestionsResponse returns the items of a single instrument
+type GetInstrumentQuestionsResponse struct {
+	InstrumentID      string     `json:"instrument_id"`
+	InstrumentVersion string     `json:"instrument_version"`
+	MinValue          int        `json:"min_value"`
+	MaxValue          int        `json:"max_value"`
+	Questions         []Question `json:"questions"`
+	Language          string     `json:"language"`
+	// Translation identifies the wording served; send it back on submission
+	Translation string           `json:"translation,omitempty"`
+	Options     []ResponseOption `json:"options,omitempty"`
+}
+
+// bigFiveScales are the five scales shared by all bundled instruments
+func bigFiveScales() []Scale {
+	return []Scale{
+		{ID: TraitExtraversion, Name: "Extraversion"},
+		{ID: TraitAgreeableness, Name: "Agreeableness"},
+		{ID: TraitConscientiousness, Name: "Conscientiousness"},
+		{ID: TraitEmotionalStability, Name: "Emotional Stability"},
+		{ID: TraitOpenness, Name: "Openness"},
+	}
+}
+
+// IPIP50 returns the IPIP Big Five 50-item questionnaire
+func IPIP50() *Instrument {
+	return &Instrument{
+		ID:       InstrumentIPIP50,
+		Version:  "1.0",
+		Kind:     InstrumentKindPersonality,
+		Name:     "IPIP Big-Five Factor Markers (50 items)",
+		Source:   "International Personality Item Pool (https://ipip.ori.org/)",
+		License:  "Public Domain",
+		MinValue: 1,
+		MaxValue: 5,
+		Scales:   bigFiveScales(),
+		Items:    ipip50Items(),
+		Antonyms: ipip50Antonyms(),
+	}
+}
+
+// ipip50Antonyms pairs IPIP-50 items describing opposite behaviour
+func ipip50Antonyms() []ItemPair {
+	return []ItemPair{
+		{21, 26}, // Start conversations / Have little to say
+		{11, 46}, // Feel comfortable around people / Am quiet around strangers
+		{41, 36}, // Don't mind being the center of attention / Don't like to draw attention to myself
+		{7, 32},  // Am interested in people / Am not really interested in others
+		{17, 2},  // Sympathize with others' feelings / Feel little concern for others
+		{33, 8},  // Like order / Leave my belongings around
+		{3, 18},  // Am always prepared / Make a mess of things
+		{9, 24},  // Am relaxed most of the time / Am easily disturbed
+		{19, 49}, // Seldom feel blue / Often feel blue
+		{15, 30}, // Have a vivid imagination / Do not have a good imagination
+	}
+}
+
+// BuiltinInstruments returns all instruments shipped with the application
+func BuiltinInstruments() []*Instrument {
+	return []*Instrument{
+		IPIP50(),
+		IPIPNEO120(),
+		BFI2(),
+		ONETInterestProfiler(),
+	}
+}
+
+// ScaleName returns the English name of a Big Five or RIASEC scale
+func ScaleName(trait Trait) string {
+	for _, scales := range [][]Scale{bigFiveScales(), riasecScales()} {
+		for _, scale := range scales {
+			if scale.ID == trait {
+				return scale.Name
+			}
+		}
+	}
+	return string(trait)
+}
@@ -0,0 +1,89 @@
+package domain
+
+// BFI2 returns the 60-item Big Five Inventory-2
+func BFI2() *Instrument {
+	return &Instrument{
+		ID:       InstrumentBFI2,
+		Version:  "1.0",
+		Kind:     InstrumentKindPersonality,
+		Name:     "Big Five Inventory-2 (BFI-2)",
+		Source:   "Soto & John (2017), https://www.colby.edu/psych/personality-lab/",
+		License:  "Free for non-commercial research and educational use",
+		MinValue: 1,
+		MaxValue: 5,
+		Scales:   bigFiveScales(),
+		Items:    bfi2Items(),
+	}
+}
+
+// bfi2Items returns the BFI-2 items in administration order. Each item
+// completes the stem "I am someone who...". Negative Emotionality items
+// are keyed towards Emotional Stability.
+func bfi2Items() []Question {
+	return []Question{
+		// Items 1-15
+		{ID: 1, Text: "Is outgoing, sociable.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 2, Text: "Is compassionate, has a soft heart.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 3, Text: "Tends to be disorganized.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 4, Text: "Is relaxed, handles stress well.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 5, Text: "Has few artistic interests.", Trait: TraitOpenness, Reversed: true},
+		{ID: 6, Text: "Has an assertive personality.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 7, Text: "Is respectful, treats others with respect.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 8, Text: "Tends to be lazy.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 9, Text: "Stays optimistic after experiencing a setback.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 10, Text: "Is curious about many different things.", Trait: TraitOpenness, Reversed: false},
+		{ID: 11, Text: "Rarely feels excited or eager.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 12, Text: "Tends to find fault with others.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 13, Text: "Is dependable, steady.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 14, Text: "Is moody, has up and down mood swings.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 15, Text: "Is inventive, finds clever ways to do things.", Trait: TraitOpenness, Reversed: false},
+		// Items 16-30
+		{ID: 16, Text: "Tends to be quiet.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 17, Text: "Feels little sympathy for others.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 18, Text: "Is systematic, likes to keep things in order.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 19, Text: "Can be tense.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 20, Text: "Is fascinated by art, music, or literature.", Trait: TraitOpenness, Reversed: false},
+		{ID: 21, Text: "Is dominant, acts as a leader.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 22, Text: "Starts arguments with others.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 23, Text: "Has difficulty getting started on tasks.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 24, Text: "Feels secure, comfortable with self.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 25, Text: "Avoids intellectual, philosophical discussions.", Trait: TraitOpenness, Reversed: true},
+		{ID: 26, Text: "Is less active than other people.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 27, Text: "Has a forgiving nature.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 28, Text: "Can be somewhat careless.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 29, Text: "Is emotionally stable, not easily upset.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 30, Text: "Has little creativity.", Trait: TraitOpenness, Reversed: true},
+		// Items 31-45
+		{ID: 31, Text: "Is sometimes shy, introverted.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 32, Text: "Is helpful and unselfish with others.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 33, Text: "Keeps things neat and tidy.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 34, Text: "Worries a lot.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 35, Text: "Values art and beauty.", Trait: TraitOpenness, Reversed: false},
+		{ID: 36, Text: "Finds it hard to influence people.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 37, Text: "Is sometimes rude to others.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 38, Text: "Is efficient, gets things done.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 39, Text: "Often feels sad.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 40, Text: "Is complex, a deep thinker.", Trait: TraitOpenness, Reversed: false},
+		{ID: 41, Text: "Is full of energy.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 42, Text: "Is suspicious of others' intentions.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 43, Text: "Is reliable, can always be counted on.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 44, Text: "Keeps their emotions under control.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 45, Text: "Has difficulty imagining things.", Trait: TraitOpenness, Reversed: true},
+		// Items 46-60
+		{ID: 46, Text: "Is talkative.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 47, Text: "Can be cold and uncaring.", Trait: TraitAgreeableness, Reversed: true},
+		{ID: 48, Text: "Leaves a mess, doesn't clean up.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 49, Text: "Rarely feels anxious or afraid.", Trait: TraitEmotionalStability, Reversed: false},
+		{ID: 50, Text: "Thinks poetry and plays are boring.", Trait: TraitOpenness, Reversed: true},
+		{ID: 51, Text: "Prefers to have others take charge.", Trait: TraitExtraversion, Reversed: true},
+		{ID: 52, Text: "Is polite, courteous to others.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 53, Text: "Is persistent, works until the task is finished.", Trait: TraitConscientiousness, Reversed: false},
+		{ID: 54, Text: "Tends to feel depressed, blue.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 55, Text: "Has little interest in abstract ideas.", Trait: TraitOpenness, Reversed: true},
+		{ID: 56, Text: "Shows a lot of enthusiasm.", Trait: TraitExtraversion, Reversed: false},
+		{ID: 57, Text: "Assumes the best about people.", Trait: TraitAgreeableness, Reversed: false},
+		{ID: 58, Text: "Sometimes behaves irresponsibly.", Trait: TraitConscientiousness, Reversed: true},
+		{ID: 59, Text: "Is temperamental, gets emotional easily.", Trait: TraitEmotionalStability, Reversed: true},
+		{ID: 60, Text: "Is original, comes up with new ideas.", Trait: TraitOpenness, Reversed: false},
+	}
+}
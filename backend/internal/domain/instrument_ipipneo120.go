@@ -0,0 +1,192 @@
+package domain
+
+// IPIPNEO120 returns the 120-item IPIP-NEO short form
+func IPIPNEO120() *Instrument {
+	return &Instrument{
+		ID:       InstrumentIPIPNEO120,
+		Version:  "1.0",
+		Kind:     InstrumentKindPersonality,
+		Name:     "IPIP-NEO-120",
+		Source:   "Johnson (2014), International Personality Item Pool (https://ipip.ori.org/)",
+		License:  "Public Domain",
+		MinValue: 1,
+		MaxValue: 5,
+		Scales:   bigFiveScales(),
+		Facets:   ipipNEO120Facets(),
+		Items:    ipipNEO120Items(),
+	}
+}
+
+// ipipNEO120Facets returns the 30 facet scales, six per domain. The
+// Neuroticism facets keep their usual names and are therefore inverted
+// relative to Emotional Stability.
+func ipipNEO120Facets() []Facet {
+	return []Facet{
+		{ID: "anxiety", Name: "Anxiety", Trait: TraitEmotionalStability, Inverted: true},
+		{ID: "anger", Name: "Anger", Trait: TraitEmotionalStability, Inverted: true},
+		{ID: "depression", Name: "Depression", Trait: TraitEmotionalStability, Inverted: true},
+		{ID: "self_consciousness", Name: "Self-Consciousness", Trait: TraitEmotionalStability, Inverted: true},
+		{ID: "immoderation", Name: "Immoderation", Trait: TraitEmotionalStability, Inverted: true},
+		{ID: "vulnerability", Name: "Vulnerability", Trait: TraitEmotionalStability, Inverted: true},
+
+		{ID: "friendliness", Name: "Friendliness", Trait: TraitExtraversion},
+		{ID: "gregariousness", Name: "Gregariousness", Trait: TraitExtraversion},
+		{ID: "assertiveness", Name: "Assertiveness", Trait: TraitExtraversion},
+		{ID: "activity_level", Name: "Activity Level", Trait: TraitExtraversion},
+		{ID: "excitement_seeking", Name: "Excitement-Seeking", Trait: TraitExtraversion},
+		{ID: "cheerfulness", Name: "Cheerfulness", Trait: TraitExtraversion},
+
+		{ID: "imagination", Name: "Imagination", Trait: TraitOpenness},
+		{ID: "artistic_interests", Name: "Artistic Interests", Trait: TraitOpenness},
+		{ID: "emotionality", Name: "Emotionality", Trait: TraitOpenness},
+		{ID: "adventurousness", Name: "Adventurousness", Trait: TraitOpenness},
+		{ID: "intellect", Name: "Intellect", Trait: TraitOpenness},
+		{ID: "liberalism", Name: "Liberalism", Trait: TraitOpenness},
+
+		{ID: "trust", Name: "Trust", Trait: TraitAgreeableness},
+		{ID: "morality", Name: "Morality", Trait: TraitAgreeableness},
+		{ID: "altruism", Name: "Altruism", Trait: TraitAgreeableness},
+		{ID: "cooperation", Name: "Cooperation", Trait: TraitAgreeableness},
+		{ID: "modesty", Name: "Modesty", Trait: TraitAgreeableness},
+		{ID: "sympathy", Name: "Sympathy", Trait: TraitAgreeableness},
+
+		{ID: "self_efficacy", Name: "Self-Efficacy", Trait: TraitConscientiousness},
+		{ID: "orderliness", Name: "Orderliness", Trait: TraitConscientiousness},
+		{ID: "dutifulness", Name: "Dutifulness", Trait: TraitConscientiousness},
+		{ID: "achievement_striving", Name: "Achievement-Striving", Trait: TraitConscientiousness},
+		{ID: "self_discipline", Name: "Self-Discipline", Trait: TraitConscientiousness},
+		{ID: "cautiousness", Name: "Cautiousness", Trait: TraitConscientiousness},
+	}
+}
+
+// ipipNEO120Items returns the IPIP-NEO-120 items in administration order.
+// Neuroticism items are keyed towards Emotional Stability, so items that
+// describe anxiety, anger etc. are reversed.
+func ipipNEO120Items() []Question {
+	return []Question{
+		// Items 1-30: Round 1 of all 30 facets
+		{ID: 1, Text: "Worry about things.", Trait: TraitEmotionalStability, Facet: "anxiety", Reversed: true},
+		{ID: 2, Text: "Make friends easily.", Trait: TraitExtraversion, Facet: "friendliness", Reversed: false},
+		{ID: 3, Text: "Have a vivid imagination.", Trait: TraitOpenness, Facet: "imagination", Reversed: false},
+		{ID: 4, Text: "Trust others.", Trait: TraitAgreeableness, Facet: "trust", Reversed: false},
+		{ID: 5, Text: "Complete tasks successfully.", Trait: TraitConscientiousness, Facet: "self_efficacy", Reversed: false},
+		{ID: 6, Text: "Get angry easily.", Trait: TraitEmotionalStability, Facet: "anger", Reversed: true},
+		{ID: 7, Text: "Love large parties.", Trait: TraitExtraversion, Facet: "gregariousness", Reversed: false},
+		{ID: 8, Text: "Believe in the importance of art.", Trait: TraitOpenness, Facet: "artistic_interests", Reversed: false},
+		{ID: 9, Text: "Use others for my own ends.", Trait: TraitAgreeableness, Facet: "morality", Reversed: true},
+		{ID: 10, Text: "Like to tidy up.", Trait: TraitConscientiousness, Facet: "orderliness", Reversed: false},
+		{ID: 11, Text: "Often feel blue.", Trait: TraitEmotionalStability, Facet: "depression", Reversed: true},
+		{ID: 12, Text: "Take charge.", Trait: TraitExtraversion, Facet: "assertiveness", Reversed: false},
+		{ID: 13, Text: "Experience my emotions intensely.", Trait: TraitOpenness, Facet: "emotionality", Reversed: false},
+		{ID: 14, Text: "Love to help others.", Trait: TraitAgreeableness, Facet: "altruism", Reversed: false},
+		{ID: 15, Text: "Keep my promises.", Trait: TraitConscientiousness, Facet: "dutifulness", Reversed: false},
+		{ID: 16, Text: "Find it difficult to approach others.", Trait: TraitEmotionalStability, Facet: "self_consciousness", Reversed: true},
+		{ID: 17, Text: "Am always busy.", Trait: TraitExtraversion, Facet: "activity_level", Reversed: false},
+		{ID: 18, Text: "Prefer variety to routine.", Trait: TraitOpenness, Facet: "adventurousness", Reversed: false},
+		{ID: 19, Text: "Love a good fight.", Trait: TraitAgreeableness, Facet: "cooperation", Reversed: true},
+		{ID: 20, Text: "Do more than what's expected of me.", Trait: TraitConscientiousness, Facet: "achievement_striving", Reversed: false},
+		{ID: 21, Text: "Go on binges.", Trait: TraitEmotionalStability, Facet: "immoderation", Reversed: true},
+		{ID: 22, Text: "Love excitement.", Trait: TraitExtraversion, Facet: "excitement_seeking", Reversed: false},
+		{ID: 23, Text: "Love to read challenging material.", Trait: TraitOpenness, Facet: "intellect", Reversed: false},
+		{ID: 24, Text: "Believe that I am better than others.", Trait: TraitAgreeableness, Facet: "modesty", Reversed: true},
+		{ID: 25, Text: "Am always prepared.", Trait: TraitConscientiousness, Facet: "self_discipline", Reversed: false},
+		{ID: 26, Text: "Panic easily.", Trait: TraitEmotionalStability, Facet: "vulnerability", Reversed: true},
+		{ID: 27, Text: "Radiate joy.", Trait: TraitExtraversion, Facet: "cheerfulness", Reversed: false},
+		{ID: 28, Text: "Tend to vote for liberal political candidates.", Trait: TraitOpenness, Facet: "liberalism", Reversed: false},
+		{ID: 29, Text: "Sympathize with the homeless.", Trait: TraitAgreeableness, Facet: "sympathy", Reversed: false},
+		{ID: 30, Text: "Jump into things without thinking.", Trait: TraitConscientiousness, Facet: "cautiousness", Reversed: true},
+		// Items 31-60: Round 2 of all 30 facets
+		{ID: 31, Text: "Fear for the worst.", Trait: TraitEmotionalStability, Facet: "anxiety", Reversed: true},
+		{ID: 32, Text: "Feel comfortable around people.", Trait: TraitExtraversion, Facet: "friendliness", Reversed: false},
+		{ID: 33, Text: "Enjoy wild flights of fantasy.", Trait: TraitOpenness, Facet: "imagination", Reversed: false},
+		{ID: 34, Text: "Believe that others have good intentions.", Trait: TraitAgreeableness, Facet: "trust", Reversed: false},
+		{ID: 35, Text: "Excel in what I do.", Trait: TraitConscientiousness, Facet: "self_efficacy", Reversed: false},
+		{ID: 36, Text: "Get irritated easily.", Trait: TraitEmotionalStability, Facet: "anger", Reversed: true},
+		{ID: 37, Text: "Talk to a lot of different people at parties.", Trait: TraitExtraversion, Facet: "gregariousness", Reversed: false},
+		{ID: 38, Text: "See beauty in things that others might not notice.", Trait: TraitOpenness, Facet: "artistic_interests", Reversed: false},
+		{ID: 39, Text: "Cheat to get ahead.", Trait: TraitAgreeableness, Facet: "morality", Reversed: true},
+		{ID: 40, Text: "Often forget to put things back in their proper place.", Trait: TraitConscientiousness, Facet: "orderliness", Reversed: true},
+		{ID: 41, Text: "Dislike myself.", Trait: TraitEmotionalStability, Facet: "depression", Reversed: true},
+		{ID: 42, Text: "Try to lead others.", Trait: TraitExtraversion, Facet: "assertiveness", Reversed: false},
+		{ID: 43, Text: "Feel others' emotions.", Trait: TraitOpenness, Facet: "emotionality", Reversed: false},
+		{ID: 44, Text: "Am concerned about others.", Trait: TraitAgreeableness, Facet: "altruism", Reversed: false},
+		{ID: 45, Text: "Tell the truth.", Trait: TraitConscientiousness, Facet: "dutifulness", Reversed: false},
+		{ID: 46, Text: "Am afraid to draw attention to myself.", Trait: TraitEmotionalStability, Facet: "self_consciousness", Reversed: true},
+		{ID: 47, Text: "Am always on the go.", Trait: TraitExtraversion, Facet: "activity_level", Reversed: false},
+		{ID: 48, Text: "Prefer to stick with things that I know.", Trait: TraitOpenness, Facet: "adventurousness", Reversed: true},
+		{ID: 49, Text: "Yell at people.", Trait: TraitAgreeableness, Facet: "cooperation", Reversed: true},
+		{ID: 50, Text: "Work hard.", Trait: TraitConscientiousness, Facet: "achievement_striving", Reversed: false},
+		{ID: 51, Text: "Rarely overindulge.", Trait: TraitEmotionalStability, Facet: "immoderation", Reversed: false},
+		{ID: 52, Text: "Seek adventure.", Trait: TraitExtraversion, Facet: "excitement_seeking", Reversed: false},
+		{ID: 53, Text: "Avoid philosophical discussions.", Trait: TraitOpenness, Facet: "intellect", Reversed: true},
+		{ID: 54, Text: "Think highly of myself.", Trait: TraitAgreeableness, Facet: "modesty", Reversed: true},
+		{ID: 55, Text: "Carry out my plans.", Trait: TraitConscientiousness, Facet: "self_discipline", Reversed: false},
+		{ID: 56, Text: "Become overwhelmed by events.", Trait: TraitEmotionalStability, Facet: "vulnerability", Reversed: true},
+		{ID: 57, Text: "Have a lot of fun.", Trait: TraitExtraversion, Facet: "cheerfulness", Reversed: false},
+		{ID: 58, Text: "Believe that there is no absolute right or wrong.", Trait: TraitOpenness, Facet: "liberalism", Reversed: false},
+		{ID: 59, Text: "Feel sympathy for those who are worse off than myself.", Trait: TraitAgreeableness, Facet: "sympathy", Reversed: false},
+		{ID: 60, Text: "Make rash decisions.", Trait: TraitConscientiousness, Facet: "cautiousness", Reversed: true},
+		// Items 61-90: Round 3 of all 30 facets
+		{ID: 61, Text: "Am afraid of many things.", Trait: TraitEmotionalStability, Facet: "anxiety", Reversed: true},
+		{ID: 62, Text: "Avoid contacts with others.", Trait: TraitExtraversion, Facet: "friendliness", Reversed: true},
+		{ID: 63, Text: "Love to daydream.", Trait: TraitOpenness, Facet: "imagination", Reversed: false},
+		{ID: 64, Text: "Trust what people say.", Trait: TraitAgreeableness, Facet: "trust", Reversed: false},
+		{ID: 65, Text: "Handle tasks smoothly.", Trait: TraitConscientiousness, Facet: "self_efficacy", Reversed: false},
+		{ID: 66, Text: "Lose my temper.", Trait: TraitEmotionalStability, Facet: "anger", Reversed: true},
+		{ID: 67, Text: "Prefer to be alone.", Trait: TraitExtraversion, Facet: "gregariousness", Reversed: true},
+		{ID: 68, Text: "Do not like poetry.", Trait: TraitOpenness, Facet: "artistic_interests", Reversed: true},
+		{ID: 69, Text: "Take advantage of others.", Trait: TraitAgreeableness, Facet: "morality", Reversed: true},
+		{ID: 70, Text: "Leave a mess in my room.", Trait: TraitConscientiousness, Facet: "orderliness", Reversed: true},
+		{ID: 71, Text: "Am often down in the dumps.", Trait: TraitEmotionalStability, Facet: "depression", Reversed: true},
+		{ID: 72, Text: "Take control of things.", Trait: TraitExtraversion, Facet: "assertiveness", Reversed: false},
+		{ID: 73, Text: "Rarely notice my emotional reactions.", Trait: TraitOpenness, Facet: "emotionality", Reversed: true},
+		{ID: 74, Text: "Am indifferent to the feelings of others.", Trait: TraitAgreeableness, Facet: "altruism", Reversed: true},
+		{ID: 75, Text: "Break rules.", Trait: TraitConscientiousness, Facet: "dutifulness", Reversed: true},
+		{ID: 76, Text: "Only feel comfortable with friends.", Trait: TraitEmotionalStability, Facet: "self_consciousness", Reversed: true},
+		{ID: 77, Text: "Do a lot in my spare time.", Trait: TraitExtraversion, Facet: "activity_level", Reversed: false},
+		{ID: 78, Text: "Dislike changes.", Trait: TraitOpenness, Facet: "adventurousness", Reversed: true},
+		{ID: 79, Text: "Insult people.", Trait: TraitAgreeableness, Facet: "cooperation", Reversed: true},
+		{ID: 80, Text: "Put little time and effort into my work.", Trait: TraitConscientiousness, Facet: "achievement_striving", Reversed: true},
+		{ID: 81, Text: "Easily resist temptations.", Trait: TraitEmotionalStability, Facet: "immoderation", Reversed: false},
+		{ID: 82, Text: "Enjoy being reckless.", Trait: TraitExtraversion, Facet: "excitement_seeking", Reversed: false},
+		{ID: 83, Text: "Have difficulty understanding abstract ideas.", Trait: TraitOpenness, Facet: "intellect", Reversed: true},
+		{ID: 84, Text: "Have a high opinion of myself.", Trait: TraitAgreeableness, Facet: "modesty", Reversed: true},
+		{ID: 85, Text: "Waste my time.", Trait: TraitConscientiousness, Facet: "self_discipline", Reversed: true},
+		{ID: 86, Text: "Feel that I'm unable to deal with things.", Trait: TraitEmotionalStability, Facet: "vulnerability", Reversed: true},
+		{ID: 87, Text: "Love life.", Trait: TraitExtraversion, Facet: "cheerfulness", Reversed: false},
+		{ID: 88, Text: "Tend to vote for conservative political candidates.", Trait: TraitOpenness, Facet: "liberalism", Reversed: true},
+		{ID: 89, Text: "Am not interested in other people's problems.", Trait: TraitAgreeableness, Facet: "sympathy", Reversed: true},
+		{ID: 90, Text: "Rush into things.", Trait: TraitConscientiousness, Facet: "cautiousness", Reversed: true},
+		// Items 91-120: Round 4 of all 30 facets
+		{ID: 91, Text: "Get stressed out easily.", Trait: TraitEmotionalStability, Facet: "anxiety", Reversed: true},
+		{ID: 92, Text: "Keep others at a distance.", Trait: TraitExtraversion, Facet: "friendliness", Reversed: true},
+		{ID: 93, Text: "Like to get lost in thought.", Trait: TraitOpenness, Facet: "imagination", Reversed: false},
+		{ID: 94, Text: "Distrust people.", Trait: TraitAgreeableness, Facet: "trust", Reversed: true},
+		{ID: 95, Text: "Know how to get things done.", Trait: TraitConscientiousness, Facet: "self_efficacy", Reversed: false},
+		{ID: 96, Text: "Am not easily annoyed.", Trait: TraitEmotionalStability, Facet: "anger", Reversed: false},
+		{ID: 97, Text: "Avoid crowds.", Trait: TraitExtraversion, Facet: "gregariousness", Reversed: true},
+		{ID: 98, Text: "Do not enjoy going to art museums.", Trait: TraitOpenness, Facet: "artistic_interests", Reversed: true},
+		{ID: 99, Text: "Obstruct others' plans.", Trait: TraitAgreeableness, Facet: "morality", Reversed: true},
+		{ID: 100, Text: "Leave my belongings around.", Trait: TraitConscientiousness, Facet: "orderliness", Reversed: true},
+		{ID: 101, Text: "Feel comfortable with myself.", Trait: TraitEmotionalStability, Facet: "depression", Reversed: false},
+		{ID: 102, Text: "Wait for others to lead the way.", Trait: TraitExtraversion, Facet: "assertiveness", Reversed: true},
+		{ID: 103, Text: "Don't understand people who get emotional.", Trait: TraitOpenness, Facet: "emotionality", Reversed: true},
+		{ID: 104, Text: "Take no time for others.", Trait: TraitAgreeableness, Facet: "altruism", Reversed: true},
+		{ID: 105, Text: "Break my promises.", Trait: TraitConscientiousness, Facet: "dutifulness", Reversed: true},
+		{ID: 106, Text: "Am not bothered by difficult social situations.", Trait: TraitEmotionalStability, Facet: "self_consciousness", Reversed: false},
+		{ID: 107, Text: "Like to take it easy.", Trait: TraitExtraversion, Facet: "activity_level", Reversed: true},
+		{ID: 108, Text: "Am attached to conventional ways.", Trait: TraitOpenness, Facet: "adventurousness", Reversed: true},
+		{ID: 109, Text: "Get back at others.", Trait: TraitAgreeableness, Facet: "cooperation", Reversed: true},
+		{ID: 110, Text: "Do just enough work to get by.", Trait: TraitConscientiousness, Facet: "achievement_striving", Reversed: true},
+		{ID: 111, Text: "Am able to control my cravings.", Trait: TraitEmotionalStability, Facet: "immoderation", Reversed: false},
+		{ID: 112, Text: "Act wild and crazy.", Trait: TraitExtraversion, Facet: "excitement_seeking", Reversed: false},
+		{ID: 113, Text: "Am not interested in theoretical discussions.", Trait: TraitOpenness, Facet: "intellect", Reversed: true},
+		{ID: 114, Text: "Boast about my virtues.", Trait: TraitAgreeableness, Facet: "modesty", Reversed: true},
+		{ID: 115, Text: "Have difficulty starting tasks.", Trait: TraitConscientiousness, Facet: "self_discipline", Reversed: true},
+		{ID: 116, Text: "Remain calm under pressure.", Trait: TraitEmotionalStability, Facet: "vulnerability", Reversed: false},
+		{ID: 117, Text: "Look at the bright side of life.", Trait: TraitExtraversion, Facet: "cheerfulness", Reversed: false},
+		{ID: 118, Text: "Believe that we should be tough on crime.", Trait: TraitOpenness, Facet: "liberalism", Reversed: true},
+		{ID: 119, Text: "Try not to think about the needy.", Trait: TraitAgreeableness, Facet: "sympathy", Reversed: true},
+		{ID: 120, Text: "Act without thinking.", Trait: TraitConscientiousness, Facet: "cautiousness", Reversed: true},
+	}
+}
@@ -0,0 +1,90 @@
+package domain
+
+// ONETInterestProfiler returns the 60-item O*NET Interest Profiler Short Form
+func ONETInterestProfiler() *Instrument {
+	return &Instrument{
+		ID:       InstrumentONETIP60,
+		Version:  "1.0",
+		Kind:     InstrumentKindInterests,
+		Name:     "O*NET Interest Profiler Short Form",
+		Source:   "Rounds et al. (2010), U.S. Department of Labor, O*NET Resource Center (https://www.onetcenter.org/IP.html)",
+		License:  "O*NET Career Exploration Tools license (free use with attribution)",
+		MinValue: 1,
+		MaxValue: 5,
+		Scales:   riasecScales(),
+		Items:    onetIP60Items(),
+	}
+}
+
+// onetIP60Items returns the Interest Profiler activities in administration
+// order. Each item is rated from 1 (strongly dislike) to 5 (strongly like);
+// no item is reversed.
+func onetIP60Items() []Question {
+	return []Question{
+		// Items 1-12
+		{ID: 1, Text: "Build kitchen cabinets.", Trait: TraitRealistic, Reversed: false},
+		{ID: 2, Text: "Develop a new medicine.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 3, Text: "Write books or plays.", Trait: TraitArtistic, Reversed: false},
+		{ID: 4, Text: "Teach an individual an exercise routine.", Trait: TraitSocial, Reversed: false},
+		{ID: 5, Text: "Buy and sell stocks and bonds.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 6, Text: "Develop a spreadsheet using computer software.", Trait: TraitConventional, Reversed: false},
+		{ID: 7, Text: "Lay brick or tile.", Trait: TraitRealistic, Reversed: false},
+		{ID: 8, Text: "Study ways to reduce water pollution.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 9, Text: "Play a musical instrument.", Trait: TraitArtistic, Reversed: false},
+		{ID: 10, Text: "Help people with personal or emotional problems.", Trait: TraitSocial, Reversed: false},
+		{ID: 11, Text: "Manage a retail store.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 12, Text: "Proofread records or forms.", Trait: TraitConventional, Reversed: false},
+		// Items 13-24
+		{ID: 13, Text: "Repair household appliances.", Trait: TraitRealistic, Reversed: false},
+		{ID: 14, Text: "Conduct chemical experiments.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 15, Text: "Compose or arrange music.", Trait: TraitArtistic, Reversed: false},
+		{ID: 16, Text: "Give career guidance to people.", Trait: TraitSocial, Reversed: false},
+		{ID: 17, Text: "Operate a beauty salon or barber shop.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 18, Text: "Load computer software into a large computer network.", Trait: TraitConventional, Reversed: false},
+		{ID: 19, Text: "Raise fish in a fish hatchery.", Trait: TraitRealistic, Reversed: false},
+		{ID: 20, Text: "Study the movement of planets.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 21, Text: "Draw pictures.", Trait: TraitArtistic, Reversed: false},
+		{ID: 22, Text: "Perform rehabilitation therapy.", Trait: TraitSocial, Reversed: false},
+		{ID: 23, Text: "Manage a department within a large company.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 24, Text: "Operate a calculator.", Trait: TraitConventional, Reversed: false},
+		// Items 25-36
+		{ID: 25, Text: "Assemble electronic parts.", Trait: TraitRealistic, Reversed: false},
+		{ID: 26, Text: "Examine blood samples using a microscope.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 27, Text: "Create special effects for movies.", Trait: TraitArtistic, Reversed: false},
+		{ID: 28, Text: "Do volunteer work at a non-profit organization.", Trait: TraitSocial, Reversed: false},
+		{ID: 29, Text: "Start your own business.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 30, Text: "Keep shipping and receiving records.", Trait: TraitConventional, Reversed: false},
+		{ID: 31, Text: "Drive a truck to deliver packages to offices and homes.", Trait: TraitRealistic, Reversed: false},
+		{ID: 32, Text: "Investigate the cause of a fire.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 33, Text: "Paint sets for plays.", Trait: TraitArtistic, Reversed: false},
+		{ID: 34, Text: "Teach children how to play sports.", Trait: TraitSocial, Reversed: false},
+		{ID: 35, Text: "Negotiate business contracts.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 36, Text: "Calculate the wages of employees.", Trait: TraitConventional, Reversed: false},
+		// Items 37-48
+		{ID: 37, Text: "Test the quality of parts before shipment.", Trait: TraitRealistic, Reversed: false},
+		{ID: 38, Text: "Develop a way to better predict the weather.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 39, Text: "Write scripts for movies or television shows.", Trait: TraitArtistic, Reversed: false},
+		{ID: 40, Text: "Teach sign language to people who are deaf or hard of hearing.", Trait: TraitSocial, Reversed: false},
+		{ID: 41, Text: "Represent a client in a lawsuit.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 42, Text: "Inventory supplies using a hand-held computer.", Trait: TraitConventional, Reversed: false},
+		{ID: 43, Text: "Repair and install locks.", Trait: TraitRealistic, Reversed: false},
+		{ID: 44, Text: "Work in a biology lab.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 45, Text: "Perform jazz or tap dance.", Trait: TraitArtistic, Reversed: false},
+		{ID: 46, Text: "Help conduct a group therapy session.", Trait: TraitSocial, Reversed: false},
+		{ID: 47, Text: "Market a new line of clothing.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 48, Text: "Record rent payments.", Trait: TraitConventional, Reversed: false},
+		// Items 49-60
+		{ID: 49, Text: "Set up and operate machines to make products.", Trait: TraitRealistic, Reversed: false},
+		{ID: 50, Text: "Invent a replacement for sugar.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 51, Text: "Sing in a band.", Trait: TraitArtistic, Reversed: false},
+		{ID: 52, Text: "Take care of children at a day-care center.", Trait: TraitSocial, Reversed: false},
+		{ID: 53, Text: "Sell merchandise at a department store.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 54, Text: "Keep inventory records.", Trait: TraitConventional, Reversed: false},
+		{ID: 55, Text: "Put out forest fires.", Trait: TraitRealistic, Reversed: false},
+		{ID: 56, Text: "Do laboratory tests to identify diseases.", Trait: TraitInvestigative, Reversed: false},
+		{ID: 57, Text: "Edit movies.", Trait: TraitArtistic, Reversed: false},
+		{ID: 58, Text: "Teach a high-school class.", Trait: TraitSocial, Reversed: false},
+		{ID: 59, Text: "Manage a clothing store.", Trait: TraitEnterprising, Reversed: false},
+		{ID: 60, Text: "Stamp, sort, and distribute mail for an organization.", Trait: TraitConventional, Reversed: false},
+	}
+}
@@ -0,0 +1,123 @@
+package domain
+
+import "time"
+
+// ScaleNorm is the reference distribution of one scale on the 0-100 score metric
+type ScaleNorm struct {
+	Mean float64 `json:"mean"`
+	SD   float64 `json:"sd"`
+}
+
+// NormGroup is a reference group within a norm set. Empty constraints
+// (zero ages, empty language or country) match everyone.
+type NormGroup struct {
+	Label    string              `json:"label"`
+	AgeMin   int                 `json:"age_min,omitempty"`
+	AgeMax   int                 `json:"age_max,omitempty"`
+	Language string              `json:"language,omitempty"`
+	Country  string              `json:"country,omitempty"`
+	N        int                 `json:"n,omitempty"`
+	Scales   map[Trait]ScaleNorm `json:"scales"`
+}
+
+// Match reports whether the group applies to a respondent and how specific
+// the match is (number of constraints satisfied)
+func (g *NormGroup) Match(age int, language, country string) (bool, int) {
+	specificity := 0
+	if g.AgeMin != 0 || g.AgeMax != 0 {
+		if age == 0 || age < g.AgeMin || (g.AgeMax != 0 && age > g.AgeMax) {
+			return false, 0
+		}
+		specificity++
+	}
+	if g.Language != "" {
+		if g.Language != language {
+			return false, 0
+		}
+		specificity++
+	}
+	if g.Country != "" {
+		if g.Country != country {
+			return false, 0
+		}
+		specificity++
+	}
+	return true, specificity
+}
+
+// NormSet is a versioned collection of norm groups for one instrument.
+// A published version is never changed; corrections get a new version.
+type NormSet struct {
+	ID           string      `json:"id"`
+	Version      string      `json:"version"`
+	InstrumentID string      `json:"instrument_id"`
+	Description  string      `json:"description,omitempty"`
+	Groups       []NormGroup `json:"groups"`
+}
+
+// Key identifies a specific version of the norm set
+func (n *NormSet) Key() string {
+	return n.ID + "@" + n.Version
+}
+
+// NormScore expresses a score relative to a norm group
+type NormScore struct {
+	Percentile float64 `json:"percentile"`
+	TScore     float64 `json:"t_score"`
+	Band       string  `json:"band"`
+}
+
+// Norm bands by T-score
+const (
+	NormBandVeryLow  = "very_low"
+	NormBandLow      = "low"
+	NormBandAverage  = "average"
+	NormBandHigh     = "high"
+	NormBandVeryHigh = "very_high"
+)
+
+// NormSnapshotFilter selects the stored results a norm snapshot is computed from.
+// Zero values mean "no constraint".
+type NormSnapshotFilter struct {
+	InstrumentID string     `json:"instrument_id"`
+	Language     string     `json:"language,omitempty"`
+	AgeMin       int        `json:"age_min,omitempty"`
+	AgeMax       int        `json:"age_max,omitempty"`
+	From         *time.Time `json:"from,omitempty"`
+	To           *time.Time `json:"to,omitempty"`
+}
+
+// NormSnapshot is a named trait distribution computed from stored results.
+// Scales is left empty while the sample is below the minimum size.
+type NormSnapshot struct {
+	TenantID   string              `json:"-"`
+	Name       string              `json:"name"`
+	Filter     NormSnapshotFilter  `json:"filter"`
+	N          int                 `json:"n"`
+	Scales     map[Trait]ScaleNorm `json:"scales,omitempty"`
+	ComputedAt time.Time           `json:"computed_at"`
+	CreatedAt  time.Time           `json:"created_at"`
+}
+
+// CreateNormSnapshotRequest is the request body for defining a norm snapshot
+type CreateNormSnapshotRequest struct {
+	Name string `json:"name"`
+	NormSnapshotFilter
+}
+
+// ScoreComparison compares one scale of a result with a norm snapshot
+type ScoreComparison struct {
+	Score float64 `json:"score"`
+	Mean  float64 `json:"mean"`
+	SD    float64 `json:"sd"`
+	NormScore
+}
+
+// CompareResponse compares a result with a norm snapshot
+type CompareResponse struct {
+	ResultID   string                    `json:"result_id"`
+	Norm       string                    `json:"norm"`
+	N          int                       `json:"n"`
+	ComputedAt time.Time                 `json:"computed_at"`
+	Scores     map[Trait]ScoreComparison `json:"scores"`
+}
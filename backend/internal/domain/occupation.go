@@ -0,0 +1,56 @@
+package domain
+
+// Occupation is an entry in the occupation catalog with the trait and
+// interest profile (0-100 per scale) typical for people in that occupation
+type Occupation struct {
+	Code        string            `json:"code"`
+	Title       string            `json:"title"`
+	HollandCode string            `json:"holland_code,omitempty"`
+	Traits      map[Trait]float64 `json:"traits,omitempty"`
+	Interests   map[Trait]float64 `json:"interests,omitempty"`
+}
+
+// Profile returns the occupation's trait and interest targets as one map
+func (o *Occupation) Profile() map[Trait]float64 {
+	profile := make(map[Trait]float64, len(o.Traits)+len(o.Interests))
+	for trait, score := range o.Traits {
+		profile[trait] = score
+	}
+	for trait, score := range o.Interests {
+		profile[trait] = score
+	}
+	return profile
+}
+
+// OccupationCatalog is a versioned set of occupations
+type OccupationCatalog struct {
+	ID          string        `json:"id"`
+	Version     string        `json:"version"`
+	Description string        `json:"description,omitempty"`
+	Occupations []*Occupation `json:"occupations"`
+}
+
+// TraitMatch explains how one scale contributed to a career match
+type TraitMatch struct {
+	Trait       Trait   `json:"trait"`
+	Score       float64 `json:"score"`
+	Target      float64 `json:"target"`
+	Difference  float64 `json:"difference"`
+	Explanation string  `json:"explanation"`
+}
+
+// CareerMatch is an occupation ranked against a result
+type CareerMatch struct {
+	Occupation *Occupation  `json:"occupation"`
+	Match      float64      `json:"match"`    // 0-100, higher is closer
+	Distance   float64      `json:"distance"` // root mean squared difference across compared scales
+	Traits     []TraitMatch `json:"traits"`
+}
+
+// GetCareersResponse returns the occupations that best match a result
+type GetCareersResponse struct {
+	ResultID       string        `json:"result_id"`
+	CatalogID      string        `json:"catalog_id"`
+	CatalogVersion string        `json:"catalog_version"`
+	Careers        []CareerMatch `json:"careers"`
+}
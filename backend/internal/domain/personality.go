@@ -18,35 +18,175 @@ type Question struct {
 	ID       int    `json:"id"`
 	Text     string `json:"text"`
 	Trait    Trait  `json:"trait"`
+	Facet    string `json:"facet,omitempty"`
 	Reversed bool   `json:"reversed"`
 }
 
 // Answer represents a user's response to a question
 type Answer struct {
 	QuestionID int `json:"question_id"`
-	Value      int `json:"value"` // 1-5 Likert scale
+	Value      int `json:"value"`                // 1-5 Likert scale
+	LatencyMs  int `json:"latency_ms,omitempty"` // Optional: time the item took to answer
 }
 
-// PersonalityResult stores the calculated personality scores
+// StoredAnswer is a raw answer persisted alongside the result it was scored into
+type StoredAnswer struct {
+	ResultID   string    `json:"result_id"`
+	QuestionID int       `json:"question_id"`
+	Value      int       `json:"value"`
+	LatencyMs  int       `json:"latency_ms,omitempty"`
+	AnsweredAt time.Time `json:"answered_at"`
+}
+
+// PersonalityResult stores the calculated personality scores. Scores holds
+// every scale of the instrument; the Big Five fields mirror it for clients
+// that predate instruments.
 type PersonalityResult struct {
-	ID                 string            `json:"id"`
-	SessionID          string            `json:"session_id"`
-	Extraversion       float64           `json:"extraversion"`
-	Agreeableness      float64           `json:"agreeableness"`
-	Conscientiousness  float64           `json:"conscientiousness"`
-	EmotionalStability float64           `json:"emotional_stability"`
-	Openness           float64           `json:"openness"`
-	CreatedAt          time.Time         `json:"created_at"`
-	Interpretations    map[Trait]string  `json:"interpretations,omitempty"`
+	ID                 string              `json:"id"`
+	TenantID           string              `json:"-"`
+	SessionID          string              `json:"session_id"`
+	InstrumentID       string              `json:"instrument_id"`
+	InstrumentVersion  string              `json:"instrument_version"`
+	Scores             map[Trait]float64   `json:"scores"`
+	Extraversion       float64             `json:"extraversion"`
+	Agreeableness      float64             `json:"agreeableness"`
+	Conscientiousness  float64             `json:"conscientiousness"`
+	EmotionalStability float64             `json:"emotional_stability"`
+	Openness           float64             `json:"openness"`
+	Facets             []FacetScore        `json:"facets,omitempty"`
+	HollandCode        string              `json:"holland_code,omitempty"`
+	Age                int                 `json:"age,omitempty"`
+	Language           string              `json:"language,omitempty"`
+	Country            string              `json:"country,omitempty"`
+	NormSetID          string              `json:"norm_set_id,omitempty"`
+	Translation        string              `json:"translation,omitempty"`
+	NormGroup          string              `json:"norm_group,omitempty"`
+	Norms              map[Trait]NormScore `json:"norms,omitempty"`
+	Quality            *ResponseQuality    `json:"quality,omitempty"`
+	StartedAt          *time.Time          `json:"started_at,omitempty"`
+	CompletedAt        *time.Time          `json:"completed_at,omitempty"`
+	CohortID           string              `json:"cohort_id,omitempty"`
+	CohortConsent      bool                `json:"cohort_consent,omitempty"`
+	CreatedAt          time.Time           `json:"created_at"`
+	Interpretations    map[Trait]string    `json:"interpretations,omitempty"`
+}
+
+// FacetScore is the 0-100 score of a single facet
+type FacetScore struct {
+	Facet string  `json:"facet"`
+	Name  string  `json:"name,omitempty"`
+	Trait Trait   `json:"trait"`
+	Score float64 `json:"score"`
+}
+
+// FacetsOf returns the facet scores belonging to a trait
+func (r *PersonalityResult) FacetsOf(trait Trait) []FacetScore {
+	var facets []FacetScore
+	for _, facet := range r.Facets {
+		if facet.Trait == trait {
+			facets = append(facets, facet)
+		}
+	}
+	return facets
 }
 
 // TraitInterpretation stores an AI-generated interpretation for a specific trait
+// together with how it was generated. Regenerating the interpretations of a
+// result adds a new version; only the active version is shown.
 type TraitInterpretation struct {
-	ID             string    `json:"id"`
-	ResultID       string    `json:"result_id"`
-	Trait          Trait     `json:"trait"`
-	Interpretation string    `json:"interpretation"`
-	CreatedAt      time.Time `json:"created_at"`
+	ID             string `json:"id"`
+	ResultID       string `json:"result_id"`
+	Version        int    `json:"version"`
+	Trait          Trait  `json:"trait"`
+	Interpretation string `json:"interpretation"`
+	Active         bool   `json:"active"`
+	Language       string `json:"language"`
+	Model          string `json:"model"`
+	// PromptVersion identifies the prompt templates the text was generated with
+	PromptVersion    string    `json:"prompt_version"`
+	Temperature      float64   `json:"temperature"`
+	PromptTokens     int       `json:"prompt_tokens"`
+	CompletionTokens int       `json:"completion_tokens"`
+	GeneratedAt      time.Time `json:"generated_at"`
+}
+
+// InterpretationVersion is one generation of a result's interpretations.
+// Token counts are summed over its traits.
+type InterpretationVersion struct {
+	Version          int              `json:"version"`
+	Active           bool             `json:"active"`
+	Language         string           `json:"language"`
+	Model            string           `json:"model"`
+	PromptVersion    string           `json:"prompt_version"`
+	Temperature      float64          `json:"temperature"`
+	PromptTokens     int              `json:"prompt_tokens"`
+	CompletionTokens int              `json:"completion_tokens"`
+	GeneratedAt      time.Time        `json:"generated_at"`
+	Interpretations  map[Trait]string `json:"interpretations"`
+}
+
+// ResultFilter selects the results of a tenant that are deleted or counted
+// together. Empty fields match every result.
+type ResultFilter struct {
+	ID        string
+	SessionID string
+	// Results is RetentionResultsAnonymous, RetentionResultsCohort or empty for all results
+	Results string
+	// CreatedBefore matches results created before this time
+	CreatedBefore *time.Time
+	// WithInterpretations matches only results that have interpretations
+	WithInterpretations bool
+}
+
+// ResultListQuery selects, orders and pages the results of a tenant for the
+// admin listing. Empty fields match every result.
+type ResultListQuery struct {
+	// From and To limit results to those created in [From, To)
+	From        *time.Time
+	To          *time.Time
+	Language    string
+	CohortID    string
+	QualityFlag string
+	// HasInterpretations limits results to those with (true) or without
+	// (false) active interpretations
+	HasInterpretations *bool
+	// Scores limit results to those with a score on each scale within its range
+	Scores []ScoreRange
+	// SortScale orders results by their score on a scale instead of their
+	// creation time; only results scored on the scale are listed
+	SortScale Trait
+	// Ascending lists the oldest or lowest scoring results first
+	Ascending bool
+	// Cursor continues a listing after the page that returned it
+	Cursor string
+	Limit  int
+}
+
+// ScoreRange bounds the score on a scale; nil bounds are open
+type ScoreRange struct {
+	Scale Trait
+	Min   *float64
+	Max   *float64
+}
+
+// ResultPage is a page of the admin result listing
+type ResultPage struct {
+	Results []*PersonalityResult `json:"results"`
+	// Total counts the results matching the query on all pages
+	Total int `json:"total"`
+	// NextCursor fetches the next page; it is empty on the last page
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// ResultDeletion counts the results and rows deleted, or that would be
+// deleted, for a ResultFilter
+type ResultDeletion struct {
+	// ResultIDs are the IDs of the deleted results, so data kept elsewhere
+	// about them can be deleted as well
+	ResultIDs       []string
+	Results         int
+	Answers         int
+	Interpretations int
 }
 
 // TraitDisplayName returns the German display name for a trait
@@ -69,9 +209,43 @@ func (t Trait) DisplayName() string {
 
 // SubmitAnswersRequest is the request body for submitting questionnaire answers
 type SubmitAnswersRequest struct {
-	SessionID string   `json:"session_id"`
-	Answers   []Answer `json:"answers"`
-	Language  string   `json:"language,omitempty"` // Optional: language for AI interpretations (defaults to "de")
+	// SessionID is taken from the verified session token, never from the body
+	SessionID    string   `json:"-"`
+	InstrumentID string   `json:"instrument_id,omitempty"` // Optional: instrument the answers belong to (defaults to "ipip-50")
+	Answers      []Answer `json:"answers"`
+	Language     string   `json:"language,omitempty"` // Optional: language for AI interpretations (defaults to "de")
+	Age          int      `json:"age,omitempty"`      // Optional: respondent's age, selects the norm group
+	Country      string   `json:"country,omitempty"`  // Optional: ISO 3166-1 alpha-2 country code, selects the norm group
+	// Optional: total completion time, used to detect too-fast responding.
+	// Derived from StartedAt/CompletedAt or item latencies when omitted.
+	DurationSeconds float64 `json:"duration_seconds,omitempty"`
+	// Optional: translation key the items were shown in, as returned with the
+	// questions; defaults to the current translation for Language
+	Translation string `json:"translation,omitempty"`
+	// Optional: when the respondent opened and finished the questionnaire
+	StartedAt   *time.Time `json:"started_at,omitempty"`
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	// Optional: join code of the cohort the respondent takes part in, and
+	// whether the cohort's teacher may see the individual result
+	CohortCode    string `json:"cohort_code,omitempty"`
+	CohortConsent bool   `json:"cohort_consent,omitempty"`
+	// CohortID is resolved from CohortCode, never taken from the body
+	CohortID string `json:"-"`
+}
+
+// ValidationIssue describes a single problem with submitted answers
+type ValidationIssue struct {
+	Code               string `json:"code"`
+	QuestionID         int    `json:"question_id,omitempty"`
+	Trait              Trait  `json:"trait,omitempty"`
+	MissingQuestionIDs []int  `json:"missing_question_ids,omitempty"`
+	Message            string `json:"message"`
+}
+
+// ValidationErrorResponse is returned when submitted answers cannot be scored
+type ValidationErrorResponse struct {
+	Error  string            `json:"error"`
+	Issues []ValidationIssue `json:"issues"`
 }
 
 // SubmitAnswersResponse is the response after calculating results
@@ -79,15 +253,49 @@ type SubmitAnswersResponse struct {
 	Result PersonalityResult `json:"result"`
 }
 
+// GetAnswersResponse returns the raw answers stored for a result
+type GetAnswersResponse struct {
+	ResultID string          `json:"result_id"`
+	Answers  []*StoredAnswer `json:"answers"`
+}
+
+// GetInterpretationHistoryResponse returns every interpretation version of a
+// result, newest first
+type GetInterpretationHistoryResponse struct {
+	ResultID string                   `json:"result_id"`
+	Versions []*InterpretationVersion `json:"versions"`
+}
+
+// RollbackInterpretationsRequest makes an earlier interpretation version active
+type RollbackInterpretationsRequest struct {
+	Version int `json:"version"`
+}
+
+// AdminResultResponse is the admin view of a result with its raw answers and timing
+type AdminResultResponse struct {
+	*PersonalityResult
+	Timing  *ResponseTiming `json:"timing"`
+	Answers []*StoredAnswer `json:"answers"`
+}
+
 // GetQuestionsResponse returns all questionnaire items
 type GetQuestionsResponse struct {
 	Questions []Question `json:"questions"`
+	Language  string     `json:"language"`
+	// Translation identifies the wording served; send it back on submission
+	Translation string           `json:"translation,omitempty"`
+	Options     []ResponseOption `json:"options,omitempty"`
 }
 
-// GetQuestions returns the IPIP Big Five 50-item questionnaire
+// GetQuestions returns the items of the default IPIP-50 questionnaire
+func GetQuestions() []Question {
+	return IPIP50().Items
+}
+
+// ipip50Items returns the IPIP Big Five 50-item questionnaire
 // Source: International Personality Item Pool (https://ipip.ori.org/)
 // License: Public Domain
-func GetQuestions() []Question {
+func ipip50Items() []Question {
 	return []Question{
 		// Items 1-10: First round of all 5 traits (alternating polarity)
 		{ID: 1, Text: "Am the life of the party.", Trait: TraitExtraversion, Reversed: false},
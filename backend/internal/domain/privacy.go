@@ -0,0 +1,67 @@
+package domain
+
+import "time"
+
+// Erasure subjects
+const (
+	// ErasureSubjectSession erases everything stored for a session
+	ErasureSubjectSession = "session"
+	// ErasureSubjectResult erases a single result
+	ErasureSubjectResult = "result"
+)
+
+// Erasure statuses
+const (
+	// ErasureStatusPending marks an erasure that was interrupted before all
+	// data was deleted; repeating the erasure completes it
+	ErasureStatusPending   = "pending"
+	ErasureStatusCompleted = "completed"
+)
+
+// ErasureRequestedBySubject marks erasures requested by the respondent
+// rather than an admin
+const ErasureRequestedBySubject = "subject"
+
+// Erasure is the tombstone left by hard-deleting a respondent's data. It
+// proves when what was erased and by whom, without keeping any of the data.
+type Erasure struct {
+	ID        string `json:"id"`
+	TenantID  string `json:"-"`
+	Subject   string `json:"subject"`
+	SubjectID string `json:"subject_id"`
+	// RequestedBy is ErasureRequestedBySubject or the name of the admin user or API key
+	RequestedBy     string    `json:"requested_by"`
+	Results         int       `json:"results"`
+	Answers         int       `json:"answers"`
+	Interpretations int       `json:"interpretations"`
+	ShareLinks      int       `json:"share_links"`
+	Drafts          int       `json:"drafts"`
+	ConsentRecords  int       `json:"consent_records"`
+	Status          string    `json:"status"`
+	ErasedAt        time.Time `json:"erased_at"`
+	// ResultIDs are the IDs of the results deleted by a pending erasure
+	ResultIDs []string `json:"-"`
+}
+
+// GetErasuresResponse lists erasure tombstones
+type GetErasuresResponse struct {
+	Erasures []*Erasure `json:"erasures"`
+}
+
+// DataExport is the archive of everything stored for a session
+type DataExport struct {
+	SessionID  string            `json:"session_id"`
+	ExportedAt time.Time         `json:"exported_at"`
+	Results    []*ExportedResult `json:"results"`
+	Drafts     []*DraftSession   `json:"drafts"`
+	Consents   []*ConsentRecord  `json:"consents"`
+}
+
+// ExportedResult is a result with its interpretations and their versions, raw
+// answers and share links, as included in a data export
+type ExportedResult struct {
+	*PersonalityResult
+	InterpretationHistory []*InterpretationVersion `json:"interpretation_history"`
+	Answers               []*StoredAnswer          `json:"answers"`
+	Shares                []*ShareLink             `json:"shares"`
+}
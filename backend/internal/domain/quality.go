@@ -0,0 +1,48 @@
+package domain
+
+// Response quality flags, from least to most severe
+const (
+	QualityOK      = "ok"
+	QualitySuspect = "suspect"
+	QualityInvalid = "invalid"
+)
+
+// Reasons a protocol is flagged for careless responding
+const (
+	QualityReasonLongString   = "long_string"
+	QualityReasonLowVariance  = "low_variance"
+	QualityReasonInconsistent = "inconsistent"
+	QualityReasonTooFast      = "too_fast"
+)
+
+// ResponseQuality summarises careless-responding indicators for a submission
+type ResponseQuality struct {
+	Flag string `json:"flag"`
+	// LongestRun is the longest sequence of identical consecutive answers
+	LongestRun int `json:"longest_run"`
+	// ResponseSD is the standard deviation of the raw answers
+	ResponseSD float64 `json:"response_sd"`
+	// Inconsistency is the mean deviation from opposite answers across
+	// antonym item pairs, in answer-scale points (0 = fully consistent)
+	Inconsistency *float64 `json:"inconsistency,omitempty"`
+	// SecondsPerItem is the average answering time, when timing was provided
+	SecondsPerItem *float64 `json:"seconds_per_item,omitempty"`
+	Reasons        []string `json:"reasons,omitempty"`
+}
+
+// ResponseTiming aggregates the timing a client reported for a submission.
+// Fields are omitted when the client did not send the underlying data.
+type ResponseTiming struct {
+	TotalSeconds         *float64 `json:"total_seconds,omitempty"`
+	MedianSecondsPerItem *float64 `json:"median_seconds_per_item,omitempty"`
+	// ItemsTimed is the number of answers that carried a latency
+	ItemsTimed int `json:"items_timed"`
+	// FastItems counts answers given in under FastItemThresholdSeconds
+	FastItems                int     `json:"fast_items"`
+	FastItemThresholdSeconds float64 `json:"fast_item_threshold_seconds"`
+}
+
+// Valid reports whether a protocol is usable for interpretation
+func (q *ResponseQuality) Valid() bool {
+	return q == nil || q.Flag != QualityInvalid
+}
@@ -0,0 +1,71 @@
+package domain
+
+import "time"
+
+// Results a retention rule applies to
+const (
+	// RetentionResultsAnonymous matches results taken outside a cohort
+	RetentionResultsAnonymous = "anonymous"
+	// RetentionResultsCohort matches results taken in a cohort
+	RetentionResultsCohort = "cohort"
+)
+
+// Retention actions
+const (
+	// RetentionActionDelete deletes results with their answers, scores,
+	// interpretations, share links and the drafts they were finalized from
+	RetentionActionDelete = "delete"
+	// RetentionActionStripInterpretations deletes the AI interpretations of
+	// results but keeps their scores for statistics
+	RetentionActionStripInterpretations = "strip_interpretations"
+)
+
+// DefaultSchoolYearEnd is the last day of the school year (month-day) used by
+// retention rules when a policy names none
+const DefaultSchoolYearEnd = "07-31"
+
+// RetentionPolicy is a tenant's set of retention rules. Without a policy,
+// results are kept until they are erased.
+type RetentionPolicy struct {
+	// SchoolYearEnd is the last day of the school year as "MM-DD"
+	SchoolYearEnd string           `json:"school_year_end,omitempty"`
+	Rules         []*RetentionRule `json:"rules"`
+}
+
+// RetentionRule applies an action to results once they are old enough. A
+// rule sets either AfterDays or AtSchoolYearEnd.
+type RetentionRule struct {
+	Name string `json:"name"`
+	// Results is RetentionResultsAnonymous, RetentionResultsCohort or empty for all results
+	Results string `json:"results,omitempty"`
+	Action  string `json:"action"`
+	// AfterDays applies the action to results older than this many days
+	AfterDays int `json:"after_days,omitempty"`
+	// AtSchoolYearEnd applies the action to results of school years that have ended
+	AtSchoolYearEnd bool `json:"at_school_year_end,omitempty"`
+}
+
+// PurgeReport describes what a run of a tenant's retention rules deleted or,
+// in a dry run, would have deleted
+type PurgeReport struct {
+	ID       string             `json:"id"`
+	TenantID string             `json:"-"`
+	DryRun   bool               `json:"dry_run"`
+	RanAt    time.Time          `json:"ran_at"`
+	Rules    []*PurgeRuleReport `json:"rules"`
+}
+
+// PurgeRuleReport counts what a single retention rule matched
+type PurgeRuleReport struct {
+	Rule   string `json:"rule"`
+	Action string `json:"action"`
+	// Before is the creation time before which results matched the rule
+	Before          time.Time `json:"before"`
+	Results         int       `json:"results"`
+	Interpretations int       `json:"interpretations"`
+}
+
+// GetPurgesResponse lists purge reports
+type GetPurgesResponse struct {
+	Purges []*PurgeReport `json:"purges"`
+}
@@ -0,0 +1,75 @@
+package domain
+
+import "sort"
+
+// RIASEC interest types (Holland, 1997)
+const (
+	TraitRealistic     Trait = "realistic"
+	TraitInvestigative Trait = "investigative"
+	TraitArtistic      Trait = "artistic"
+	TraitSocial        Trait = "social"
+	TraitEnterprising  Trait = "enterprising"
+	TraitConventional  Trait = "conventional"
+)
+
+// TraitInterests keys the interpretation of a whole interest profile
+const TraitInterests Trait = "interests"
+
+// RIASECTraits returns the six interest types in Holland's hexagon order
+func RIASECTraits() []Trait {
+	return []Trait{
+		TraitRealistic,
+		TraitInvestigative,
+		TraitArtistic,
+		TraitSocial,
+		TraitEnterprising,
+		TraitConventional,
+	}
+}
+
+// HollandLetter returns the single-letter code of an interest type
+func HollandLetter(trait Trait) string {
+	switch trait {
+	case TraitRealistic:
+		return "R"
+	case TraitInvestigative:
+		return "I"
+	case TraitArtistic:
+		return "A"
+	case TraitSocial:
+		return "S"
+	case TraitEnterprising:
+		return "E"
+	case TraitConventional:
+		return "C"
+	default:
+		return ""
+	}
+}
+
+// HollandCode returns the three-letter code of the highest interest scores.
+// Ties are broken by hexagon order so the code is deterministic.
+func HollandCode(scores map[Trait]float64) string {
+	traits := RIASECTraits()
+	sort.SliceStable(traits, func(i, j int) bool {
+		return scores[traits[i]] > scores[traits[j]]
+	})
+
+	code := ""
+	for _, trait := range traits[:3] {
+		code += HollandLetter(trait)
+	}
+	return code
+}
+
+// riasecScales are the six scales of an interest inventory
+func riasecScales() []Scale {
+	return []Scale{
+		{ID: TraitRealistic, Name: "Realistic"},
+		{ID: TraitInvestigative, Name: "Investigative"},
+		{ID: TraitArtistic, Name: "Artistic"},
+		{ID: TraitSocial, Name: "Social"},
+		{ID: TraitEnterprising, Name: "Enterprising"},
+		{ID: TraitConventional, Name: "Conventional"},
+	}
+}
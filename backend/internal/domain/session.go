@@ -0,0 +1,17 @@
+package domain
+
+import "time"
+
+// SessionToken is a signed, expiring credential binding a respondent's
+// results to a server-issued session
+type SessionToken struct {
+	Token     string    `json:"token"`
+	SessionID string    `json:"session_id"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// GetSessionResultsResponse lists the results of one session
+type GetSessionResultsResponse struct {
+	SessionID string               `json:"session_id"`
+	Results   []*PersonalityResult `json:"results"`
+}
@@ -0,0 +1,50 @@
+package domain
+
+import "time"
+
+// Share link scopes
+const (
+	// ShareScopeScores exposes scores and norms only
+	ShareScopeScores = "scores"
+	// ShareScopeFull additionally exposes the AI interpretations
+	ShareScopeFull = "full"
+)
+
+// ShareLink grants read-only access to a result through an unguessable token.
+// Only a hash of the token is stored; the token itself is returned once.
+type ShareLink struct {
+	ID             string     `json:"id"`
+	TenantID       string     `json:"-"`
+	ResultID       string     `json:"result_id"`
+	Scope          string     `json:"scope"`
+	Token          string     `json:"token,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	ExpiresAt      *time.Time `json:"expires_at,omitempty"`
+	RevokedAt      *time.Time `json:"revoked_at,omitempty"`
+	AccessCount    int        `json:"access_count"`
+	LastAccessedAt *time.Time `json:"last_accessed_at,omitempty"`
+}
+
+// Active reports whether a share link can still be used at the given time
+func (l *ShareLink) Active(now time.Time) bool {
+	return l.RevokedAt == nil && (l.ExpiresAt == nil || now.Before(*l.ExpiresAt))
+}
+
+// CreateShareLinkRequest is the request body for creating a share link
+type CreateShareLinkRequest struct {
+	Scope     string     `json:"scope,omitempty"`      // Optional: "scores" (default) or "full"
+	ExpiresAt *time.Time `json:"expires_at,omitempty"` // Optional: no expiry when omitted
+}
+
+// GetShareLinksResponse lists the share links of a result
+type GetShareLinksResponse struct {
+	ResultID string       `json:"result_id"`
+	Shares   []*ShareLink `json:"shares"`
+}
+
+// SharedResultResponse is what a share link exposes of a result
+type SharedResultResponse struct {
+	Scope     string             `json:"scope"`
+	ExpiresAt *time.Time         `json:"expires_at,omitempty"`
+	Result    *PersonalityResult `json:"result"`
+}
@@ -0,0 +1,48 @@
+package domain
+
+// DefaultTenantID is the tenant of requests that name no tenant and of all
+// data stored before tenants existed
+const DefaultTenantID = "default"
+
+// Tenant is a school or other customer served from a shared deployment.
+// Results, sessions, admin users and cohorts belong to exactly one tenant and
+// are never visible to another.
+type Tenant struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// Hosts are the request hosts served as this tenant, e.g. "voca.school-a.example"
+	Hosts []string `json:"hosts,omitempty"`
+	// DefaultLanguage is used when a respondent's preferred languages have no translation
+	DefaultLanguage string `json:"default_language,omitempty"`
+	// Instruments lists the instruments respondents may take; empty enables all
+	Instruments []string `json:"instruments,omitempty"`
+	// DisableInterpretations turns off AI-generated interpretations
+	DisableInterpretations bool `json:"disable_interpretations,omitempty"`
+	// PromptBranding is added to the system prompt of AI interpretations,
+	// e.g. to name the school or its counseling service
+	PromptBranding string `json:"prompt_branding,omitempty"`
+	// GuardianConsentAge overrides the age below which a guardian has to
+	// confirm a respondent's consent, e.g. where national law sets another age
+	GuardianConsentAge int `json:"guardian_consent_age,omitempty"`
+	// Retention sets when the tenant's results are deleted or stripped of
+	// their interpretations; without it results are kept until erased
+	Retention *RetentionPolicy `json:"retention,omitempty"`
+}
+
+// InstrumentEnabled reports whether respondents of the tenant may take an instrument
+func (t *Tenant) InstrumentEnabled(id string) bool {
+	if len(t.Instruments) == 0 {
+		return true
+	}
+	for _, enabled := range t.Instruments {
+		if enabled == id {
+			return true
+		}
+	}
+	return false
+}
+
+// TenantConfig is the file format of the tenant configuration
+type TenantConfig struct {
+	Tenants []*Tenant `json:"tenants"`
+}
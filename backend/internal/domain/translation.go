@@ -0,0 +1,26 @@
+package domain
+
+// SourceLanguage is the language of the item text compiled into the instruments
+const SourceLanguage = "en"
+
+// Translation is a versioned wording of an instrument's items and response
+// options in one language
+type Translation struct {
+	InstrumentID string         `json:"instrument_id"`
+	Language     string         `json:"language"`
+	Version      string         `json:"version"`
+	Description  string         `json:"description,omitempty"`
+	Items        map[int]string `json:"items"`
+	Options      map[int]string `json:"options"`
+}
+
+// Key identifies a translation version as "instrument/language@version"
+func (t *Translation) Key() string {
+	return t.InstrumentID + "/" + t.Language + "@" + t.Version
+}
+
+// ResponseOption labels one value of the answer scale
+type ResponseOption struct {
+	Value int    `json:"value"`
+	Label string `json:"label"`
+}
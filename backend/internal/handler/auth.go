@@ -0,0 +1,246 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// adminSessionCookie carries the admin session token of a signed-in user
+const adminSessionCookie = "voca_admin_session"
+
+// principalKey is the request context key of the authenticated principal
+type principalKey struct{}
+
+// AuthHandler handles HTTP requests for admin authentication, admin users
+// and API keys, and guards admin routes
+type AuthHandler struct {
+	service *service.AuthService
+	// secureCookies marks the session cookie HTTPS-only and lets the
+	// frontend send it from another site
+	secureCookies bool
+	// allowedOrigins may change state with the session cookie
+	allowedOrigins []string
+}
+
+// NewAuthHandler creates a new auth handler. Cookie-authenticated requests
+// other than GET are only accepted from allowedOrigins.
+func NewAuthHandler(svc *service.AuthService, secureCookies bool, allowedOrigins []string) *AuthHandler {
+	return &AuthHandler{service: svc, secureCookies: secureCookies, allowedOrigins: allowedOrigins}
+}
+
+// Require restricts a route to principals holding at least the given role.
+// Callers authenticate with the admin session cookie or with an API key in
+// an "Authorization: Bearer" header.
+func (h *AuthHandler) Require(role domain.Role, next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		principal, err := h.authenticate(r)
+		if errors.Is(err, service.ErrUnauthenticated) {
+			w.Header().Set("WWW-Authenticate", `Bearer realm="voca-admin"`)
+			writeError(w, http.StatusUnauthorized, "Authentication required")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to authenticate")
+			return
+		}
+		if !principal.Role.Allows(role) {
+			writeError(w, http.StatusForbidden, "Requires role "+string(role))
+			return
+		}
+
+		next(w, r.WithContext(context.WithValue(r.Context(), principalKey{}, principal)))
+	}
+}
+
+// Login handles POST /api/admin/login
+func (h *AuthHandler) Login(w http.ResponseWriter, r *http.Request) {
+	var req domain.LoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	resp, token, err := h.service.Login(tenantFromContext(r.Context()).ID, req)
+	if errors.Is(err, service.ErrInvalidCredentials) {
+		writeError(w, http.StatusUnauthorized, "Invalid username or password")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to sign in")
+		return
+	}
+
+	http.SetCookie(w, h.sessionCookie(token, resp.ExpiresAt))
+	writeJSON(w, http.StatusOK, resp)
+}
+
+// Logout handles POST /api/admin/logout
+func (h *AuthHandler) Logout(w http.ResponseWriter, r *http.Request) {
+	if cookie, err := r.Cookie(adminSessionCookie); err == nil && cookie.Value != "" {
+		if err := h.service.Logout(cookie.Value); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to sign out")
+			return
+		}
+	}
+
+	http.SetCookie(w, h.sessionCookie("", time.Unix(0, 0)))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// Me handles GET /api/admin/me
+func (h *AuthHandler) Me(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, principalFromContext(r.Context()))
+}
+
+// GetUsers handles GET /api/admin/users
+func (h *AuthHandler) GetUsers(w http.ResponseWriter, r *http.Request) {
+	users, err := h.service.GetUsers(tenantFromContext(r.Context()).ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve users")
+		return
+	}
+
+	if users == nil {
+		users = []*domain.AdminUser{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetAdminUsersResponse{Users: users})
+}
+
+// CreateUser handles POST /api/admin/users
+func (h *AuthHandler) CreateUser(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateAdminUserRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	user, err := h.service.CreateUser(tenantFromContext(r.Context()).ID, req)
+	if errors.Is(err, service.ErrInvalidAdminRequest) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrUserExists) {
+		writeError(w, http.StatusConflict, "Username already taken")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create user")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, user)
+}
+
+// GetAPIKeys handles GET /api/admin/api-keys
+func (h *AuthHandler) GetAPIKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.service.GetAPIKeys(tenantFromContext(r.Context()).ID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve API keys")
+		return
+	}
+
+	if keys == nil {
+		keys = []*domain.APIKey{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetAPIKeysResponse{Keys: keys})
+}
+
+// CreateAPIKey handles POST /api/admin/api-keys
+func (h *AuthHandler) CreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateAPIKeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	key, err := h.service.CreateAPIKey(tenantFromContext(r.Context()).ID, req, principalFromContext(r.Context()).Name)
+	if errors.Is(err, service.ErrInvalidAdminRequest) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create API key")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, key)
+}
+
+// RevokeAPIKey handles DELETE /api/admin/api-keys/{id}
+func (h *AuthHandler) RevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	err := h.service.RevokeAPIKey(tenantFromContext(r.Context()).ID, r.PathValue("id"))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		writeError(w, http.StatusNotFound, "API key not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to revoke API key")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// authenticate resolves the principal of a request from its API key or
+// admin session cookie
+func (h *AuthHandler) authenticate(r *http.Request) (*domain.Principal, error) {
+	if auth := r.Header.Get("Authorization"); auth != "" {
+		key, ok := strings.CutPrefix(auth, "Bearer ")
+		if !ok {
+			return nil, service.ErrUnauthenticated
+		}
+		return h.service.AuthenticateAPIKey(tenantFromContext(r.Context()).ID, strings.TrimSpace(key))
+	}
+
+	cookie, err := r.Cookie(adminSessionCookie)
+	if err != nil || cookie.Value == "" {
+		return nil, service.ErrUnauthenticated
+	}
+
+	// Browsers attach the cookie to cross-site requests too, so reject
+	// state changes a foreign page could have triggered
+	origin := r.Header.Get("Origin")
+	if r.Method != http.MethodGet && r.Method != http.MethodHead && origin != "" && !slices.Contains(h.allowedOrigins, origin) {
+		return nil, service.ErrUnauthenticated
+	}
+
+	return h.service.AuthenticateSession(tenantFromContext(r.Context()).ID, cookie.Value)
+}
+
+// sessionCookie builds the admin session cookie; an empty token clears it
+func (h *AuthHandler) sessionCookie(token string, expiresAt time.Time) *http.Cookie {
+	cookie := &http.Cookie{
+		Name:     adminSessionCookie,
+		Value:    token,
+		Path:     "/api",
+		Expires:  expiresAt,
+		HttpOnly: true,
+		Secure:   h.secureCookies,
+		SameSite: http.SameSiteLaxMode,
+	}
+	if h.secureCookies {
+		// The frontend and the API are served from different sites in production
+		cookie.SameSite = http.SameSiteNoneMode
+	}
+	if token == "" {
+		cookie.MaxAge = -1
+	}
+	return cookie
+}
+
+// principalFromContext returns the principal stored by Require
+func principalFromContext(ctx context.Context) *domain.Principal {
+	principal, _ := ctx.Value(principalKey{}).(*domain.Principal)
+	return principal
+}
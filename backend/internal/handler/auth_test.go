@@ -0,0 +1,198 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// adminRoutes lists the admin routes with the role cmd/api requires for them
+var adminRoutes = []struct {
+	pattern string
+	role    domain.Role
+}{
+	{"GET /api/admin/me", domain.RoleViewer},
+	{"GET /api/admin/sessions/stats", domain.RoleViewer},
+	{"GET /api/admin/norms/snapshots", domain.RoleViewer},
+	{"GET /api/organizations", domain.RoleTeacher},
+	{"GET /api/cohorts", domain.RoleTeacher},
+	{"POST /api/cohorts", domain.RoleTeacher},
+	{"GET /api/cohorts/{id}/summary", domain.RoleTeacher},
+	{"GET /api/admin/results", domain.RoleCounselor},
+	{"GET /api/admin/results/{id}", domain.RoleCounselor},
+	{"GET /api/admin/results/{id}/answers", domain.RoleCounselor},
+	{"GET /api/admin/results/{id}/interpretations/history", domain.RoleCounselor},
+	{"POST /api/admin/results/{id}/interpretations/rollback", domain.RoleCounselor},
+	{"GET /api/admin/sessions/{id}/results", domain.RoleCounselor},
+	{"POST /api/organizations", domain.RoleAdmin},
+	{"POST /api/admin/norms/snapshots", domain.RoleAdmin},
+	{"POST /api/admin/norms/snapshots/{name}/refresh", domain.RoleAdmin},
+	{"GET /api/admin/users", domain.RoleAdmin},
+	{"POST /api/admin/users", domain.RoleAdmin},
+	{"GET /api/admin/api-keys", domain.RoleAdmin},
+	{"POST /api/admin/api-keys", domain.RoleAdmin},
+	{"DELETE /api/admin/api-keys/{id}", domain.RoleAdmin},
+	{"DELETE /api/admin/results/{id}", domain.RoleAdmin},
+	{"DELETE /api/admin/sessions/{id}", domain.RoleAdmin},
+	{"GET /api/admin/erasures", domain.RoleAdmin},
+	{"GET /api/admin/purges", domain.RoleAdmin},
+	{"POST /api/admin/purges", domain.RoleAdmin},
+}
+
+// TestAdminRoutes checks that adminRoutes lists the routes cmd/api restricts
+// to a role, with the role it requires
+func TestAdminRoutes(t *testing.T) {
+	source, err := os.ReadFile(filepath.Join("..", "..", "cmd", "api", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	registered := make(map[string]domain.Role)
+	wrapped := regexp.MustCompile(`mux\.HandleFunc\("([^"]+)", (viewer|teacher|counselor|admin)\(`)
+	for _, match := range wrapped.FindAllStringSubmatch(string(source), -1) {
+		registered[match[1]] = domain.Role(match[2])
+	}
+
+	listed := make(map[string]domain.Role)
+	for _, route := range adminRoutes {
+		listed[route.pattern] = route.role
+	}
+	for pattern, role := range registered {
+		if listed[pattern] != role {
+			t.Errorf("%s requires %s in cmd/api, adminRoutes lists %q", pattern, role, listed[pattern])
+		}
+	}
+	for pattern := range listed {
+		if _, ok := registered[pattern]; !ok {
+			t.Errorf("adminRoutes lists %s, which cmd/api does not restrict to a role", pattern)
+		}
+	}
+}
+
+// newAuthHandler returns an auth handler over a fresh database
+func newAuthHandler(t *testing.T) (*AuthHandler, *service.AuthService) {
+	t.Helper()
+	db, err := repository.InitDB(filepath.Join(t.TempDir(), "voca.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	auth := service.NewAuthService(repository.NewAuthRepository(db), time.Hour)
+	return NewAuthHandler(auth, false, []string{"https://voca.example"}), auth
+}
+
+// serveAdmin sends a request to a route restricted to role and returns the
+// response status
+func serveAdmin(h *AuthHandler, role domain.Role, method string, prepare func(*http.Request)) int {
+	r := httptest.NewRequest(method, "/api/admin", nil)
+	r = r.WithContext(context.WithValue(r.Context(), tenantKey{}, &domain.Tenant{ID: "default"}))
+	prepare(r)
+	rec := httptest.NewRecorder()
+	h.Require(role, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})(rec, r)
+	return rec.Code
+}
+
+func TestRequireRoles(t *testing.T) {
+	h, auth := newAuthHandler(t)
+	keys := make(map[domain.Role]string)
+	for _, role := range domain.Roles {
+		key, err := auth.CreateAPIKey("default", domain.CreateAPIKeyRequest{Name: string(role), Role: role}, "test")
+		if err != nil {
+			t.Fatal(err)
+		}
+		keys[role] = key.Key
+	}
+
+	for _, route := range adminRoutes {
+		method, _, _ := strings.Cut(route.pattern, " ")
+		for _, role := range domain.Roles {
+			want := http.StatusForbidden
+			if role.Allows(route.role) {
+				want = http.StatusNoContent
+			}
+			status := serveAdmin(h, route.role, method, func(r *http.Request) {
+				r.Header.Set("Authorization", "Bearer "+keys[role])
+			})
+			if status != want {
+				t.Errorf("%s as %s = %d, want %d", route.pattern, role, status, want)
+			}
+		}
+
+		if status := serveAdmin(h, route.role, method, func(*http.Request) {}); status != http.StatusUnauthorized {
+			t.Errorf("%s without credentials = %d, want %d", route.pattern, status, http.StatusUnauthorized)
+		}
+	}
+}
+
+func TestRequireCredentials(t *testing.T) {
+	h, auth := newAuthHandler(t)
+	if _, err := auth.CreateUser("default", domain.CreateAdminUserRequest{Username: "counselor", Password: "password123", Role: domain.RoleCounselor}); err != nil {
+		t.Fatal(err)
+	}
+	_, session, err := auth.Login("default", domain.LoginRequest{Username: "counselor", Password: "password123"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	revoked, err := auth.CreateAPIKey("default", domain.CreateAPIKeyRequest{Name: "revoked", Role: domain.RoleAdmin}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := auth.RevokeAPIKey("default", revoked.ID); err != nil {
+		t.Fatal(err)
+	}
+	other, err := auth.CreateAPIKey("other", domain.CreateAPIKeyRequest{Name: "other", Role: domain.RoleAdmin}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cookie := func(origin string) func(*http.Request) {
+		return func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: adminSessionCookie, Value: session})
+			if origin != "" {
+				r.Header.Set("Origin", origin)
+			}
+		}
+	}
+	bearer := func(value string) func(*http.Request) {
+		return func(r *http.Request) { r.Header.Set("Authorization", value) }
+	}
+
+	tests := []struct {
+		name    string
+		role    domain.Role
+		method  string
+		prepare func(*http.Request)
+		want    int
+	}{
+		{"session", domain.RoleCounselor, http.MethodGet, cookie(""), http.StatusNoContent},
+		{"session below role", domain.RoleAdmin, http.MethodGet, cookie(""), http.StatusForbidden},
+		{"session change from allowed origin", domain.RoleCounselor, http.MethodPost, cookie("https://voca.example"), http.StatusNoContent},
+		{"session change from foreign origin", domain.RoleCounselor, http.MethodPost, cookie("https://evil.example"), http.StatusUnauthorized},
+		{"unknown session", domain.RoleViewer, http.MethodGet, func(r *http.Request) {
+			r.AddCookie(&http.Cookie{Name: adminSessionCookie, Value: "unknown"})
+		}, http.StatusUnauthorized},
+		{"revoked key", domain.RoleViewer, http.MethodGet, bearer("Bearer " + revoked.Key), http.StatusUnauthorized},
+		{"key of another tenant", domain.RoleViewer, http.MethodGet, bearer("Bearer " + other.Key), http.StatusUnauthorized},
+		{"unknown key", domain.RoleViewer, http.MethodGet, bearer("Bearer voca_unknown"), http.StatusUnauthorized},
+		{"not a bearer key", domain.RoleViewer, http.MethodGet, bearer("Basic " + revoked.Key), http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if status := serveAdmin(h, tt.role, tt.method, tt.prepare); status != tt.want {
+				t.Errorf("status = %d, want %d", status, tt.want)
+			}
+		})
+	}
+}
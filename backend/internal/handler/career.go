@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// CareerHandler handles HTTP requests for career matching
+type CareerHandler struct {
+	service *service.CareerService
+}
+
+// NewCareerHandler creates a new career handler
+func NewCareerHandler(svc *service.CareerService) *CareerHandler {
+	return &CareerHandler{service: svc}
+}
+
+// GetCareers handles GET /api/results/{id}/careers?limit=
+func (h *CareerHandler) GetCareers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			writeError(w, http.StatusBadRequest, "limit must be a positive integer")
+			return
+		}
+		limit = parsed
+	}
+
+	response, err := h.service.MatchCareers(tenantFromContext(r.Context()), id, limit)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to match careers")
+		return
+	}
+
+	if response == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
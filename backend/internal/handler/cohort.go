@@ -0,0 +1,187 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// CohortHandler handles HTTP requests for organizations, cohorts and
+// cohort summaries
+type CohortHandler struct {
+	service *service.CohortService
+}
+
+// NewCohortHandler creates a new cohort handler
+func NewCohortHandler(svc *service.CohortService) *CohortHandler {
+	return &CohortHandler{service: svc}
+}
+
+// CreateOrganization handles POST /api/organizations
+func (h *CohortHandler) CreateOrganization(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateOrganizationRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	org, err := h.service.CreateOrganization(tenantFromContext(r.Context()), req)
+	if errors.Is(err, service.ErrInvalidCohort) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create organization")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, org)
+}
+
+// GetOrganizations handles GET /api/organizations
+func (h *CohortHandler) GetOrganizations(w http.ResponseWriter, r *http.Request) {
+	orgs, err := h.service.GetOrganizations(tenantFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve organizations")
+		return
+	}
+
+	if orgs == nil {
+		orgs = []*domain.Organization{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetOrganizationsResponse{Organizations: orgs})
+}
+
+// CreateCohort handles POST /api/cohorts
+func (h *CohortHandler) CreateCohort(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateCohortRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	cohort, err := h.service.CreateCohort(tenantFromContext(r.Context()), req, principalFromContext(r.Context()))
+	if errors.Is(err, service.ErrInvalidCohort) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusBadRequest, "Unknown instrument")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create cohort")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, cohort)
+}
+
+// GetCohorts handles GET /api/cohorts
+func (h *CohortHandler) GetCohorts(w http.ResponseWriter, r *http.Request) {
+	cohorts, err := h.service.GetCohorts(tenantFromContext(r.Context()), principalFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve cohorts")
+		return
+	}
+
+	if cohorts == nil {
+		cohorts = []*domain.Cohort{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetCohortsResponse{Cohorts: cohorts})
+}
+
+// GetSummary handles GET /api/cohorts/{id}/summary
+func (h *CohortHandler) GetSummary(w http.ResponseWriter, r *http.Request) {
+	summary, err := h.service.GetSummary(tenantFromContext(r.Context()), r.PathValue("id"), principalFromContext(r.Context()))
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		writeError(w, http.StatusNotFound, "Cohort not found")
+		return
+	}
+	if errors.Is(err, service.ErrNotCohortOwner) {
+		writeError(w, http.StatusForbidden, "Cohort belongs to another teacher")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to summarize cohort")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, summary)
+}
+
+// Join handles GET /api/join/{code}
+func (h *CohortHandler) Join(w http.ResponseWriter, r *http.Request) {
+	info, err := h.service.Join(tenantFromContext(r.Context()), r.PathValue("code"))
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		writeError(w, http.StatusNotFound, "Unknown join code")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve cohort")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, info)
+}
+
+// SetConsent handles PUT /api/results/{id}/cohort-consent
+func (h *CohortHandler) SetConsent(w http.ResponseWriter, r *http.Request) {
+	var req domain.SetCohortConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	err := h.service.SetConsent(tenantFromContext(r.Context()), r.PathValue("id"), req.Consent)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if errors.Is(err, service.ErrNoCohort) {
+		writeError(w, http.StatusConflict, "Result was not submitted to a cohort")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to update consent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, req)
+}
+
+// resolveCohort resolves the join code of a submission to the ID of a cohort
+// of the tenant. An empty code resolves to no cohort. It writes an error
+// response and returns false if the code is unknown or the cohort takes
+// another instrument.
+func resolveCohort(w http.ResponseWriter, cohorts *service.CohortService, tenant *domain.Tenant, code, instrumentID string) (string, bool) {
+	if code == "" {
+		return "", true
+	}
+
+	cohort, err := cohorts.Resolve(tenant, code, instrumentID)
+	if errors.Is(err, repository.ErrCohortNotFound) {
+		writeError(w, http.StatusBadRequest, "Unknown cohort code")
+		return "", false
+	}
+	if errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusBadRequest, "Unknown instrument")
+		return "", false
+	}
+	if errors.Is(err, service.ErrCohortInstrumentMismatch) {
+		writeError(w, http.StatusBadRequest, "Cohort takes a different instrument")
+		return "", false
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to resolve cohort")
+		return "", false
+	}
+
+	return cohort.ID, true
+}
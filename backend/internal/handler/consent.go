@@ -0,0 +1,174 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// ConsentHandler handles HTTP requests for consent texts, consent records and
+// guardian confirmations
+type ConsentHandler struct {
+	service *service.ConsentService
+	tokens  *service.TokenService
+}
+
+// NewConsentHandler creates a new consent handler
+func NewConsentHandler(svc *service.ConsentService, tokens *service.TokenService) *ConsentHandler {
+	return &ConsentHandler{service: svc, tokens: tokens}
+}
+
+// GetText handles GET /api/consent/text?lang=
+func (h *ConsentHandler) GetText(w http.ResponseWriter, r *http.Request) {
+	response := h.service.GetText(tenantFromContext(r.Context()), preferredLanguages(r))
+
+	w.Header().Set("Content-Language", response.Language)
+	w.Header().Add("Vary", "Accept-Language")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Grant handles POST /api/consent
+func (h *ConsentHandler) Grant(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	var req domain.GrantConsentRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	record, err := h.service.Grant(tenantFromContext(r.Context()), sessionID, req)
+	if errors.Is(err, service.ErrInvalidConsent) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrUnknownConsentText) {
+		writeError(w, http.StatusBadRequest, "Unknown consent text")
+		return
+	}
+	if errors.Is(err, service.ErrConsentExists) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to record consent")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, record)
+}
+
+// Get handles GET /api/consent
+func (h *ConsentHandler) Get(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	record, err := h.service.Get(tenantFromContext(r.Context()), sessionID)
+	if errors.Is(err, repository.ErrConsentNotFound) {
+		writeError(w, http.StatusNotFound, "No consent recorded")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve consent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, record)
+}
+
+// Withdraw handles POST /api/consent/withdraw
+func (h *ConsentHandler) Withdraw(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	var req domain.WithdrawConsentRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	response, err := h.service.Withdraw(tenantFromContext(r.Context()), sessionID, req)
+	if errors.Is(err, service.ErrInvalidConsent) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if errors.Is(err, repository.ErrConsentNotFound) {
+		writeError(w, http.StatusNotFound, "No consent recorded")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to withdraw consent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetGuardianConsent handles GET /api/consent/guardian/{code}
+func (h *ConsentHandler) GetGuardianConsent(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.GetGuardianConsent(tenantFromContext(r.Context()), r.PathValue("code"))
+	if err != nil {
+		writeGuardianError(w, err, "Failed to retrieve consent")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// ConfirmGuardian handles POST /api/consent/guardian/{code}/confirm
+func (h *ConsentHandler) ConfirmGuardian(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.ConfirmGuardian(tenantFromContext(r.Context()), r.PathValue("code"))
+	if err != nil {
+		writeGuardianError(w, err, "Failed to confirm consent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// WithdrawGuardian handles POST /api/consent/guardian/{code}/withdraw
+func (h *ConsentHandler) WithdrawGuardian(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.WithdrawGuardian(tenantFromContext(r.Context()), r.PathValue("code"))
+	if err != nil {
+		writeGuardianError(w, err, "Failed to withdraw consent")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeGuardianError maps guardian confirmation errors to HTTP responses
+func writeGuardianError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case errors.Is(err, repository.ErrConsentNotFound):
+		writeError(w, http.StatusNotFound, "Unknown guardian code")
+	case errors.Is(err, service.ErrConsentWithdrawn):
+		writeError(w, http.StatusConflict, "Consent was withdrawn")
+	case errors.Is(err, service.ErrGuardianCodeExpired):
+		writeError(w, http.StatusGone, "Guardian code expired")
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
+
+// consentErrorMessage describes why a session's data cannot be processed
+func consentErrorMessage(err error) string {
+	if errors.Is(err, service.ErrGuardianConsentPending) {
+		return "Waiting for guardian consent"
+	}
+	return "Consent required"
+}
@@ -0,0 +1,170 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// DraftHandler handles HTTP requests for resumable draft sessions
+type DraftHandler struct {
+	service *service.DraftService
+	tokens  *service.TokenService
+	cohorts *service.CohortService
+}
+
+// NewDraftHandler creates a new draft handler
+func NewDraftHandler(svc *service.DraftService, tokens *service.TokenService, cohorts *service.CohortService) *DraftHandler {
+	return &DraftHandler{service: svc, tokens: tokens, cohorts: cohorts}
+}
+
+// CreateDraft handles POST /api/sessions. The draft joins the session of the
+// request's session token; without one, a new session token is issued and
+// returned with the draft.
+func (h *DraftHandler) CreateDraft(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateDraftSessionRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	if req.Age < 0 || req.Age > 120 {
+		writeError(w, http.StatusBadRequest, "Invalid age")
+		return
+	}
+
+	var ok bool
+	if req.CohortID, ok = resolveCohort(w, h.cohorts, tenantFromContext(r.Context()), req.CohortCode, req.InstrumentID); !ok {
+		return
+	}
+
+	var token *domain.SessionToken
+	var sessionID string
+	if r.Header.Get(sessionTokenHeader) != "" {
+		if sessionID, ok = requireSession(w, r, h.tokens); !ok {
+			return
+		}
+	} else {
+		var err error
+		if token, err = h.tokens.Issue(); err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to create session token")
+			return
+		}
+		sessionID = token.SessionID
+	}
+
+	draft, err := h.service.CreateDraft(tenantFromContext(r.Context()), sessionID, req)
+	if errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusBadRequest, "Unknown instrument")
+		return
+	}
+	if errors.Is(err, service.ErrConsentRequired) || errors.Is(err, service.ErrGuardianConsentPending) {
+		writeError(w, http.StatusForbidden, consentErrorMessage(err))
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create session")
+		return
+	}
+	draft.Token = token
+
+	writeJSON(w, http.StatusCreated, draft)
+}
+
+// GetDraft handles GET /api/sessions/{id}
+func (h *DraftHandler) GetDraft(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.service.GetDraft(tenantFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeDraftError(w, err, "Failed to retrieve session")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, draft)
+}
+
+// ResumeDraft handles GET /api/sessions/resume/{code}
+func (h *DraftHandler) ResumeDraft(w http.ResponseWriter, r *http.Request) {
+	draft, err := h.service.ResumeDraft(tenantFromContext(r.Context()), r.PathValue("code"))
+	if err != nil {
+		writeDraftError(w, err, "Failed to resume session")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, draft)
+}
+
+// SaveAnswers handles PUT /api/sessions/{id}/answers
+func (h *DraftHandler) SaveAnswers(w http.ResponseWriter, r *http.Request) {
+	var req domain.SaveDraftAnswersRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	for _, answer := range req.Answers {
+		if answer.LatencyMs < 0 {
+			writeError(w, http.StatusBadRequest, "latency_ms must not be negative")
+			return
+		}
+	}
+
+	draft, err := h.service.SaveAnswers(tenantFromContext(r.Context()), r.PathValue("id"), req.Answers)
+	if err != nil {
+		writeDraftError(w, err, "Failed to save answers")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, draft)
+}
+
+// Finalize handles POST /api/sessions/{id}/finalize
+func (h *DraftHandler) Finalize(w http.ResponseWriter, r *http.Request) {
+	result, err := h.service.Finalize(tenantFromContext(r.Context()), r.PathValue("id"))
+	if err != nil {
+		writeDraftError(w, err, "Failed to calculate results")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, domain.SubmitAnswersResponse{
+		Result: *result,
+	})
+}
+
+// GetStats handles GET /api/admin/sessions/stats
+func (h *DraftHandler) GetStats(w http.ResponseWriter, r *http.Request) {
+	stats, err := h.service.GetStats(tenantFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve session statistics")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, stats)
+}
+
+// writeDraftError maps draft service errors to HTTP responses
+func writeDraftError(w http.ResponseWriter, err error, fallback string) {
+	var validationErr *service.ValidationError
+	switch {
+	case errors.Is(err, repository.ErrDraftNotFound):
+		writeError(w, http.StatusNotFound, "Session not found")
+	case errors.Is(err, service.ErrDraftExpired):
+		writeError(w, http.StatusGone, "Session expired")
+	case errors.Is(err, service.ErrDraftCompleted):
+		writeError(w, http.StatusConflict, "Session already finalized")
+	case errors.Is(err, service.ErrUnknownTranslation):
+		writeError(w, http.StatusBadRequest, "Unknown translation")
+	case errors.Is(err, service.ErrConsentRequired), errors.Is(err, service.ErrGuardianConsentPending):
+		writeError(w, http.StatusForbidden, consentErrorMessage(err))
+	case errors.As(err, &validationErr):
+		writeJSON(w, http.StatusUnprocessableEntity, domain.ValidationErrorResponse{
+			Error:  "Invalid answers",
+			Issues: validationErr.Issues,
+		})
+	default:
+		writeError(w, http.StatusInternalServerError, fallback)
+	}
+}
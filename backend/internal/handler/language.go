@@ -0,0 +1,59 @@
+package handler
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// preferredLanguages returns the languages a client asked for, most preferred
+// first: the lang query parameter, then the Accept-Language header by q-value
+func preferredLanguages(r *http.Request) []string {
+	var languages []string
+	if lang := r.URL.Query().Get("lang"); lang != "" {
+		languages = append(languages, lang)
+	}
+	return append(languages, parseAcceptLanguage(r.Header.Get("Accept-Language"))...)
+}
+
+// parseAcceptLanguage orders the language ranges of an Accept-Language header
+// by descending q-value, dropping the wildcard and ranges with q=0
+func parseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+
+	var ranges []weighted
+	for _, part := range strings.Split(header, ",") {
+		tag, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		tag = strings.TrimSpace(tag)
+		if tag == "" || tag == "*" {
+			continue
+		}
+
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		if q <= 0 {
+			continue
+		}
+		ranges = append(ranges, weighted{tag: tag, q: q})
+	}
+
+	sort.SliceStable(ranges, func(i, j int) bool {
+		return ranges[i].q > ranges[j].q
+	})
+
+	languages := make([]string, len(ranges))
+	for i, r := range ranges {
+		languages[i] = r.tag
+	}
+	return languages
+}
@@ -3,15 +3,25 @@ package handler
 import (
 	"log"
 	"net/http"
+	"slices"
 	"time"
 )
 
-// CORS adds CORS headers to responses
-func CORS(next http.Handler) http.Handler {
+// CORS adds CORS headers to responses. Requests from one of the allowed
+// origins may send credentials such as the admin session cookie; all other
+// origins get anonymous access.
+func CORS(next http.Handler, allowedOrigins ...string) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Access-Control-Allow-Origin", "*")
+		origin := r.Header.Get("Origin")
+		w.Header().Add("Vary", "Origin")
+		if origin != "" && slices.Contains(allowedOrigins, origin) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+		} else {
+			w.Header().Set("Access-Control-Allow-Origin", "*")
+		}
 		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization")
+		w.Header().Set("Access-Control-Allow-Headers", "Content-Type, Authorization, X-Session-Token, X-Tenant")
 
 		if r.Method == "OPTIONS" {
 			w.WriteHeader(http.StatusOK)
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// NormHandler handles HTTP requests for empirical norm snapshots
+type NormHandler struct {
+	service *service.NormSnapshotService
+}
+
+// NewNormHandler creates a new norm handler
+func NewNormHandler(svc *service.NormSnapshotService) *NormHandler {
+	return &NormHandler{service: svc}
+}
+
+// CreateSnapshot handles POST /api/admin/norms/snapshots
+func (h *NormHandler) CreateSnapshot(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateNormSnapshotRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	snapshot, err := h.service.CreateSnapshot(tenantFromContext(r.Context()), req)
+	if errors.Is(err, service.ErrInvalidSnapshot) || errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create norm snapshot")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, snapshot)
+}
+
+// GetSnapshots handles GET /api/admin/norms/snapshots
+func (h *NormHandler) GetSnapshots(w http.ResponseWriter, r *http.Request) {
+	snapshots, err := h.service.GetSnapshots(tenantFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to get norm snapshots")
+		return
+	}
+
+	if snapshots == nil {
+		snapshots = []*domain.NormSnapshot{}
+	}
+
+	writeJSON(w, http.StatusOK, snapshots)
+}
+
+// RefreshSnapshot handles POST /api/admin/norms/snapshots/{name}/refresh
+func (h *NormHandler) RefreshSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := h.service.RefreshSnapshot(tenantFromContext(r.Context()), r.PathValue("name"))
+	if errors.Is(err, repository.ErrNormSnapshotNotFound) {
+		writeError(w, http.StatusNotFound, "Norm snapshot not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to refresh norm snapshot")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, snapshot)
+}
+
+// Compare handles GET /api/results/{id}/compare?norm=
+func (h *NormHandler) Compare(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+	name := r.URL.Query().Get("norm")
+	if name == "" {
+		writeError(w, http.StatusBadRequest, "norm is required")
+		return
+	}
+
+	response, err := h.service.Compare(tenantFromContext(r.Context()), id, name)
+	switch {
+	case errors.Is(err, repository.ErrNotFound):
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	case errors.Is(err, repository.ErrNormSnapshotNotFound):
+		writeError(w, http.StatusNotFound, "Norm snapshot not found")
+		return
+	case errors.Is(err, service.ErrInstrumentMismatch):
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	case errors.Is(err, service.ErrInsufficientSample):
+		writeError(w, http.StatusConflict, "Norm group is too small to compare against")
+		return
+	case err != nil:
+		writeError(w, http.StatusInternalServerError, "Failed to compare result")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// PrivacyHandler handles HTTP requests for data exports and erasures
+type PrivacyHandler struct {
+	service *service.PrivacyService
+	tokens  *service.TokenService
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(svc *service.PrivacyService, tokens *service.TokenService) *PrivacyHandler {
+	return &PrivacyHandler{service: svc, tokens: tokens}
+}
+
+// Export handles GET /api/me/export
+func (h *PrivacyHandler) Export(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	export, err := h.service.Export(tenantFromContext(r.Context()), sessionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to export data")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="voca-export-%s.json"`, sessionID))
+	writeJSON(w, http.StatusOK, export)
+}
+
+// Erase handles DELETE /api/me
+func (h *PrivacyHandler) Erase(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	erasure, err := h.service.EraseSession(tenantFromContext(r.Context()), sessionID, domain.ErasureRequestedBySubject)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to erase data")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, erasure)
+}
+
+// EraseSession handles DELETE /api/admin/sessions/{id}
+func (h *PrivacyHandler) EraseSession(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	if sessionID == "" {
+		writeError(w, http.StatusBadRequest, "Session ID is required")
+		return
+	}
+
+	erasure, err := h.service.EraseSession(tenantFromContext(r.Context()), sessionID, principalFromContext(r.Context()).Name)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to erase session")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, erasure)
+}
+
+// EraseResult handles DELETE /api/admin/results/{id}
+func (h *PrivacyHandler) EraseResult(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	erasure, err := h.service.EraseResult(tenantFromContext(r.Context()), id, principalFromContext(r.Context()).Name)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to erase result")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, erasure)
+}
+
+// GetErasures handles GET /api/admin/erasures
+func (h *PrivacyHandler) GetErasures(w http.ResponseWriter, r *http.Request) {
+	erasures, err := h.service.GetErasures(tenantFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve erasures")
+		return
+	}
+
+	if erasures == nil {
+		erasures = []*domain.Erasure{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetErasuresResponse{Erasures: erasures})
+}
@@ -2,47 +2,146 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
 	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
 	"github.com/thielel/voca/internal/service"
 )
 
 // QuestionnaireHandler handles HTTP requests for the questionnaire
 type QuestionnaireHandler struct {
 	service *service.PersonalityService
+	tokens  *service.TokenService
+	cohorts *service.CohortService
 }
 
 // NewQuestionnaireHandler creates a new questionnaire handler
-func NewQuestionnaireHandler(svc *service.PersonalityService) *QuestionnaireHandler {
-	return &QuestionnaireHandler{service: svc}
+func NewQuestionnaireHandler(svc *service.PersonalityService, tokens *service.TokenService, cohorts *service.CohortService) *QuestionnaireHandler {
+	return &QuestionnaireHandler{service: svc, tokens: tokens, cohorts: cohorts}
 }
 
-// GetQuestions handles GET /api/questions
+// GetQuestions handles GET /api/questions?lang=
 func (h *QuestionnaireHandler) GetQuestions(w http.ResponseWriter, r *http.Request) {
-	questions := h.service.GetQuestions()
+	response := h.service.GetQuestions(tenantFromContext(r.Context()), preferredLanguages(r))
 
-	response := domain.GetQuestionsResponse{
-		Questions: questions,
+	w.Header().Set("Content-Language", response.Language)
+	w.Header().Add("Vary", "Accept-Language")
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetInstruments handles GET /api/instruments
+func (h *QuestionnaireHandler) GetInstruments(w http.ResponseWriter, r *http.Request) {
+	instruments := h.service.GetInstruments(tenantFromContext(r.Context()))
+
+	response := domain.GetInstrumentsResponse{
+		Instruments: make([]domain.InstrumentSummary, 0, len(instruments)),
+	}
+	for _, instrument := range instruments {
+		response.Instruments = append(response.Instruments, domain.InstrumentSummary{
+			Instrument: instrument,
+			ItemCount:  instrument.ItemCount(),
+		})
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// GetInstrumentQuestions handles GET /api/instruments/{id}/questions?lang=
+func (h *QuestionnaireHandler) GetInstrumentQuestions(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Instrument ID is required")
+		return
 	}
 
+	response, err := h.service.GetInstrumentQuestions(tenantFromContext(r.Context()), id, preferredLanguages(r))
+	if errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusNotFound, "Instrument not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve instrument")
+		return
+	}
+
+	w.Header().Set("Content-Language", response.Language)
+	w.Header().Add("Vary", "Accept-Language")
 	writeJSON(w, http.StatusOK, response)
 }
 
 // SubmitAnswers handles POST /api/results
 func (h *QuestionnaireHandler) SubmitAnswers(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
 	var req domain.SubmitAnswersRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		writeError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
+	req.SessionID = sessionID
 
 	if len(req.Answers) == 0 {
 		writeError(w, http.StatusBadRequest, "No answers provided")
 		return
 	}
 
-	result, err := h.service.CalculateResults(req.SessionID, req.Answers, req.Language)
+	if req.Age < 0 || req.Age > 120 {
+		writeError(w, http.StatusBadRequest, "Invalid age")
+		return
+	}
+
+	if req.DurationSeconds < 0 {
+		writeError(w, http.StatusBadRequest, "Invalid duration")
+		return
+	}
+	if req.StartedAt != nil && req.CompletedAt != nil && req.CompletedAt.Before(*req.StartedAt) {
+		writeError(w, http.StatusBadRequest, "completed_at must not be before started_at")
+		return
+	}
+	for _, answer := range req.Answers {
+		if answer.LatencyMs < 0 {
+			writeError(w, http.StatusBadRequest, "latency_ms must not be negative")
+			return
+		}
+	}
+
+	if req.CohortID, ok = resolveCohort(w, h.cohorts, tenantFromContext(r.Context()), req.CohortCode, req.InstrumentID); !ok {
+		return
+	}
+
+	result, err := h.service.CalculateResults(tenantFromContext(r.Context()), req)
+	if errors.Is(err, service.ErrUnknownInstrument) {
+		writeError(w, http.StatusBadRequest, "Unknown instrument")
+		return
+	}
+	if errors.Is(err, service.ErrUnknownTranslation) {
+		writeError(w, http.StatusBadRequest, "Unknown translation")
+		return
+	}
+	if errors.Is(err, service.ErrConsentRequired) || errors.Is(err, service.ErrGuardianConsentPending) {
+		writeError(w, http.StatusForbidden, consentErrorMessage(err))
+		return
+	}
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		writeJSON(w, http.StatusUnprocessableEntity, domain.ValidationErrorResponse{
+			Error:  "Invalid answers",
+			Issues: validationErr.Issues,
+		})
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to calculate results")
 		return
@@ -63,7 +162,7 @@ func (h *QuestionnaireHandler) GetResult(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	result, err := h.service.GetResult(id)
+	result, err := h.service.GetResult(tenantFromContext(r.Context()), id)
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to retrieve result")
 		return
@@ -77,19 +176,269 @@ func (h *QuestionnaireHandler) GetResult(w http.ResponseWriter, r *http.Request)
 	writeJSON(w, http.StatusOK, result)
 }
 
-// GetAllResults handles GET /api/admin/results
-func (h *QuestionnaireHandler) GetAllResults(w http.ResponseWriter, r *http.Request) {
-	results, err := h.service.GetAllResults()
+// GetResultAnswers handles GET /api/admin/results/{id}/answers
+func (h *QuestionnaireHandler) GetResultAnswers(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	answers, err := h.service.GetAnswers(tenantFromContext(r.Context()), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve answers")
+		return
+	}
+
+	if answers == nil {
+		answers = []*domain.StoredAnswer{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetAnswersResponse{
+		ResultID: id,
+		Answers:  answers,
+	})
+}
+
+// GetInterpretationHistory handles GET /api/admin/results/{id}/interpretations/history
+func (h *QuestionnaireHandler) GetInterpretationHistory(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	versions, err := h.service.GetInterpretationHistory(tenantFromContext(r.Context()), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
 	if err != nil {
-		writeError(w, http.StatusInternalServerError, "Failed to retrieve results")
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve interpretation history")
 		return
 	}
 
-	if results == nil {
-		results = []*domain.PersonalityResult{}
+	if versions == nil {
+		versions = []*domain.InterpretationVersion{}
 	}
 
-	writeJSON(w, http.StatusOK, results)
+	writeJSON(w, http.StatusOK, domain.GetInterpretationHistoryResponse{
+		ResultID: id,
+		Versions: versions,
+	})
+}
+
+// RollbackInterpretations handles POST /api/admin/results/{id}/interpretations/rollback
+func (h *QuestionnaireHandler) RollbackInterpretations(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	var req domain.RollbackInterpretationsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Version < 1 {
+		writeError(w, http.StatusBadRequest, "Version must be a positive number")
+		return
+	}
+
+	result, err := h.service.RollbackInterpretations(tenantFromContext(r.Context()), id, req.Version)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if errors.Is(err, repository.ErrInterpretationVersionNotFound) {
+		writeError(w, http.StatusNotFound, "Interpretation version not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to roll back interpretations")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+// GetAdminResult handles GET /api/admin/results/{id}
+func (h *QuestionnaireHandler) GetAdminResult(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	if id == "" {
+		writeError(w, http.StatusBadRequest, "Result ID is required")
+		return
+	}
+
+	response, err := h.service.GetAdminResult(tenantFromContext(r.Context()), id)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve result")
+		return
+	}
+
+	if response == nil {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, response)
+}
+
+// Page sizes of the admin result listing
+const (
+	defaultResultPageSize = 50
+	maxResultPageSize     = 200
+)
+
+// ListResults handles GET /api/admin/results?from=&to=&language=&cohort=&quality=&interpretations=&min_<scale>=&max_<scale>=&sort=&order=&cursor=&limit=
+func (h *QuestionnaireHandler) ListResults(w http.ResponseWriter, r *http.Request) {
+	query, err := parseResultListQuery(r.URL.Query())
+	if err != nil {
+		writeResultListError(w, err)
+		return
+	}
+
+	page, err := h.service.ListResults(tenantFromContext(r.Context()), query)
+	if err != nil {
+		writeResultListError(w, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, page)
+}
+
+// writeResultListError reports a rejected result listing query with an error
+// code naming the parameter at fault, or a failed one
+func writeResultListError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidFilter):
+		writeErrorCode(w, http.StatusBadRequest, "invalid_filter", err.Error())
+	case errors.Is(err, service.ErrInvalidSort):
+		writeErrorCode(w, http.StatusBadRequest, "invalid_sort", err.Error())
+	case errors.Is(err, errInvalidLimit):
+		writeErrorCode(w, http.StatusBadRequest, "invalid_limit", err.Error())
+	case errors.Is(err, repository.ErrInvalidCursor):
+		writeErrorCode(w, http.StatusBadRequest, "invalid_cursor", "cursor is invalid or belongs to another sort order")
+	default:
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve results")
+	}
+}
+
+// errInvalidLimit is returned for a result listing page size out of range
+var errInvalidLimit = fmt.Errorf("limit must be an integer from 1 to %d", maxResultPageSize)
+
+// parseResultListQuery reads the filters, order and page of the admin result
+// listing from query parameters
+func parseResultListQuery(values url.Values) (domain.ResultListQuery, error) {
+	query := domain.ResultListQuery{
+		Language:    values.Get("language"),
+		CohortID:    values.Get("cohort"),
+		QualityFlag: values.Get("quality"),
+		Cursor:      values.Get("cursor"),
+		Limit:       defaultResultPageSize,
+	}
+
+	switch query.QualityFlag {
+	case "", domain.QualityOK, domain.QualitySuspect, domain.QualityInvalid:
+	default:
+		return query, fmt.Errorf("%w: quality must be ok, suspect or invalid", service.ErrInvalidFilter)
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(values, "from"); err != nil {
+		return query, err
+	}
+	if query.To, err = parseTimeParam(values, "to"); err != nil {
+		return query, err
+	}
+	if query.From != nil && query.To != nil && !query.To.After(*query.From) {
+		return query, fmt.Errorf("%w: to must be after from", service.ErrInvalidFilter)
+	}
+
+	if value := values.Get("interpretations"); value != "" {
+		interpreted, err := strconv.ParseBool(value)
+		if err != nil {
+			return query, fmt.Errorf("%w: interpretations must be true or false", service.ErrInvalidFilter)
+		}
+		query.HasInterpretations = &interpreted
+	}
+
+	// Score ranges come as min_<scale> and max_<scale>, e.g. min_openness=60
+	ranges := make(map[domain.Trait]*domain.ScoreRange)
+	for key := range values {
+		bound, scale, ok := strings.Cut(key, "_")
+		if !ok || (bound != "min" && bound != "max") || scale == "" {
+			continue
+		}
+		score, err := strconv.ParseFloat(values.Get(key), 64)
+		if err != nil {
+			return query, fmt.Errorf("%w: %s must be a number", service.ErrInvalidFilter, key)
+		}
+		scoreRange, exists := ranges[domain.Trait(scale)]
+		if !exists {
+			scoreRange = &domain.ScoreRange{Scale: domain.Trait(scale)}
+			ranges[domain.Trait(scale)] = scoreRange
+		}
+		if bound == "min" {
+			scoreRange.Min = &score
+		} else {
+			scoreRange.Max = &score
+		}
+	}
+	for _, scale := range slices.Sorted(maps.Keys(ranges)) {
+		scoreRange := ranges[scale]
+		if scoreRange.Min != nil && scoreRange.Max != nil && *scoreRange.Min > *scoreRange.Max {
+			return query, fmt.Errorf("%w: min_%s must not be greater than max_%s", service.ErrInvalidFilter, scale, scale)
+		}
+		query.Scores = append(query.Scores, *scoreRange)
+	}
+
+	if sort := values.Get("sort"); sort != "" && sort != "created_at" {
+		query.SortScale = domain.Trait(sort)
+	}
+	switch values.Get("order") {
+	case "", "desc":
+	case "asc":
+		query.Ascending = true
+	default:
+		return query, fmt.Errorf("%w: order must be asc or desc", service.ErrInvalidSort)
+	}
+
+	if value := values.Get("limit"); value != "" {
+		limit, err := strconv.Atoi(value)
+		if err != nil || limit < 1 || limit > maxResultPageSize {
+			return query, errInvalidLimit
+		}
+		query.Limit = limit
+	}
+
+	return query, nil
+}
+
+// parseTimeParam reads an optional query parameter given as an RFC 3339
+// timestamp or as a date, which means midnight server time
+func parseTimeParam(values url.Values, name string) (*time.Time, error) {
+	value := values.Get(name)
+	if value == "" {
+		return nil, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return &t, nil
+	}
+	t, err := time.ParseInLocation(time.DateOnly, value, time.Local)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s must be a date (YYYY-MM-DD) or an RFC 3339 timestamp", service.ErrInvalidFilter, name)
+	}
+	return &t, nil
 }
 
 // RegenerateRequest represents the request body for regenerating interpretations
@@ -111,13 +460,33 @@ func (h *QuestionnaireHandler) RegenerateInterpretations(w http.ResponseWriter,
 		_ = json.NewDecoder(r.Body).Decode(&req)
 	}
 
-	// Default to German if no language specified
+	// Default to the tenant's language, or German if neither is specified
+	tenant := tenantFromContext(r.Context())
 	language := req.Language
+	if language == "" {
+		language = tenant.DefaultLanguage
+	}
 	if language == "" {
 		language = "de"
 	}
 
-	result, err := h.service.RegenerateInterpretations(id, language)
+	result, err := h.service.RegenerateInterpretations(tenant, id, language)
+	if errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Result not found")
+		return
+	}
+	if errors.Is(err, service.ErrInterpretationsDisabled) {
+		writeError(w, http.StatusConflict, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrInvalidProtocol) {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+	if errors.Is(err, service.ErrConsentRequired) || errors.Is(err, service.ErrGuardianConsentPending) {
+		writeError(w, http.StatusForbidden, consentErrorMessage(err))
+		return
+	}
 	if err != nil {
 		writeError(w, http.StatusInternalServerError, "Failed to regenerate interpretations: "+err.Error())
 		return
@@ -142,3 +511,8 @@ func writeJSON(w http.ResponseWriter, status int, data interface{}) {
 func writeError(w http.ResponseWriter, status int, message string) {
 	writeJSON(w, status, map[string]string{"error": message})
 }
+
+// writeErrorCode writes an error with a machine-readable code alongside the message
+func writeErrorCode(w http.ResponseWriter, status int, code, message string) {
+	writeJSON(w, status, map[string]string{"error": message, "code": code})
+}
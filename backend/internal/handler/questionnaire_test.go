@@ -0,0 +1,92 @@
+package handler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+func TestListResultsQuery(t *testing.T) {
+	svc := service.NewPersonalityService(repository.NewMemoryResultRepository(), nil,
+		service.NewDefaultInstrumentRegistry(), service.NewNormRegistry(), nil, nil, false)
+	h := NewQuestionnaireHandler(svc, nil, nil)
+	tenant := &domain.Tenant{ID: "default"}
+
+	tests := []struct {
+		name   string
+		query  string
+		status int
+		code   string
+	}{
+		{"defaults", "", http.StatusOK, ""},
+		{"filtered and sorted", "from=2026-01-01&to=2026-02-01T00:00:00Z&min_openness=20&max_openness=80&sort=openness&order=asc&limit=10", http.StatusOK, ""},
+		{"limit not a number", "limit=ten", http.StatusBadRequest, "invalid_limit"},
+		{"limit zero", "limit=0", http.StatusBadRequest, "invalid_limit"},
+		{"limit too large", "limit=201", http.StatusBadRequest, "invalid_limit"},
+		{"malformed cursor", "cursor=garbage", http.StatusBadRequest, "invalid_cursor"},
+		{"cursor of another order", "cursor=" + cursorOf(t, tenant, "sort=openness") + "&order=asc", http.StatusBadRequest, "invalid_cursor"},
+		{"inverted score range", "min_openness=80&max_openness=20", http.StatusBadRequest, "invalid_filter"},
+		{"score not a number", "min_openness=high", http.StatusBadRequest, "invalid_filter"},
+		{"unknown scale", "min_charisma=10", http.StatusBadRequest, "invalid_filter"},
+		{"malformed from", "from=garbage", http.StatusBadRequest, "invalid_filter"},
+		{"malformed to", "to=2026-13-01", http.StatusBadRequest, "invalid_filter"},
+		{"to before from", "from=2026-02-01&to=2026-01-01", http.StatusBadRequest, "invalid_filter"},
+		{"unknown quality", "quality=great", http.StatusBadRequest, "invalid_filter"},
+		{"unknown sort scale", "sort=charisma", http.StatusBadRequest, "invalid_sort"},
+		{"unknown order", "order=up", http.StatusBadRequest, "invalid_sort"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := listResults(h, tenant, tt.query)
+			if rec.Code != tt.status {
+				t.Fatalf("GET ?%s = %d %s, want %d", tt.query, rec.Code, rec.Body, tt.status)
+			}
+			if tt.code == "" {
+				return
+			}
+			var body map[string]string
+			if err := json.NewDecoder(rec.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body["code"] != tt.code {
+				t.Errorf("GET ?%s returned code %q, want %q", tt.query, body["code"], tt.code)
+			}
+		})
+	}
+}
+
+// listResults serves an admin result listing request of a tenant
+func listResults(h *QuestionnaireHandler, tenant *domain.Tenant, query string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, "/api/admin/results?"+query, nil)
+	r = r.WithContext(context.WithValue(r.Context(), tenantKey{}, tenant))
+	rec := httptest.NewRecorder()
+	h.ListResults(rec, r)
+	return rec
+}
+
+// cursorOf returns the cursor to the second page of a listing with one result
+// per page
+func cursorOf(t *testing.T, tenant *domain.Tenant, query string) string {
+	t.Helper()
+	repo := repository.NewMemoryResultRepository()
+	for _, id := range []string{"a", "b"} {
+		result := &domain.PersonalityResult{ID: id, TenantID: tenant.ID, InstrumentID: "ipip-50", Openness: 50}
+		if err := repo.Save(result, nil); err != nil {
+			t.Fatal(err)
+		}
+	}
+	svc := service.NewPersonalityService(repo, nil, service.NewDefaultInstrumentRegistry(), service.NewNormRegistry(), nil, nil, false)
+	rec := listResults(NewQuestionnaireHandler(svc, nil, nil), tenant, query+"&limit=1")
+	var page domain.ResultPage
+	if err := json.NewDecoder(rec.Body).Decode(&page); err != nil || page.NextCursor == "" {
+		t.Fatalf("listing %s returned no cursor: %d %v", query, rec.Code, err)
+	}
+	return page.NextCursor
+}
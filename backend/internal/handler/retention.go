@@ -0,0 +1,54 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/service"
+)
+
+// RetentionHandler handles HTTP requests for retention purges
+type RetentionHandler struct {
+	service *service.RetentionService
+}
+
+// NewRetentionHandler creates a new retention handler
+func NewRetentionHandler(svc *service.RetentionService) *RetentionHandler {
+	return &RetentionHandler{service: svc}
+}
+
+// Purge handles POST /api/admin/purges?dry_run=
+func (h *RetentionHandler) Purge(w http.ResponseWriter, r *http.Request) {
+	dryRun := false
+	if value := r.URL.Query().Get("dry_run"); value != "" {
+		var err error
+		if dryRun, err = strconv.ParseBool(value); err != nil {
+			writeError(w, http.StatusBadRequest, "dry_run must be true or false")
+			return
+		}
+	}
+
+	report, err := h.service.Purge(tenantFromContext(r.Context()), dryRun)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to apply retention rules")
+		return
+	}
+
+	writeJSON(w, http.StatusOK, report)
+}
+
+// GetPurges handles GET /api/admin/purges
+func (h *RetentionHandler) GetPurges(w http.ResponseWriter, r *http.Request) {
+	purges, err := h.service.GetPurges(tenantFromContext(r.Context()))
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve purges")
+		return
+	}
+
+	if purges == nil {
+		purges = []*domain.PurgeReport{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetPurgesResponse{Purges: purges})
+}
@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// sessionTokenHeader carries the signed session token on respondent requests
+const sessionTokenHeader = "X-Session-Token"
+
+// SessionHandler handles HTTP requests for session tokens and session results
+type SessionHandler struct {
+	tokens  *service.TokenService
+	service *service.PersonalityService
+}
+
+// NewSessionHandler creates a new session handler
+func NewSessionHandler(tokens *service.TokenService, svc *service.PersonalityService) *SessionHandler {
+	return &SessionHandler{tokens: tokens, service: svc}
+}
+
+// CreateToken handles POST /api/session-tokens
+func (h *SessionHandler) CreateToken(w http.ResponseWriter, r *http.Request) {
+	token, err := h.tokens.Issue()
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create session token")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, token)
+}
+
+// GetResults handles GET /api/session/results
+func (h *SessionHandler) GetResults(w http.ResponseWriter, r *http.Request) {
+	sessionID, ok := requireSession(w, r, h.tokens)
+	if !ok {
+		return
+	}
+
+	h.writeSessionResults(w, r, sessionID)
+}
+
+// GetAdminResults handles GET /api/admin/sessions/{id}/results
+func (h *SessionHandler) GetAdminResults(w http.ResponseWriter, r *http.Request) {
+	sessionID := r.PathValue("id")
+	if sessionID == "" {
+		writeError(w, http.StatusBadRequest, "Session ID is required")
+		return
+	}
+
+	h.writeSessionResults(w, r, sessionID)
+}
+
+// RequireOwner restricts a route with an {id} result parameter to requests
+// carrying the session token the result was created with
+func (h *SessionHandler) RequireOwner(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		sessionID, ok := requireSession(w, r, h.tokens)
+		if !ok {
+			return
+		}
+
+		err := h.service.CheckOwner(tenantFromContext(r.Context()), r.PathValue("id"), sessionID)
+		if errors.Is(err, repository.ErrNotFound) {
+			writeError(w, http.StatusNotFound, "Result not found")
+			return
+		}
+		if errors.Is(err, service.ErrNotOwner) {
+			writeError(w, http.StatusForbidden, "Result belongs to another session")
+			return
+		}
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, "Failed to retrieve result")
+			return
+		}
+
+		next(w, r)
+	}
+}
+
+// writeSessionResults responds with all results of a session within the request's tenant
+func (h *SessionHandler) writeSessionResults(w http.ResponseWriter, r *http.Request, sessionID string) {
+	results, err := h.service.GetSessionResults(tenantFromContext(r.Context()), sessionID)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve results")
+		return
+	}
+
+	if results == nil {
+		results = []*domain.PersonalityResult{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetSessionResultsResponse{
+		SessionID: sessionID,
+		Results:   results,
+	})
+}
+
+// requireSession verifies the session token of a request and returns its
+// session ID. It writes a 401 response and returns false if the token is
+// missing, forged or expired.
+func requireSession(w http.ResponseWriter, r *http.Request, tokens *service.TokenService) (string, bool) {
+	token := r.Header.Get(sessionTokenHeader)
+	if token == "" {
+		writeError(w, http.StatusUnauthorized, "Session token required")
+		return "", false
+	}
+
+	sessionID, err := tokens.Verify(token)
+	if errors.Is(err, service.ErrTokenExpired) {
+		writeError(w, http.StatusUnauthorized, "Session token expired")
+		return "", false
+	}
+	if err != nil {
+		writeError(w, http.StatusUnauthorized, "Invalid session token")
+		return "", false
+	}
+
+	return sessionID, true
+}
@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// ShareHandler handles HTTP requests for result share links
+type ShareHandler struct {
+	service *service.ShareService
+}
+
+// NewShareHandler creates a new share handler
+func NewShareHandler(svc *service.ShareService) *ShareHandler {
+	return &ShareHandler{service: svc}
+}
+
+// CreateShare handles POST /api/results/{id}/shares
+func (h *ShareHandler) CreateShare(w http.ResponseWriter, r *http.Request) {
+	var req domain.CreateShareLinkRequest
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+	}
+
+	link, err := h.service.CreateShare(tenantFromContext(r.Context()), r.PathValue("id"), req)
+	if errors.Is(err, service.ErrInvalidShare) {
+		writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to create share link")
+		return
+	}
+
+	writeJSON(w, http.StatusCreated, link)
+}
+
+// GetShares handles GET /api/results/{id}/shares
+func (h *ShareHandler) GetShares(w http.ResponseWriter, r *http.Request) {
+	id := r.PathValue("id")
+	links, err := h.service.GetShares(tenantFromContext(r.Context()), id)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve share links")
+		return
+	}
+
+	if links == nil {
+		links = []*domain.ShareLink{}
+	}
+
+	writeJSON(w, http.StatusOK, domain.GetShareLinksResponse{
+		ResultID: id,
+		Shares:   links,
+	})
+}
+
+// RevokeShare handles DELETE /api/results/{id}/shares/{shareID}
+func (h *ShareHandler) RevokeShare(w http.ResponseWriter, r *http.Request) {
+	err := h.service.RevokeShare(tenantFromContext(r.Context()), r.PathValue("id"), r.PathValue("shareID"))
+	if errors.Is(err, repository.ErrShareNotFound) {
+		writeError(w, http.StatusNotFound, "Share link not found")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to revoke share link")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GetSharedResult handles GET /api/shared/{token}
+func (h *ShareHandler) GetSharedResult(w http.ResponseWriter, r *http.Request) {
+	response, err := h.service.OpenShare(tenantFromContext(r.Context()), r.PathValue("token"))
+	if errors.Is(err, repository.ErrShareNotFound) || errors.Is(err, repository.ErrNotFound) {
+		writeError(w, http.StatusNotFound, "Share link not found")
+		return
+	}
+	if errors.Is(err, service.ErrShareInactive) {
+		writeError(w, http.StatusGone, "Share link revoked or expired")
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, "Failed to retrieve shared result")
+		return
+	}
+
+	w.Header().Set("Cache-Control", "no-store")
+	writeJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/service"
+)
+
+// tenantHeader names the tenant of a request explicitly, e.g. when several
+// tenants share the API host
+const tenantHeader = "X-Tenant"
+
+// tenantKey is the request context key of the request's tenant
+type tenantKey struct{}
+
+// Tenant resolves the tenant of every request from the X-Tenant header or,
+// without the header, from the request host. Hosts not mapped to a tenant
+// are served as the default tenant.
+func Tenant(next http.Handler, tenants *service.TenantRegistry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		tenant := tenants.ForHost(r.Host)
+		if id := r.Header.Get(tenantHeader); id != "" {
+			named, err := tenants.Get(id)
+			if err != nil {
+				writeError(w, http.StatusBadRequest, "Unknown tenant")
+				return
+			}
+			tenant = named
+		}
+
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), tenantKey{}, tenant)))
+	})
+}
+
+// tenantFromContext returns the tenant stored by Tenant
+func tenantFromContext(ctx context.Context) *domain.Tenant {
+	tenant, _ := ctx.Value(tenantKey{}).(*domain.Tenant)
+	return tenant
+}
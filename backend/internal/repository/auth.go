@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+var (
+	// ErrUserNotFound is returned when an admin user is not found
+	ErrUserNotFound = errors.New("admin user not found")
+	// ErrAdminSessionNotFound is returned when an admin session is not found
+	ErrAdminSessionNotFound = errors.New("admin session not found")
+	// ErrAPIKeyNotFound is returned when an API key is not found
+	ErrAPIKeyNotFound = errors.New("api key not found")
+)
+
+// AuthRepository handles database operations for admin users, admin
+// sessions and API keys
+type AuthRepository struct {
+	db *sql.DB
+}
+
+// NewAuthRepository creates a new auth repository
+func NewAuthRepository(db *sql.DB) *AuthRepository {
+	return &AuthRepository{db: db}
+}
+
+// userColumns lists the admin_users columns read by scanUser
+const userColumns = `id, tenant_id, username, role, created_at, last_login_at`
+
+// apiKeyColumns lists the api_keys columns read by scanAPIKey
+const apiKeyColumns = `id, tenant_id, name, prefix, role, created_by, created_at,
+			last_used_at, revoked_at`
+
+// CreateUser stores an admin user with its password hash
+func (r *AuthRepository) CreateUser(user *domain.AdminUser, passwordHash string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO admin_users (id, tenant_id, username, password_hash, role, created_at)
+		VALUES (?, ?, ?, ?, ?, ?)
+	`, user.ID, user.TenantID, user.Username, passwordHash, string(user.Role), formatOptionalTime(&user.CreatedAt))
+	return err
+}
+
+// UsernameExists reports whether an admin user with the username exists in
+// any tenant. Usernames are unique across tenants.
+func (r *AuthRepository) UsernameExists(username string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM admin_users WHERE username = ?)`, username).Scan(&exists)
+	return exists, err
+}
+
+// GetUserByUsername retrieves an admin user of a tenant and its password hash
+func (r *AuthRepository) GetUserByUsername(tenantID, username string) (*domain.AdminUser, string, error) {
+	var passwordHash string
+	user, err := scanUser(r.db.QueryRow(`
+		SELECT `+userColumns+`, password_hash
+		FROM admin_users
+		WHERE tenant_id = ? AND username = ?
+	`, tenantID, username), &passwordHash)
+	if err == sql.ErrNoRows {
+		return nil, "", ErrUserNotFound
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	return user, passwordHash, nil
+}
+
+// GetUsers retrieves the admin users of a tenant ordered by username
+func (r *AuthRepository) GetUsers(tenantID string) ([]*domain.AdminUser, error) {
+	rows, err := r.db.Query(`
+		SELECT `+userColumns+`
+		FROM admin_users
+		WHERE tenant_id = ?
+		ORDER BY username
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var users []*domain.AdminUser
+	for rows.Next() {
+		user, err := scanUser(rows)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+
+	return users, rows.Err()
+}
+
+// RecordLogin stores the time of a user's latest sign-in
+func (r *AuthRepository) RecordLogin(userID string, at time.Time) error {
+	_, err := r.db.Exec(`UPDATE admin_users SET last_login_at = ? WHERE id = ?`, formatOptionalTime(&at), userID)
+	return err
+}
+
+// CreateSession stores an admin session under the hash of its token
+func (r *AuthRepository) CreateSession(tokenHash, userID string, createdAt, expiresAt time.Time) error {
+	_, err := r.db.Exec(`
+		INSERT INTO admin_sessions (token_hash, user_id, created_at, expires_at)
+		VALUES (?, ?, ?, ?)
+	`, tokenHash, userID, formatOptionalTime(&createdAt), formatOptionalTime(&expiresAt))
+	return err
+}
+
+// GetSessionUser retrieves the user of an admin session and the session's
+// expiry by the hash of its token. Sessions of another tenant's users are
+// not found.
+func (r *AuthRepository) GetSessionUser(tenantID, tokenHash string) (*domain.AdminUser, time.Time, error) {
+	var expiresAtStr string
+	user, err := scanUser(r.db.QueryRow(`
+		SELECT u.id, u.tenant_id, u.username, u.role, u.created_at, u.last_login_at, s.expires_at
+		FROM admin_sessions s
+		JOIN admin_users u ON u.id = s.user_id
+		WHERE u.tenant_id = ? AND s.token_hash = ?
+	`, tenantID, tokenHash), &expiresAtStr)
+	if err == sql.ErrNoRows {
+		return nil, time.Time{}, ErrAdminSessionNotFound
+	}
+	if err != nil {
+		return nil, time.Time{}, err
+	}
+
+	var expiresAt time.Time
+	if t := parseOptionalTime(expiresAtStr); t != nil {
+		expiresAt = *t
+	}
+	return user, expiresAt, nil
+}
+
+// DeleteSession removes an admin session by the hash of its token
+func (r *AuthRepository) DeleteSession(tokenHash string) error {
+	_, err := r.db.Exec(`DELETE FROM admin_sessions WHERE token_hash = ?`, tokenHash)
+	return err
+}
+
+// DeleteExpiredSessions removes admin sessions that expired before the given time
+func (r *AuthRepository) DeleteExpiredSessions(before time.Time) error {
+	_, err := r.db.Exec(`DELETE FROM admin_sessions WHERE expires_at < ?`, formatOptionalTime(&before))
+	return err
+}
+
+// CreateAPIKey stores an API key under the hash of the key
+func (r *AuthRepository) CreateAPIKey(key *domain.APIKey, keyHash string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO api_keys (id, tenant_id, name, key_hash, prefix, role, created_by, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`, key.ID, key.TenantID, key.Name, keyHash, key.Prefix, string(key.Role), key.CreatedBy, formatOptionalTime(&key.CreatedAt))
+	return err
+}
+
+// GetAPIKeyByHash retrieves an API key of a tenant by the hash of the key
+func (r *AuthRepository) GetAPIKeyByHash(tenantID, keyHash string) (*domain.APIKey, error) {
+	key, err := scanAPIKey(r.db.QueryRow(`
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE tenant_id = ? AND key_hash = ?
+	`, tenantID, keyHash))
+	if err == sql.ErrNoRows {
+		return nil, ErrAPIKeyNotFound
+	}
+	return key, err
+}
+
+// GetAPIKeys retrieves the API keys of a tenant, newest first
+func (r *AuthRepository) GetAPIKeys(tenantID string) ([]*domain.APIKey, error) {
+	rows, err := r.db.Query(`
+		SELECT `+apiKeyColumns+`
+		FROM api_keys
+		WHERE tenant_id = ?
+		ORDER BY created_at DESC
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys []*domain.APIKey
+	for rows.Next() {
+		key, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, rows.Err()
+}
+
+// RevokeAPIKey marks an API key of a tenant as revoked. Revoking twice keeps
+// the original revocation time.
+func (r *AuthRepository) RevokeAPIKey(tenantID, id string, revokedAt time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE api_keys
+		SET revoked_at = CASE WHEN revoked_at = '' THEN ? ELSE revoked_at END
+		WHERE tenant_id = ? AND id = ?
+	`, formatOptionalTime(&revokedAt), tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// RecordAPIKeyUse stores the time an API key was last used
+func (r *AuthRepository) RecordAPIKeyUse(id string, at time.Time) error {
+	_, err := r.db.Exec(`UPDATE api_keys SET last_used_at = ? WHERE id = ?`, formatOptionalTime(&at), id)
+	return err
+}
+
+// scanUser reads an admin user selected with userColumns, followed by any
+// extra columns
+func scanUser(row rowScanner, extra ...any) (*domain.AdminUser, error) {
+	user := &domain.AdminUser{}
+	var role, createdAtStr, lastLoginAtStr string
+	dest := append([]any{&user.ID, &user.TenantID, &user.Username, &role, &createdAtStr, &lastLoginAtStr}, extra...)
+	if err := row.Scan(dest...); err != nil {
+		return nil, err
+	}
+	user.Role = domain.Role(role)
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		user.CreatedAt = *createdAt
+	}
+	user.LastLoginAt = parseOptionalTime(lastLoginAtStr)
+	return user, nil
+}
+
+// scanAPIKey reads an API key selected with apiKeyColumns
+func scanAPIKey(row rowScanner) (*domain.APIKey, error) {
+	key := &domain.APIKey{}
+	var role, createdAtStr, lastUsedAtStr, revokedAtStr string
+	err := row.Scan(
+		&key.ID,
+		&key.TenantID,
+		&key.Name,
+		&key.Prefix,
+		&role,
+		&key.CreatedBy,
+		&createdAtStr,
+		&lastUsedAtStr,
+		&revokedAtStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+	key.Role = domain.Role(role)
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		key.CreatedAt = *createdAt
+	}
+	key.LastUsedAt = parseOptionalTime(lastUsedAtStr)
+	key.RevokedAt = parseOptionalTime(revokedAtStr)
+	return key, nil
+}
@@ -0,0 +1,175 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+var (
+	// ErrOrganizationNotFound is returned when an organization is not found
+	ErrOrganizationNotFound = errors.New("organization not found")
+	// ErrCohortNotFound is returned when a cohort is not found
+	ErrCohortNotFound = errors.New("cohort not found")
+)
+
+// CohortRepository handles database operations for organizations and cohorts
+type CohortRepository struct {
+	db *sql.DB
+}
+
+// NewCohortRepository creates a new cohort repository
+func NewCohortRepository(db *sql.DB) *CohortRepository {
+	return &CohortRepository{db: db}
+}
+
+// cohortColumns lists the cohorts columns read by scanCohort
+const cohortColumns = `id, tenant_id, organization_id, name, instrument_id, join_code, created_by, created_at`
+
+// CreateOrganization stores a new organization
+func (r *CohortRepository) CreateOrganization(org *domain.Organization) error {
+	_, err := r.db.Exec(`
+		INSERT INTO organizations (id, tenant_id, name, created_at) VALUES (?, ?, ?, ?)
+	`, org.ID, org.TenantID, org.Name, formatOptionalTime(&org.CreatedAt))
+	return err
+}
+
+// GetOrganization retrieves an organization of a tenant by ID
+func (r *CohortRepository) GetOrganization(tenantID, id string) (*domain.Organization, error) {
+	org := &domain.Organization{}
+	var createdAtStr string
+	err := r.db.QueryRow(`
+		SELECT id, tenant_id, name, created_at FROM organizations WHERE tenant_id = ? AND id = ?
+	`, tenantID, id).Scan(&org.ID, &org.TenantID, &org.Name, &createdAtStr)
+	if err == sql.ErrNoRows {
+		return nil, ErrOrganizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		org.CreatedAt = *createdAt
+	}
+	return org, nil
+}
+
+// GetOrganizations retrieves the organizations of a tenant ordered by name
+func (r *CohortRepository) GetOrganizations(tenantID string) ([]*domain.Organization, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tenant_id, name, created_at FROM organizations WHERE tenant_id = ? ORDER BY name
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orgs []*domain.Organization
+	for rows.Next() {
+		org := &domain.Organization{}
+		var createdAtStr string
+		if err := rows.Scan(&org.ID, &org.TenantID, &org.Name, &createdAtStr); err != nil {
+			return nil, err
+		}
+		if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+			org.CreatedAt = *createdAt
+		}
+		orgs = append(orgs, org)
+	}
+
+	return orgs, rows.Err()
+}
+
+// Create stores a new cohort
+func (r *CohortRepository) Create(cohort *domain.Cohort) error {
+	_, err := r.db.Exec(`
+		INSERT INTO cohorts (`+cohortColumns+`) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		cohort.ID,
+		cohort.TenantID,
+		cohort.OrganizationID,
+		cohort.Name,
+		cohort.InstrumentID,
+		cohort.JoinCode,
+		cohort.CreatedBy,
+		formatOptionalTime(&cohort.CreatedAt),
+	)
+	return err
+}
+
+// JoinCodeExists reports whether a join code is already in use in any tenant
+func (r *CohortRepository) JoinCodeExists(code string) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`SELECT EXISTS(SELECT 1 FROM cohorts WHERE join_code = ?)`, code).Scan(&exists)
+	return exists, err
+}
+
+// GetByID retrieves a cohort of a tenant by ID
+func (r *CohortRepository) GetByID(tenantID, id string) (*domain.Cohort, error) {
+	return r.getCohort(`SELECT `+cohortColumns+` FROM cohorts WHERE tenant_id = ? AND id = ?`, tenantID, id)
+}
+
+// GetByJoinCode retrieves a cohort of a tenant by its join code
+func (r *CohortRepository) GetByJoinCode(tenantID, code string) (*domain.Cohort, error) {
+	return r.getCohort(`SELECT `+cohortColumns+` FROM cohorts WHERE tenant_id = ? AND join_code = ?`, tenantID, code)
+}
+
+// getCohort runs a query selecting a single cohort
+func (r *CohortRepository) getCohort(query string, args ...any) (*domain.Cohort, error) {
+	cohort, err := scanCohort(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrCohortNotFound
+	}
+	return cohort, err
+}
+
+// GetCohorts retrieves the cohorts of a tenant, newest first. A non-empty
+// createdBy returns only the cohorts created by that admin user or API key.
+func (r *CohortRepository) GetCohorts(tenantID, createdBy string) ([]*domain.Cohort, error) {
+	query := `
+		SELECT ` + cohortColumns + `
+		FROM cohorts
+		WHERE tenant_id = ? AND (? = '' OR created_by = ?)
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, tenantID, createdBy, createdBy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var cohorts []*domain.Cohort
+	for rows.Next() {
+		cohort, err := scanCohort(rows)
+		if err != nil {
+			return nil, err
+		}
+		cohorts = append(cohorts, cohort)
+	}
+
+	return cohorts, rows.Err()
+}
+
+// scanCohort reads a cohort selected with cohortColumns
+func scanCohort(row rowScanner) (*domain.Cohort, error) {
+	cohort := &domain.Cohort{}
+	var createdAtStr string
+	err := row.Scan(
+		&cohort.ID,
+		&cohort.TenantID,
+		&cohort.OrganizationID,
+		&cohort.Name,
+		&cohort.InstrumentID,
+		&cohort.JoinCode,
+		&cohort.CreatedBy,
+		&createdAtStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		cohort.CreatedAt = *createdAt
+	}
+	return cohort, nil
+}
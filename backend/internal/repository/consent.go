@@ -0,0 +1,292 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thielel/voca/data"
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrConsentNotFound is returned when a consent record is not found
+var ErrConsentNotFound = errors.New("consent record not found")
+
+// LoadConsentTexts reads the bundled consent texts and, if dir is set, every
+// *.json consent text in that directory. Files are returned in name order.
+func LoadConsentTexts(dir string) ([]*domain.ConsentText, error) {
+	bundled, err := fs.Sub(data.FS, data.ConsentDir)
+	if err != nil {
+		return nil, err
+	}
+
+	texts, err := readConsentTexts(bundled)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		extra, err := readConsentTexts(os.DirFS(dir))
+		if err != nil {
+			return nil, err
+		}
+		texts = append(texts, extra...)
+	}
+
+	return texts, nil
+}
+
+// readConsentTexts parses all *.json files at the root of fsys
+func readConsentTexts(fsys fs.FS) ([]*domain.ConsentText, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var texts []*domain.ConsentText
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var text domain.ConsentText
+		if err := json.Unmarshal(content, &text); err != nil {
+			return nil, fmt.Errorf("invalid consent text %s: %w", path.Base(name), err)
+		}
+		if text.Language == "" || text.Version == "" {
+			return nil, fmt.Errorf("invalid consent text %s: language and version are required", path.Base(name))
+		}
+		texts = append(texts, &text)
+	}
+	return texts, nil
+}
+
+// ConsentRepository handles database operations for consent records
+type ConsentRepository struct {
+	db *sql.DB
+}
+
+// NewConsentRepository creates a new consent record repository
+func NewConsentRepository(db *sql.DB) *ConsentRepository {
+	return &ConsentRepository{db: db}
+}
+
+// consentColumns lists the consent_records columns read by scanConsent
+const consentColumns = `id, tenant_id, session_id, text, purposes, age, guardian_required,
+			guardian_expires_at, guardian_confirmed_at, created_at, withdrawn_at`
+
+// Create stores a consent record. guardianCodeHash is the hash of the code a
+// guardian confirms the consent with, or empty if no guardian is involved.
+func (r *ConsentRepository) Create(record *domain.ConsentRecord, guardianCodeHash string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO consent_records (`+consentColumns+`, guardian_code_hash)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		record.ID,
+		record.TenantID,
+		record.SessionID,
+		record.Text,
+		strings.Join(record.Purposes, ","),
+		record.Age,
+		record.GuardianRequired,
+		formatOptionalTime(record.GuardianExpiresAt),
+		formatOptionalTime(record.GuardianConfirmedAt),
+		formatOptionalTime(&record.CreatedAt),
+		formatOptionalTime(record.WithdrawnAt),
+		guardianCodeHash,
+	)
+	return err
+}
+
+// GetLatest retrieves the most recent consent record of a session within a
+// tenant, which may have been withdrawn
+func (r *ConsentRepository) GetLatest(tenantID, sessionID string) (*domain.ConsentRecord, error) {
+	return r.getConsent(`
+		SELECT `+consentColumns+`
+		FROM consent_records
+		WHERE tenant_id = ? AND session_id = ?
+		ORDER BY rowid DESC
+		LIMIT 1
+	`, tenantID, sessionID)
+}
+
+// GetByGuardianCodeHash follows a guardian code to the most recent consent
+// record of the session it was issued for. current reports whether that
+// record still carries the code: records replacing a partially withdrawn one
+// keep it, consent granted anew comes with a new code or none.
+func (r *ConsentRepository) GetByGuardianCodeHash(tenantID, codeHash string) (record *domain.ConsentRecord, current bool, err error) {
+	var latestCodeHash string
+	record, err = scanConsent(r.db.QueryRow(`
+		SELECT `+consentColumns+`, guardian_code_hash
+		FROM consent_records
+		WHERE tenant_id = ? AND session_id = (
+			SELECT session_id
+			FROM consent_records
+			WHERE tenant_id = ? AND guardian_code_hash = ?
+			LIMIT 1
+		)
+		ORDER BY rowid DESC
+		LIMIT 1
+	`, tenantID, tenantID, codeHash), &latestCodeHash)
+	if err == sql.ErrNoRows {
+		return nil, false, ErrConsentNotFound
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return record, latestCodeHash == codeHash, nil
+}
+
+// GetBySessionID retrieves all consent records of a session within a
+// tenant, oldest first
+func (r *ConsentRepository) GetBySessionID(tenantID, sessionID string) ([]*domain.ConsentRecord, error) {
+	rows, err := r.db.Query(`
+		SELECT `+consentColumns+`
+		FROM consent_records
+		WHERE tenant_id = ? AND session_id = ?
+		ORDER BY rowid
+	`, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var records []*domain.ConsentRecord
+	for rows.Next() {
+		record, err := scanConsent(rows)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, rows.Err()
+}
+
+// getConsent runs a query for a single consent record
+func (r *ConsentRepository) getConsent(query string, args ...any) (*domain.ConsentRecord, error) {
+	record, err := scanConsent(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrConsentNotFound
+	}
+	return record, err
+}
+
+// scanConsent reads a consent record selected with consentColumns, followed
+// by any extra columns
+func scanConsent(row rowScanner, extra ...any) (*domain.ConsentRecord, error) {
+	record := &domain.ConsentRecord{}
+	var purposes, guardianExpiresAtStr, guardianConfirmedAtStr, createdAtStr, withdrawnAtStr string
+	dest := []any{
+		&record.ID,
+		&record.TenantID,
+		&record.SessionID,
+		&record.Text,
+		&purposes,
+		&record.Age,
+		&record.GuardianRequired,
+		&guardianExpiresAtStr,
+		&guardianConfirmedAtStr,
+		&createdAtStr,
+		&withdrawnAtStr,
+	}
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+	record.Purposes = []string{}
+	if purposes != "" {
+		record.Purposes = strings.Split(purposes, ",")
+	}
+	record.GuardianExpiresAt = parseOptionalTime(guardianExpiresAtStr)
+	record.GuardianConfirmedAt = parseOptionalTime(guardianConfirmedAtStr)
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		record.CreatedAt = *createdAt
+	}
+	record.WithdrawnAt = parseOptionalTime(withdrawnAtStr)
+	return record, nil
+}
+
+// ConfirmGuardian records a guardian's confirmation of a consent record of a
+// tenant. Confirming twice keeps the original confirmation time.
+func (r *ConsentRepository) ConfirmGuardian(tenantID, id string, confirmedAt time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE consent_records
+		SET guardian_confirmed_at = CASE WHEN guardian_confirmed_at = '' THEN ? ELSE guardian_confirmed_at END
+		WHERE tenant_id = ? AND id = ? AND withdrawn_at = ''
+	`, formatOptionalTime(&confirmedAt), tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrConsentNotFound
+	}
+	return nil
+}
+
+// Withdraw marks a consent record of a tenant as withdrawn. If successor is
+// set, it is stored in the same transaction as the record for the purposes
+// that remain, keeping the withdrawn record's guardian code.
+func (r *ConsentRepository) Withdraw(tenantID, id string, withdrawnAt time.Time, successor *domain.ConsentRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE consent_records
+		SET withdrawn_at = ?
+		WHERE tenant_id = ? AND id = ? AND withdrawn_at = ''
+	`, formatOptionalTime(&withdrawnAt), tenantID, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrConsentNotFound
+	}
+
+	if successor != nil {
+		_, err = tx.Exec(`
+			INSERT INTO consent_records (`+consentColumns+`, guardian_code_hash)
+			SELECT ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, '', guardian_code_hash
+			FROM consent_records
+			WHERE id = ?
+		`,
+			successor.ID,
+			successor.TenantID,
+			successor.SessionID,
+			successor.Text,
+			strings.Join(successor.Purposes, ","),
+			successor.Age,
+			successor.GuardianRequired,
+			formatOptionalTime(successor.GuardianExpiresAt),
+			formatOptionalTime(successor.GuardianConfirmedAt),
+			formatOptionalTime(&successor.CreatedAt),
+			id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
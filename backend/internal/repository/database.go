@@ -5,12 +5,37 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
 
-// InitDB initializes the SQLite database and runs migrations
+// InitDB opens the SQLite database and applies pending migrations. It fails
+// if the database was migrated by a newer build or an applied migration was
+// modified.
 func InitDB(dbPath string) (*sql.DB, error) {
+	db, err := OpenDB(dbPath)
+	if err != nil {
+		return nil, err
+	}
+
+	migrator, err := NewMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	log.Printf("Database initialized at %s (schema version %d, %d migrations applied)", dbPath, migrator.Latest(), applied)
+	return db, nil
+}
+
+// OpenDB opens the SQLite database without migrating it
+func OpenDB(dbPath string) (*sql.DB, error) {
 	// Ensure the directory exists
 	dir := filepath.Dir(dbPath)
 	if dir != "." && dir != "" {
@@ -26,56 +51,27 @@ func InitDB(dbPath string) (*sql.DB, error) {
 
 	// Test the connection
 	if err := db.Ping(); err != nil {
+		db.Close()
 		return nil, err
 	}
 
-	// Run migrations
-	if err := runMigrations(db); err != nil {
-		return nil, err
-	}
-
-	log.Printf("Database initialized at %s", dbPath)
 	return db, nil
 }
 
-// runMigrations creates the necessary tables
-func runMigrations(db *sql.DB) error {
-	migration := `
-		CREATE TABLE IF NOT EXISTS personality_results (
-			id TEXT PRIMARY KEY,
-			session_id TEXT NOT NULL,
-			extraversion REAL NOT NULL,
-			agreeableness REAL NOT NULL,
-			conscientiousness REAL NOT NULL,
-			emotional_stability REAL NOT NULL,
-			openness REAL NOT NULL,
-			created_at TEXT NOT NULL DEFAULT (datetime('now'))
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_personality_results_session_id 
-		ON personality_results(session_id);
-
-		CREATE INDEX IF NOT EXISTS idx_personality_results_created_at 
-		ON personality_results(created_at DESC);
-
-		CREATE TABLE IF NOT EXISTS trait_interpretations (
-			id TEXT PRIMARY KEY,
-			result_id TEXT NOT NULL REFERENCES personality_results(id),
-			trait TEXT NOT NULL,
-			interpretation TEXT NOT NULL,
-			created_at TEXT NOT NULL DEFAULT (datetime('now')),
-			UNIQUE(result_id, trait)
-		);
-
-		CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id 
-		ON trait_interpretations(result_id);
-	`
+// formatOptionalTime formats a timestamp in local time like created_at,
+// leaving a missing one empty
+func formatOptionalTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Local().Format("2006-01-02 15:04:05")
+}
 
-	_, err := db.Exec(migration)
+// parseOptionalTime parses a timestamp written by formatOptionalTime
+func parseOptionalTime(value string) *time.Time {
+	t, err := time.ParseInLocation("2006-01-02 15:04:05", value, time.Local)
 	if err != nil {
-		return err
+		return nil
 	}
-
-	log.Println("Database migrations completed")
-	return nil
+	return &t
 }
@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"strconv"
+	"strings"
+)
+
+// dialect adapts SQL written for SQLite to the database it runs on
+type dialect struct {
+	// numberedPlaceholders writes the ? placeholders as $1, $2, ...
+	numberedPlaceholders bool
+	// insertOrder is the column that orders rows by insertion
+	insertOrder string
+}
+
+var (
+	sqliteDialect   = dialect{insertOrder: "rowid"}
+	postgresDialect = dialect{numberedPlaceholders: true, insertOrder: "seq"}
+)
+
+// rebind rewrites the ? placeholders of a query for the dialect. Queries
+// must not contain ? in string literals.
+func (d dialect) rebind(query string) string {
+	if !d.numberedPlaceholders {
+		return query
+	}
+
+	var b strings.Builder
+	n := 0
+	for _, c := range query {
+		if c != '?' {
+			b.WriteRune(c)
+			continue
+		}
+		n++
+		b.WriteByte('$')
+		b.WriteString(strconv.Itoa(n))
+	}
+	return b.String()
+}
@@ -0,0 +1,339 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrDraftNotFound is returned when a draft session is not found
+var ErrDraftNotFound = errors.New("draft session not found")
+
+// DraftRepository handles database operations for draft sessions
+type DraftRepository struct {
+	db *sql.DB
+}
+
+// NewDraftRepository creates a new draft session repository
+func NewDraftRepository(db *sql.DB) *DraftRepository {
+	return &DraftRepository{db: db}
+}
+
+// draftColumns lists the draft_sessions columns read by scanDraft
+const draftColumns = `id, tenant_id, resume_code, session_id, instrument_id, language, translation,
+			age, country, cohort_id, cohort_consent, status, result_id, created_at,
+			updated_at, expires_at`
+
+// Create stores a new draft session without answers
+func (r *DraftRepository) Create(draft *domain.DraftSession) error {
+	query := `
+		INSERT INTO draft_sessions (` + draftColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(query,
+		draft.ID,
+		draft.TenantID,
+		draft.ResumeCode,
+		draft.SessionID,
+		draft.InstrumentID,
+		draft.Language,
+		draft.Translation,
+		draft.Age,
+		draft.Country,
+		draft.CohortID,
+		draft.CohortConsent,
+		draft.Status,
+		draft.ResultID,
+		draft.CreatedAt.Format("2006-01-02 15:04:05"),
+		draft.UpdatedAt.Format("2006-01-02 15:04:05"),
+		draft.ExpiresAt.Format("2006-01-02 15:04:05"),
+	)
+
+	return err
+}
+
+// ResumeCodeExists reports whether a resume code is already in use
+func (r *DraftRepository) ResumeCodeExists(code string) (bool, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM draft_sessions WHERE resume_code = ?`, code).Scan(&count)
+	return count > 0, err
+}
+
+// GetByID retrieves a draft session of a tenant and its answers by ID
+func (r *DraftRepository) GetByID(tenantID, id string) (*domain.DraftSession, error) {
+	query := `
+		SELECT ` + draftColumns + `
+		FROM draft_sessions
+		WHERE tenant_id = ? AND id = ?
+	`
+
+	return r.getDraft(query, tenantID, id)
+}
+
+// GetByResumeCode retrieves a draft session of a tenant and its answers by resume code
+func (r *DraftRepository) GetByResumeCode(tenantID, code string) (*domain.DraftSession, error) {
+	query := `
+		SELECT ` + draftColumns + `
+		FROM draft_sessions
+		WHERE tenant_id = ? AND resume_code = ?
+	`
+
+	return r.getDraft(query, tenantID, code)
+}
+
+// GetBySessionID retrieves all draft sessions of a session within a tenant
+// and their answers, oldest first
+func (r *DraftRepository) GetBySessionID(tenantID, sessionID string) ([]*domain.DraftSession, error) {
+	rows, err := r.db.Query(`
+		SELECT id
+		FROM draft_sessions
+		WHERE tenant_id = ? AND session_id = ?
+		ORDER BY created_at
+	`, tenantID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var drafts []*domain.DraftSession
+	for _, id := range ids {
+		draft, err := r.GetByID(tenantID, id)
+		if err != nil {
+			return nil, err
+		}
+		drafts = append(drafts, draft)
+	}
+	return drafts, nil
+}
+
+// getDraft runs a query selecting a single draft and attaches its answers
+func (r *DraftRepository) getDraft(query string, args ...any) (*domain.DraftSession, error) {
+	draft, err := scanDraft(r.db.QueryRow(query, args...))
+	if err == sql.ErrNoRows {
+		return nil, ErrDraftNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := r.db.Query(`
+		SELECT question_id, value, latency_ms
+		FROM draft_answers
+		WHERE draft_id = ?
+		ORDER BY question_id
+	`, draft.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	draft.Answers = []domain.Answer{}
+	for rows.Next() {
+		var answer domain.Answer
+		if err := rows.Scan(&answer.QuestionID, &answer.Value, &answer.LatencyMs); err != nil {
+			return nil, err
+		}
+		draft.Answers = append(draft.Answers, answer)
+	}
+
+	return draft, rows.Err()
+}
+
+// scanDraft reads a draft session selected with draftColumns
+func scanDraft(row rowScanner) (*domain.DraftSession, error) {
+	draft := &domain.DraftSession{}
+	var createdAtStr, updatedAtStr, expiresAtStr string
+	err := row.Scan(
+		&draft.ID,
+		&draft.TenantID,
+		&draft.ResumeCode,
+		&draft.SessionID,
+		&draft.InstrumentID,
+		&draft.Language,
+		&draft.Translation,
+		&draft.Age,
+		&draft.Country,
+		&draft.CohortID,
+		&draft.CohortConsent,
+		&draft.Status,
+		&draft.ResultID,
+		&createdAtStr,
+		&updatedAtStr,
+		&expiresAtStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+	draft.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAtStr)
+	draft.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAtStr)
+	draft.ExpiresAt, _ = time.Parse("2006-01-02 15:04:05", expiresAtStr)
+	return draft, nil
+}
+
+// SaveAnswers upserts answers of a tenant's draft session and extends its
+// expiry in a single transaction
+func (r *DraftRepository) SaveAnswers(tenantID, id string, answers []domain.Answer, updatedAt, expiresAt time.Time) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	answeredAt := updatedAt.Format("2006-01-02 15:04:05")
+	result, err := tx.Exec(`
+		UPDATE draft_sessions SET updated_at = ?, expires_at = ? WHERE tenant_id = ? AND id = ?
+	`, answeredAt, expiresAt.Format("2006-01-02 15:04:05"), tenantID, id)
+	if err != nil {
+		return err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrDraftNotFound
+	}
+
+	stmt, err := tx.Prepare(`
+		INSERT INTO draft_answers (draft_id, question_id, value, latency_ms, answered_at)
+		VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT(draft_id, question_id) DO UPDATE SET
+			value = excluded.value,
+			latency_ms = excluded.latency_ms,
+			answered_at = excluded.answered_at
+	`)
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	for _, answer := range answers {
+		if _, err := stmt.Exec(id, answer.QuestionID, answer.Value, answer.LatencyMs, answeredAt); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Complete marks a tenant's draft session as finalized into a result
+func (r *DraftRepository) Complete(tenantID, id, resultID string, completedAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE draft_sessions SET status = ?, result_id = ?, updated_at = ? WHERE tenant_id = ? AND id = ?
+	`, domain.DraftStatusCompleted, resultID, completedAt.Format("2006-01-02 15:04:05"), tenantID, id)
+	return err
+}
+
+// Summaries returns the status and answer count of every draft session of a tenant
+func (r *DraftRepository) Summaries(tenantID string) ([]domain.DraftSummary, error) {
+	query := `
+		SELECT d.instrument_id, d.status, COUNT(a.question_id), d.updated_at, d.expires_at
+		FROM draft_sessions d
+		LEFT JOIN draft_answers a ON a.draft_id = d.id
+		WHERE d.tenant_id = ?
+		GROUP BY d.id
+		ORDER BY d.instrument_id
+	`
+
+	rows, err := r.db.Query(query, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var summaries []domain.DraftSummary
+	for rows.Next() {
+		var summary domain.DraftSummary
+		var updatedAtStr, expiresAtStr string
+		if err := rows.Scan(&summary.InstrumentID, &summary.Status, &summary.Answered, &updatedAtStr, &expiresAtStr); err != nil {
+			return nil, err
+		}
+		summary.UpdatedAt, _ = time.Parse("2006-01-02 15:04:05", updatedAtStr)
+		summary.ExpiresAt, _ = time.Parse("2006-01-02 15:04:05", expiresAtStr)
+		summaries = append(summaries, summary)
+	}
+
+	return summaries, rows.Err()
+}
+
+// CountInProgressByCohort counts the unfinished, unexpired draft sessions of a tenant's cohort
+func (r *DraftRepository) CountInProgressByCohort(tenantID, cohortID string, now time.Time) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM draft_sessions
+		WHERE tenant_id = ? AND cohort_id = ? AND status = ? AND expires_at > ?
+	`, tenantID, cohortID, domain.DraftStatusInProgress, now.UTC().Format("2006-01-02 15:04:05")).Scan(&count)
+	return count, err
+}
+
+// DeleteBySessionID deletes all draft sessions of a session within a tenant
+// together with their answers, and returns how many drafts were deleted
+func (r *DraftRepository) DeleteBySessionID(tenantID, sessionID string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		DELETE FROM draft_answers
+		WHERE draft_id IN (SELECT id FROM draft_sessions WHERE tenant_id = ? AND session_id = ?)
+	`, tenantID, sessionID)
+	if err != nil {
+		return 0, err
+	}
+
+	result, err := tx.Exec(`
+		DELETE FROM draft_sessions WHERE tenant_id = ? AND session_id = ?
+	`, tenantID, sessionID)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(affected), tx.Commit()
+}
+
+// DeleteByResultIDs deletes the draft sessions of a tenant that were
+// finalized into the given results, and returns how many were deleted
+func (r *DraftRepository) DeleteByResultIDs(tenantID string, resultIDs []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	deleted, err := deleteResultDrafts(tx, tenantID, resultIDs)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
+}
+
+// deleteResultDrafts deletes the draft sessions of a tenant finalized into
+// the given results together with their answers
+func deleteResultDrafts(tx *sql.Tx, tenantID string, resultIDs []string) (int, error) {
+	return forResultIDs(resultIDs, func(placeholders string, args []any) (int, error) {
+		return eraseDrafts(tx, "tenant_id = ? AND result_id IN ("+placeholders+")", append([]any{tenantID}, args...)...)
+	})
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrErasureNotFound is returned when no pending erasure exists for a subject
+var ErrErasureNotFound = errors.New("erasure not found")
+
+// ErasureRepository hard-deletes respondent data and keeps erasure tombstones
+type ErasureRepository struct {
+	db *sql.DB
+}
+
+// NewErasureRepository creates a new erasure repository
+func NewErasureRepository(db *sql.DB) *ErasureRepository {
+	return &ErasureRepository{db: db}
+}
+
+// erasureColumns lists the erasures columns read by scanErasure
+const erasureColumns = `id, tenant_id, subject, subject_id, requested_by, results, answers,
+			interpretations, share_links, drafts, consent_records, status, erased_at, result_ids`
+
+// Start stores the tombstone of an erasure as pending before anything is
+// deleted, so an interrupted erasure stays on record. If an erasure of the
+// same subject is still pending, it is returned instead, to be continued.
+func (r *ErasureRepository) Start(erasure *domain.Erasure) (*domain.Erasure, error) {
+	pending, err := r.GetPending(erasure.TenantID, erasure.Subject, erasure.SubjectID)
+	if err == nil {
+		return pending, nil
+	}
+	if !errors.Is(err, ErrErasureNotFound) {
+		return nil, err
+	}
+
+	erasure.Status = domain.ErasureStatusPending
+	_, err = r.db.Exec(`
+		INSERT INTO erasures (`+erasureColumns+`)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		erasure.ID,
+		erasure.TenantID,
+		erasure.Subject,
+		erasure.SubjectID,
+		erasure.RequestedBy,
+		erasure.Results,
+		erasure.Answers,
+		erasure.Interpretations,
+		erasure.ShareLinks,
+		erasure.Drafts,
+		erasure.ConsentRecords,
+		erasure.Status,
+		formatOptionalTime(&erasure.ErasedAt),
+		strings.Join(erasure.ResultIDs, ","),
+	)
+	if err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// GetPending retrieves the pending erasure of a subject within a tenant
+func (r *ErasureRepository) GetPending(tenantID, subject, subjectID string) (*domain.Erasure, error) {
+	erasure, err := scanErasure(r.db.QueryRow(`
+		SELECT `+erasureColumns+`
+		FROM erasures
+		WHERE tenant_id = ? AND subject = ? AND subject_id = ? AND status = ?
+		ORDER BY erased_at DESC
+		LIMIT 1
+	`, tenantID, subject, subjectID, domain.ErasureStatusPending))
+	if err == sql.ErrNoRows {
+		return nil, ErrErasureNotFound
+	}
+	return erasure, err
+}
+
+// RecordResults adds results deleted from the result store to a pending
+// erasure, so they are counted and their share links and drafts are deleted
+// even if the erasure has to be repeated
+func (r *ErasureRepository) RecordResults(erasure *domain.Erasure, deletion *domain.ResultDeletion) error {
+	erasure.Results += deletion.Results
+	erasure.Answers += deletion.Answers
+	erasure.Interpretations += deletion.Interpretations
+	for _, id := range deletion.ResultIDs {
+		if !slices.Contains(erasure.ResultIDs, id) {
+			erasure.ResultIDs = append(erasure.ResultIDs, id)
+		}
+	}
+
+	_, err := r.db.Exec(`
+		UPDATE erasures
+		SET results = ?, answers = ?, interpretations = ?, result_ids = ?
+		WHERE tenant_id = ? AND id = ? AND status = ?
+	`,
+		erasure.Results,
+		erasure.Answers,
+		erasure.Interpretations,
+		strings.Join(erasure.ResultIDs, ","),
+		erasure.TenantID,
+		erasure.ID,
+		domain.ErasureStatusPending,
+	)
+	return err
+}
+
+// Complete deletes what is kept about the erased results besides the results
+// themselves: their share links and the drafts they were finalized from, and
+// for a session erasure also the session's drafts and consent records. The
+// counts are added to the erasure, which is marked completed in the same
+// transaction.
+func (r *ErasureRepository) Complete(erasure *domain.Erasure) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	shareLinks, err := deleteShareLinks(tx, erasure.TenantID, erasure.ResultIDs)
+	if err != nil {
+		return err
+	}
+	drafts, err := deleteResultDrafts(tx, erasure.TenantID, erasure.ResultIDs)
+	if err != nil {
+		return err
+	}
+
+	consentRecords := 0
+	if erasure.Subject == domain.ErasureSubjectSession {
+		sessionDrafts, err := eraseDrafts(tx, "tenant_id = ? AND session_id = ?", erasure.TenantID, erasure.SubjectID)
+		if err != nil {
+			return err
+		}
+		drafts += sessionDrafts
+		if consentRecords, err = execCount(tx, `
+			DELETE FROM consent_records WHERE tenant_id = ? AND session_id = ?
+		`, erasure.TenantID, erasure.SubjectID); err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE erasures
+		SET share_links = share_links + ?, drafts = drafts + ?, consent_records = consent_records + ?,
+			status = ?, result_ids = ''
+		WHERE tenant_id = ? AND id = ?
+	`, shareLinks, drafts, consentRecords, domain.ErasureStatusCompleted, erasure.TenantID, erasure.ID); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	erasure.ShareLinks += shareLinks
+	erasure.Drafts += drafts
+	erasure.ConsentRecords += consentRecords
+	erasure.Status = domain.ErasureStatusCompleted
+	erasure.ResultIDs = nil
+	return nil
+}
+
+// eraseDrafts deletes the draft sessions matching a draft_sessions condition
+// and their answers, and returns how many drafts were deleted
+func eraseDrafts(tx *sql.Tx, condition string, args ...any) (int, error) {
+	if _, err := tx.Exec(`
+		DELETE FROM draft_answers
+		WHERE draft_id IN (SELECT id FROM draft_sessions WHERE `+condition+`)
+	`, args...); err != nil {
+		return 0, err
+	}
+	return execCount(tx, `DELETE FROM draft_sessions WHERE `+condition, args...)
+}
+
+// execCount executes a statement and returns the number of affected rows
+func execCount(tx *sql.Tx, query string, args ...any) (int, error) {
+	result, err := tx.Exec(query, args...)
+	if err != nil {
+		return 0, err
+	}
+	affected, err := result.RowsAffected()
+	return int(affected), err
+}
+
+// forResultIDs calls fn with the placeholders and arguments of consecutive
+// batches of result IDs and returns the sum of the counts fn returns
+func forResultIDs(resultIDs []string, fn func(placeholders string, args []any) (int, error)) (int, error) {
+	total := 0
+	for start := 0; start < len(resultIDs); start += scoreBatchSize {
+		ids := resultIDs[start:min(start+scoreBatchSize, len(resultIDs))]
+		args := make([]any, len(ids))
+		for i, id := range ids {
+			args[i] = id
+		}
+		n, err := fn(strings.TrimSuffix(strings.Repeat("?,", len(ids)), ","), args)
+		if err != nil {
+			return total, err
+		}
+		total += n
+	}
+	return total, nil
+}
+
+// GetAll retrieves the erasure tombstones of a tenant, newest first
+func (r *ErasureRepository) GetAll(tenantID string) ([]*domain.Erasure, error) {
+	rows, err := r.db.Query(`
+		SELECT `+erasureColumns+`
+		FROM erasures
+		WHERE tenant_id = ?
+		ORDER BY erased_at DESC
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var erasures []*domain.Erasure
+	for rows.Next() {
+		erasure, err := scanErasure(rows)
+		if err != nil {
+			return nil, err
+		}
+		erasures = append(erasures, erasure)
+	}
+
+	return erasures, rows.Err()
+}
+
+// scanErasure reads an erasure tombstone selected with erasureColumns
+func scanErasure(row rowScanner) (*domain.Erasure, error) {
+	erasure := &domain.Erasure{}
+	var erasedAtStr, resultIDs string
+	err := row.Scan(
+		&erasure.ID,
+		&erasure.TenantID,
+		&erasure.Subject,
+		&erasure.SubjectID,
+		&erasure.RequestedBy,
+		&erasure.Results,
+		&erasure.Answers,
+		&erasure.Interpretations,
+		&erasure.ShareLinks,
+		&erasure.Drafts,
+		&erasure.ConsentRecords,
+		&erasure.Status,
+		&erasedAtStr,
+		&resultIDs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if erasedAt := parseOptionalTime(erasedAtStr); erasedAt != nil {
+		erasure.ErasedAt = *erasedAt
+	}
+	if resultIDs != "" {
+		erasure.ResultIDs = strings.Split(resultIDs, ",")
+	}
+	return erasure, nil
+}
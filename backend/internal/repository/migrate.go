@@ -0,0 +1,430 @@
+package repository
+
+import (
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/thielel/voca/migrations"
+)
+
+var (
+	// ErrSchemaTooNew is returned when the database was migrated by a newer
+	// build and has migrations this build does not know
+	ErrSchemaTooNew = errors.New("database schema is newer than this build")
+	// ErrMigrationModified is returned when an applied migration's file
+	// changed since it was applied
+	ErrMigrationModified = errors.New("applied migration was modified")
+	// ErrUnknownMigration is returned when migrating to a version that does not exist
+	ErrUnknownMigration = errors.New("unknown migration version")
+	// ErrUnknownSchema is returned for a database with tables but no
+	// migration history whose schema is not the one created before
+	// versioned migrations
+	ErrUnknownSchema = errors.New("database schema has no migration history")
+)
+
+// legacySchemaVersion is the last migration the schema created before
+// versioned migrations corresponds to
+const legacySchemaVersion = 2
+
+// legacyResultColumns are the personality_results columns of the schema
+// created before versioned migrations
+var legacyResultColumns = []string{
+	"id", "session_id", "extraversion", "agreeableness", "conscientiousness",
+	"emotional_stability", "openness", "created_at",
+}
+
+// migrationFilePattern matches migration file names like 001_create_results.up.sql
+var migrationFilePattern = regexp.MustCompile(`^(\d+)_(\w+)\.(up|down)\.sql$`)
+
+// Migration is a numbered schema change with the SQL that applies and
+// reverts it
+type Migration struct {
+	Version int
+	Name    string
+	Up      string
+	Down    string
+	// Checksum is the SHA-256 of Up, recorded when the migration is applied
+	Checksum string
+}
+
+// MigrationStatus describes whether a migration is applied to a database
+type MigrationStatus struct {
+	Version   int
+	Name      string
+	AppliedAt *time.Time
+	// Modified is set when the migration's file changed after it was applied
+	Modified bool
+	// Unknown is set for applied migrations this build has no file for
+	Unknown bool
+}
+
+// LoadMigrations reads the *.up.sql and *.down.sql files at the root of
+// fsys. Migrations are returned by version and must be numbered 1, 2, 3, ...
+func LoadMigrations(fsys fs.FS) ([]*Migration, error) {
+	names, err := fs.Glob(fsys, "*.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := make(map[int]*Migration)
+	for _, name := range names {
+		match := migrationFilePattern.FindStringSubmatch(name)
+		if match == nil {
+			return nil, fmt.Errorf("invalid migration file name %s", name)
+		}
+		version, _ := strconv.Atoi(match[1])
+
+		migration, exists := byVersion[version]
+		if !exists {
+			migration = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = migration
+		}
+		if migration.Name != match[2] {
+			return nil, fmt.Errorf("migration %d has files named %s and %s", version, migration.Name, match[2])
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+		if match[3] == "up" {
+			migration.Up = string(content)
+			sum := sha256.Sum256(content)
+			migration.Checksum = hex.EncodeToString(sum[:])
+		} else {
+			migration.Down = string(content)
+		}
+	}
+
+	loaded := make([]*Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		loaded = append(loaded, migration)
+	}
+	sort.Slice(loaded, func(i, j int) bool { return loaded[i].Version < loaded[j].Version })
+	for i, migration := range loaded {
+		if migration.Version != i+1 {
+			return nil, fmt.Errorf("migration %d is missing", i+1)
+		}
+		if migration.Up == "" || migration.Down == "" {
+			return nil, fmt.Errorf("migration %d needs an up and a down file", migration.Version)
+		}
+	}
+	return loaded, nil
+}
+
+// Migrator applies and reverts migrations, recording the applied ones in
+// the schema_migrations table
+type Migrator struct {
+	db         *sql.DB
+	dialect    dialect
+	migrations []*Migration
+	// legacyVersion is the version a database with tables but no migration
+	// history is baselined at, or 0 if there are no such databases
+	legacyVersion int
+}
+
+// NewMigrator creates a migrator for the embedded migrations. A database
+// created before versioned migrations is recorded as having the migrations
+// it corresponds to applied, so the later ones are applied to it.
+func NewMigrator(db *sql.DB) (*Migrator, error) {
+	loaded, err := LoadMigrations(migrations.FS)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{db: db, dialect: sqliteDialect, migrations: loaded, legacyVersion: legacySchemaVersion}
+	if err := m.ensureSchemaTable(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// NewPostgresMigrator creates a migrator for the embedded migrations of the
+// PostgreSQL result store
+func NewPostgresMigrator(db *sql.DB) (*Migrator, error) {
+	files, err := fs.Sub(migrations.PostgresFS, "postgres")
+	if err != nil {
+		return nil, err
+	}
+	loaded, err := LoadMigrations(files)
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Migrator{db: db, dialect: postgresDialect, migrations: loaded}
+	if err := m.ensureSchemaTable(); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// ensureSchemaTable creates the schema_migrations table, baselining a
+// database that has tables but no migration history
+func (m *Migrator) ensureSchemaTable() error {
+	tracked, err := m.tableExists("schema_migrations")
+	if err != nil || tracked {
+		return err
+	}
+	legacy := false
+	if m.legacyVersion > 0 {
+		if legacy, err = m.tableExists("personality_results"); err != nil {
+			return err
+		}
+	}
+
+	if legacy {
+		if err := m.checkLegacySchema(); err != nil {
+			return err
+		}
+		log.Printf("Database created before versioned migrations is at version %d", m.legacyVersion)
+	}
+
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		CREATE TABLE schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			checksum TEXT NOT NULL,
+			applied_at TEXT NOT NULL
+		)
+	`); err != nil {
+		return err
+	}
+	if legacy {
+		now := time.Now()
+		for _, migration := range m.migrations[:m.legacyVersion] {
+			if err := m.recordMigration(tx, migration, now); err != nil {
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+// checkLegacySchema returns ErrUnknownSchema unless the database has the
+// schema created before versioned migrations
+func (m *Migrator) checkLegacySchema() error {
+	interpretations, err := m.tableExists("trait_interpretations")
+	if err != nil {
+		return err
+	}
+	columns, err := m.tableColumns("personality_results")
+	if err != nil {
+		return err
+	}
+	if !interpretations || !slices.Equal(columns, legacyResultColumns) {
+		return fmt.Errorf("%w: expected the schema of migration %d, found personality_results columns %v", ErrUnknownSchema, m.legacyVersion, columns)
+	}
+	return nil
+}
+
+// Latest returns the version of the newest migration this build knows
+func (m *Migrator) Latest() int {
+	return len(m.migrations)
+}
+
+// Version returns the version of the newest applied migration, or 0 for an
+// empty database
+func (m *Migrator) Version() (int, error) {
+	var version int
+	err := m.db.QueryRow(`SELECT COALESCE(MAX(version), 0) FROM schema_migrations`).Scan(&version)
+	return version, err
+}
+
+// Status lists all known and applied migrations by version
+func (m *Migrator) Status() ([]*MigrationStatus, error) {
+	rows, err := m.db.Query(`SELECT version, name, checksum, applied_at FROM schema_migrations ORDER BY version`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make(map[int]*MigrationStatus)
+	checksums := make(map[int]string)
+	var unknown []*MigrationStatus
+	for rows.Next() {
+		status := &MigrationStatus{}
+		var checksum, appliedAtStr string
+		if err := rows.Scan(&status.Version, &status.Name, &checksum, &appliedAtStr); err != nil {
+			return nil, err
+		}
+		status.AppliedAt = parseOptionalTime(appliedAtStr)
+		applied[status.Version] = status
+		checksums[status.Version] = checksum
+		if status.Version > len(m.migrations) {
+			status.Unknown = true
+			unknown = append(unknown, status)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	statuses := make([]*MigrationStatus, 0, len(m.migrations)+len(unknown))
+	for _, migration := range m.migrations {
+		status, ok := applied[migration.Version]
+		if !ok {
+			status = &MigrationStatus{Version: migration.Version, Name: migration.Name}
+		}
+		status.Modified = ok && checksums[migration.Version] != migration.Checksum
+		statuses = append(statuses, status)
+	}
+	return append(statuses, unknown...), nil
+}
+
+// Up applies all pending migrations and returns how many were applied
+func (m *Migrator) Up() (int, error) {
+	return m.To(m.Latest())
+}
+
+// Down reverts the given number of most recently applied migrations and
+// returns how many were reverted
+func (m *Migrator) Down(steps int) (int, error) {
+	version, err := m.Version()
+	if err != nil {
+		return 0, err
+	}
+	return m.To(max(version-steps, 0))
+}
+
+// To applies or reverts migrations until the database is at the given
+// version and returns how many migrations were applied or reverted. It
+// refuses to touch a database with modified or unknown applied migrations.
+func (m *Migrator) To(target int) (int, error) {
+	if err := m.check(); err != nil {
+		return 0, err
+	}
+	if target < 0 || target > m.Latest() {
+		return 0, fmt.Errorf("%w %d", ErrUnknownMigration, target)
+	}
+	version, err := m.Version()
+	if err != nil {
+		return 0, err
+	}
+
+	count := 0
+	for ; version < target; version++ {
+		migration := m.migrations[version]
+		if err := m.apply(migration); err != nil {
+			return count, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Applied migration %d_%s", migration.Version, migration.Name)
+		count++
+	}
+	for ; version > target; version-- {
+		migration := m.migrations[version-1]
+		if err := m.revert(migration); err != nil {
+			return count, fmt.Errorf("migration %d_%s: %w", migration.Version, migration.Name, err)
+		}
+		log.Printf("Reverted migration %d_%s", migration.Version, migration.Name)
+		count++
+	}
+	return count, nil
+}
+
+// check fails if the database has applied migrations this build does not
+// know or whose files changed after they were applied
+func (m *Migrator) check() error {
+	statuses, err := m.Status()
+	if err != nil {
+		return err
+	}
+	for _, status := range statuses {
+		if status.Unknown {
+			return fmt.Errorf("%w: migration %d_%s is applied, this build knows up to %d", ErrSchemaTooNew, status.Version, status.Name, m.Latest())
+		}
+		if status.Modified {
+			return fmt.Errorf("%w: %d_%s", ErrMigrationModified, status.Version, status.Name)
+		}
+	}
+	return nil
+}
+
+// apply runs a migration's up SQL and records it in one transaction
+func (m *Migrator) apply(migration *Migration) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration.Up); err != nil {
+		return err
+	}
+	if err := m.recordMigration(tx, migration, time.Now()); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// revert runs a migration's down SQL and removes its record in one transaction
+func (m *Migrator) revert(migration *Migration) error {
+	tx, err := m.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(migration.Down); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(m.dialect.rebind(`DELETE FROM schema_migrations WHERE version = ?`), migration.Version); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// recordMigration marks a migration as applied
+func (m *Migrator) recordMigration(tx *sql.Tx, migration *Migration, appliedAt time.Time) error {
+	_, err := tx.Exec(m.dialect.rebind(`
+		INSERT INTO schema_migrations (version, name, checksum, applied_at)
+		VALUES (?, ?, ?, ?)
+	`), migration.Version, migration.Name, migration.Checksum, formatOptionalTime(&appliedAt))
+	return err
+}
+
+// tableExists reports whether the database has a table
+func (m *Migrator) tableExists(table string) (bool, error) {
+	query := `SELECT COUNT(*) FROM sqlite_master WHERE type = 'table' AND name = ?`
+	if m.dialect == postgresDialect {
+		query = `SELECT COUNT(*) FROM information_schema.tables WHERE table_schema = current_schema() AND table_name = ?`
+	}
+
+	var count int
+	err := m.db.QueryRow(m.dialect.rebind(query), table).Scan(&count)
+	return count > 0, err
+}
+
+// tableColumns lists the columns of a SQLite table in order
+func (m *Migrator) tableColumns(table string) ([]string, error) {
+	rows, err := m.db.Query(`SELECT name FROM pragma_table_info(?) ORDER BY cid`, table)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var columns []string
+	for rows.Next() {
+		var column string
+		if err := rows.Scan(&column); err != nil {
+			return nil, err
+		}
+		columns = append(columns, column)
+	}
+	return columns, rows.Err()
+}
@@ -0,0 +1,253 @@
+package repository_test
+
+import (
+	"database/sql"
+	"errors"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/migrations"
+)
+
+// openDB opens an empty SQLite database
+func openDB(t *testing.T) *sql.DB {
+	t.Helper()
+	db, err := repository.OpenDB(filepath.Join(t.TempDir(), "voca.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+// exec runs statements on a database or fails the test
+func exec(t *testing.T, db *sql.DB, statements ...string) {
+	t.Helper()
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestMigratorTo(t *testing.T) {
+	db := openDB(t)
+	m, err := repository.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := m.Latest()
+
+	// Each step runs on the database the steps before it left
+	steps := []struct {
+		name        string
+		migrate     func() (int, error)
+		wantChanged int
+		wantVersion int
+		wantErr     error
+	}{
+		{"to 5", func() (int, error) { return m.To(5) }, 5, 5, nil},
+		{"to the same version", func() (int, error) { return m.To(5) }, 0, 5, nil},
+		{"down 2", func() (int, error) { return m.Down(2) }, 2, 3, nil},
+		{"up", m.Up, latest - 3, latest, nil},
+		{"up when current", m.Up, 0, latest, nil},
+		{"beyond the latest", func() (int, error) { return m.To(latest + 1) }, 0, latest, repository.ErrUnknownMigration},
+		{"below zero", func() (int, error) { return m.To(-1) }, 0, latest, repository.ErrUnknownMigration},
+		{"down more than applied", func() (int, error) { return m.Down(latest + 5) }, latest, 0, nil},
+		{"up from empty", m.Up, latest, latest, nil},
+	}
+
+	for _, step := range steps {
+		changed, err := step.migrate()
+		if !errors.Is(err, step.wantErr) || changed != step.wantChanged {
+			t.Fatalf("%s: changed %d migrations, %v, want %d, %v", step.name, changed, err, step.wantChanged, step.wantErr)
+		}
+		if version, err := m.Version(); err != nil || version != step.wantVersion {
+			t.Fatalf("%s: at version %d, %v, want %d", step.name, version, err, step.wantVersion)
+		}
+	}
+}
+
+func TestMigratorDownRevertsSchema(t *testing.T) {
+	db := openDB(t)
+	m, err := repository.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Up(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.To(0); err != nil {
+		t.Fatal(err)
+	}
+
+	var tables []string
+	rows, err := db.Query(`SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatal(err)
+		}
+		tables = append(tables, table)
+	}
+	if len(tables) != 1 || tables[0] != "schema_migrations" {
+		t.Errorf("tables after reverting all migrations = %v, want only schema_migrations", tables)
+	}
+}
+
+func TestMigratorRefusesChangedHistory(t *testing.T) {
+	tests := []struct {
+		name    string
+		change  string
+		wantErr error
+	}{
+		{"checksum mismatch", `UPDATE schema_migrations SET checksum = 'edited' WHERE version = 3`, repository.ErrMigrationModified},
+		{"too new schema", `INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (999, 'from_the_future', '', '2026-01-01 00:00:00')`, repository.ErrSchemaTooNew},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openDB(t)
+			m, err := repository.NewMigrator(db)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := m.To(5); err != nil {
+				t.Fatal(err)
+			}
+			exec(t, db, tt.change)
+
+			if _, err := m.Up(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Up() = %v, want %v", err, tt.wantErr)
+			}
+			if _, err := m.Down(1); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Down(1) = %v, want %v", err, tt.wantErr)
+			}
+			if version, err := m.Version(); err != nil || version < 5 {
+				t.Errorf("migrated to version %d, %v despite the refusal", version, err)
+			}
+		})
+	}
+}
+
+func TestMigratorStatus(t *testing.T) {
+	db := openDB(t)
+	m, err := repository.NewMigrator(db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.To(2); err != nil {
+		t.Fatal(err)
+	}
+	exec(t, db,
+		`UPDATE schema_migrations SET checksum = 'edited' WHERE version = 1`,
+		`INSERT INTO schema_migrations (version, name, checksum, applied_at) VALUES (999, 'from_the_future', '', '2026-01-01 00:00:00')`,
+	)
+
+	statuses, err := m.Status()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != m.Latest()+1 {
+		t.Fatalf("got %d statuses, want %d", len(statuses), m.Latest()+1)
+	}
+	for _, status := range statuses {
+		applied := status.AppliedAt != nil
+		wantApplied := status.Version <= 2 || status.Version == 999
+		if applied != wantApplied || status.Modified != (status.Version == 1) || status.Unknown != (status.Version == 999) {
+			t.Errorf("migration %d: applied %v, modified %v, unknown %v", status.Version, applied, status.Modified, status.Unknown)
+		}
+	}
+}
+
+func TestMigratorLegacySchema(t *testing.T) {
+	loaded, err := repository.LoadMigrations(migrations.FS)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The schema created before versioned migrations is that of migrations 1 and 2
+	baseline := []string{loaded[0].Up, loaded[1].Up}
+
+	tests := []struct {
+		name    string
+		schema  []string
+		wantErr error
+	}{
+		{"baseline", baseline, nil},
+		{"changed in place", append(baseline, `ALTER TABLE personality_results ADD COLUMN instrument_id TEXT NOT NULL DEFAULT 'ipip-50'`), repository.ErrUnknownSchema},
+		{"without interpretations", baseline[:1], repository.ErrUnknownSchema},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := openDB(t)
+			exec(t, db, tt.schema...)
+			exec(t, db, `INSERT INTO personality_results (id, session_id, extraversion, agreeableness, conscientiousness, emotional_stability, openness) VALUES ('legacy', 'session', 10, 20, 30, 40, 50)`)
+
+			m, err := repository.NewMigrator(db)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewMigrator() = %v, want %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if version, err := m.Version(); err != nil || version != 2 {
+				t.Fatalf("legacy database at version %d, %v, want 2", version, err)
+			}
+			if _, err := m.Up(); err != nil {
+				t.Fatal(err)
+			}
+
+			result, err := repository.NewResultRepository(db).GetByID("default", "legacy")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if result.InstrumentID != "ipip-50" || result.Scores["openness"] != 50 {
+				t.Errorf("legacy result upgraded to instrument %q with scores %v", result.InstrumentID, result.Scores)
+			}
+			var rows int
+			if err := db.QueryRow(`SELECT COUNT(*) FROM result_scores WHERE result_id = 'legacy'`).Scan(&rows); err != nil || rows != 5 {
+				t.Errorf("legacy result has %d scale rows, %v, want 5", rows, err)
+			}
+		})
+	}
+}
+
+func TestLoadMigrations(t *testing.T) {
+	file := func(sql string) *fstest.MapFile { return &fstest.MapFile{Data: []byte(sql)} }
+
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    int
+		wantErr bool
+	}{
+		{"numbered", fstest.MapFS{
+			"001_a.up.sql": file("CREATE TABLE a (id TEXT)"), "001_a.down.sql": file("DROP TABLE a"),
+			"002_b.up.sql": file("CREATE TABLE b (id TEXT)"), "002_b.down.sql": file("DROP TABLE b"),
+			"README.md": file("ignored"),
+		}, 2, false},
+		{"gap", fstest.MapFS{
+			"001_a.up.sql": file("CREATE TABLE a (id TEXT)"), "001_a.down.sql": file("DROP TABLE a"),
+			"003_c.up.sql": file("CREATE TABLE c (id TEXT)"), "003_c.down.sql": file("DROP TABLE c"),
+		}, 0, true},
+		{"without down", fstest.MapFS{
+			"001_a.up.sql": file("CREATE TABLE a (id TEXT)"),
+		}, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := repository.LoadMigrations(tt.files)
+			if (err != nil) != tt.wantErr || len(loaded) != tt.want {
+				t.Errorf("LoadMigrations() = %d migrations, %v, want %d", len(loaded), err, tt.want)
+			}
+		})
+	}
+}
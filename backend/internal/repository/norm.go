@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/thielel/voca/data"
+	"github.com/thielel/voca/internal/domain"
+)
+
+// LoadNormSets reads the bundled norm tables and, if dir is set, every
+// *.json norm table in that directory. Files are returned in name order.
+func LoadNormSets(dir string) ([]*domain.NormSet, error) {
+	bundled, err := fs.Sub(data.FS, data.NormsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	sets, err := readNormSets(bundled)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		extra, err := readNormSets(os.DirFS(dir))
+		if err != nil {
+			return nil, err
+		}
+		sets = append(sets, extra...)
+	}
+
+	return sets, nil
+}
+
+// readNormSets parses all *.json files at the root of fsys
+func readNormSets(fsys fs.FS) ([]*domain.NormSet, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var sets []*domain.NormSet
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var set domain.NormSet
+		if err := json.Unmarshal(content, &set); err != nil {
+			return nil, fmt.Errorf("invalid norm table %s: %w", path.Base(name), err)
+		}
+		if err := validateNormSet(&set); err != nil {
+			return nil, fmt.Errorf("invalid norm table %s: %w", path.Base(name), err)
+		}
+		sets = append(sets, &set)
+	}
+	return sets, nil
+}
+
+// validateNormSet checks that a norm set is identifiable and usable
+func validateNormSet(set *domain.NormSet) error {
+	if set.ID == "" || set.Version == "" || set.InstrumentID == "" {
+		return fmt.Errorf("id, version and instrument_id are required")
+	}
+	if len(set.Groups) == 0 {
+		return fmt.Errorf("no norm groups")
+	}
+	for _, group := range set.Groups {
+		for trait, norm := range group.Scales {
+			if norm.SD <= 0 {
+				return fmt.Errorf("group %q: %s has non-positive sd", group.Label, trait)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrNormSnapshotNotFound is returned when a norm snapshot is not found
+var ErrNormSnapshotNotFound = errors.New("norm snapshot not found")
+
+// NormSnapshotRepository handles database operations for norm snapshots
+type NormSnapshotRepository struct {
+	db *sql.DB
+}
+
+// NewNormSnapshotRepository creates a new norm snapshot repository
+func NewNormSnapshotRepository(db *sql.DB) *NormSnapshotRepository {
+	return &NormSnapshotRepository{db: db}
+}
+
+// normSnapshotColumns lists the norm_snapshots columns read by scanNormSnapshot
+const normSnapshotColumns = `tenant_id, name, instrument_id, language, age_min, age_max,
+			from_date, to_date, n, scales, computed_at, created_at`
+
+// Save creates or replaces a norm snapshot by tenant and name
+func (r *NormSnapshotRepository) Save(snapshot *domain.NormSnapshot) error {
+	scales, err := json.Marshal(snapshot.Scales)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		INSERT INTO norm_snapshots (` + normSnapshotColumns + `)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT(tenant_id, name) DO UPDATE SET
+			instrument_id = excluded.instrument_id,
+			language = excluded.language,
+			age_min = excluded.age_min,
+			age_max = excluded.age_max,
+			from_date = excluded.from_date,
+			to_date = excluded.to_date,
+			n = excluded.n,
+			scales = excluded.scales,
+			computed_at = excluded.computed_at
+	`
+
+	_, err = r.db.Exec(query,
+		snapshot.TenantID,
+		snapshot.Name,
+		snapshot.Filter.InstrumentID,
+		snapshot.Filter.Language,
+		snapshot.Filter.AgeMin,
+		snapshot.Filter.AgeMax,
+		formatOptionalTime(snapshot.Filter.From),
+		formatOptionalTime(snapshot.Filter.To),
+		snapshot.N,
+		string(scales),
+		snapshot.ComputedAt.Format("2006-01-02 15:04:05"),
+		snapshot.CreatedAt.Format("2006-01-02 15:04:05"),
+	)
+
+	return err
+}
+
+// GetByName retrieves a norm snapshot of a tenant by its name
+func (r *NormSnapshotRepository) GetByName(tenantID, name string) (*domain.NormSnapshot, error) {
+	query := `
+		SELECT ` + normSnapshotColumns + `
+		FROM norm_snapshots
+		WHERE tenant_id = ? AND name = ?
+	`
+
+	snapshot, err := scanNormSnapshot(r.db.QueryRow(query, tenantID, name))
+	if err == sql.ErrNoRows {
+		return nil, ErrNormSnapshotNotFound
+	}
+	return snapshot, err
+}
+
+// GetAll retrieves the norm snapshots of a tenant ordered by name
+func (r *NormSnapshotRepository) GetAll(tenantID string) ([]*domain.NormSnapshot, error) {
+	query := `
+		SELECT ` + normSnapshotColumns + `
+		FROM norm_snapshots
+		WHERE tenant_id = ?
+		ORDER BY name
+	`
+
+	return r.query(query, tenantID)
+}
+
+// GetAllTenants retrieves the norm snapshots of every tenant. It is the only
+// query that crosses tenants and is meant for the periodic refresh, which
+// recomputes each snapshot within its own tenant.
+func (r *NormSnapshotRepository) GetAllTenants() ([]*domain.NormSnapshot, error) {
+	query := `
+		SELECT ` + normSnapshotColumns + `
+		FROM norm_snapshots
+		ORDER BY tenant_id, name
+	`
+
+	return r.query(query)
+}
+
+// query runs a query selecting normSnapshotColumns
+func (r *NormSnapshotRepository) query(query string, args ...any) ([]*domain.NormSnapshot, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var snapshots []*domain.NormSnapshot
+	for rows.Next() {
+		snapshot, err := scanNormSnapshot(rows)
+		if err != nil {
+			return nil, err
+		}
+		snapshots = append(snapshots, snapshot)
+	}
+
+	return snapshots, rows.Err()
+}
+
+// scanNormSnapshot reads a norm snapshot row
+func scanNormSnapshot(row rowScanner) (*domain.NormSnapshot, error) {
+	snapshot := &domain.NormSnapshot{}
+	var fromStr, toStr, scales, computedAtStr, createdAtStr string
+	err := row.Scan(
+		&snapshot.TenantID,
+		&snapshot.Name,
+		&snapshot.Filter.InstrumentID,
+		&snapshot.Filter.Language,
+		&snapshot.Filter.AgeMin,
+		&snapshot.Filter.AgeMax,
+		&fromStr,
+		&toStr,
+		&snapshot.N,
+		&scales,
+		&computedAtStr,
+		&createdAtStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal([]byte(scales), &snapshot.Scales); err != nil {
+		return nil, err
+	}
+	snapshot.Filter.From = parseOptionalTime(fromStr)
+	snapshot.Filter.To = parseOptionalTime(toStr)
+	snapshot.ComputedAt, _ = time.Parse("2006-01-02 15:04:05", computedAtStr)
+	snapshot.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAtStr)
+	return snapshot, nil
+}
@@ -0,0 +1,160 @@
+package repository
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/thielel/voca/data"
+	"github.com/thielel/voca/internal/domain"
+)
+
+// LoadOccupationCatalog reads an occupation catalog from a JSON or CSV file.
+// An empty path loads the catalog bundled with the application.
+func LoadOccupationCatalog(path string) (*domain.OccupationCatalog, error) {
+	if path == "" {
+		f, err := data.FS.Open(data.OccupationsFile)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return ParseOccupationsJSON(f)
+	}
+
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ParseOccupationsJSON(f)
+	case ".csv":
+		name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+		return ParseOccupationsCSV(f, name, "")
+	default:
+		return nil, fmt.Errorf("unsupported occupation catalog format: %s", path)
+	}
+}
+
+// ParseOccupationsJSON reads a catalog in the bundled JSON format
+func ParseOccupationsJSON(r io.Reader) (*domain.OccupationCatalog, error) {
+	var catalog domain.OccupationCatalog
+	if err := json.NewDecoder(r).Decode(&catalog); err != nil {
+		return nil, fmt.Errorf("invalid occupation catalog: %w", err)
+	}
+	return &catalog, validateCatalog(&catalog)
+}
+
+// ParseOccupationsCSV reads a catalog from CSV with a header row. The columns
+// code and title are required, holland_code is optional, and every column
+// named after a Big Five or RIASEC scale (e.g. openness, realistic) is read
+// as that scale's 0-100 target.
+func ParseOccupationsCSV(r io.Reader, id, version string) (*domain.OccupationCatalog, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("invalid occupation catalog: %w", err)
+	}
+
+	bigFive := make(map[domain.Trait]bool)
+	for _, trait := range []domain.Trait{
+		domain.TraitExtraversion,
+		domain.TraitAgreeableness,
+		domain.TraitConscientiousness,
+		domain.TraitEmotionalStability,
+		domain.TraitOpenness,
+	} {
+		bigFive[trait] = true
+	}
+	interests := make(map[domain.Trait]bool)
+	for _, trait := range domain.RIASECTraits() {
+		interests[trait] = true
+	}
+
+	columns := make(map[string]int)
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["code"]; !ok {
+		return nil, fmt.Errorf("invalid occupation catalog: missing column code")
+	}
+	if _, ok := columns["title"]; !ok {
+		return nil, fmt.Errorf("invalid occupation catalog: missing column title")
+	}
+
+	catalog := &domain.OccupationCatalog{ID: id, Version: version}
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, fmt.Errorf("invalid occupation catalog: %w", err)
+		}
+
+		occupation := &domain.Occupation{
+			Code:      record[columns["code"]],
+			Title:     record[columns["title"]],
+			Traits:    make(map[domain.Trait]float64),
+			Interests: make(map[domain.Trait]float64),
+		}
+		if i, ok := columns["holland_code"]; ok {
+			occupation.HollandCode = strings.ToUpper(record[i])
+		}
+
+		for name, i := range columns {
+			trait := domain.Trait(name)
+			if !bigFive[trait] && !interests[trait] {
+				continue
+			}
+			if strings.TrimSpace(record[i]) == "" {
+				continue
+			}
+			value, err := strconv.ParseFloat(strings.TrimSpace(record[i]), 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid occupation catalog: line %d, column %s: %w", line, name, err)
+			}
+			if bigFive[trait] {
+				occupation.Traits[trait] = value
+			} else {
+				occupation.Interests[trait] = value
+			}
+		}
+
+		catalog.Occupations = append(catalog.Occupations, occupation)
+	}
+
+	return catalog, validateCatalog(catalog)
+}
+
+// validateCatalog checks that every occupation is identifiable and its targets are on the 0-100 scale
+func validateCatalog(catalog *domain.OccupationCatalog) error {
+	codes := make(map[string]bool)
+	for _, occupation := range catalog.Occupations {
+		if occupation.Code == "" || occupation.Title == "" {
+			return fmt.Errorf("invalid occupation catalog: occupation without code or title")
+		}
+		if codes[occupation.Code] {
+			return fmt.Errorf("invalid occupation catalog: duplicate code %s", occupation.Code)
+		}
+		codes[occupation.Code] = true
+
+		for trait, score := range occupation.Profile() {
+			if score < 0 || score > 100 {
+				return fmt.Errorf("invalid occupation catalog: %s target for %s is outside 0-100", trait, occupation.Code)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"database/sql"
+	"log"
+
+	_ "github.com/lib/pq"
+)
+
+// InitPostgres opens the PostgreSQL database of the result store and applies
+// pending migrations
+func InitPostgres(url string) (*sql.DB, error) {
+	db, err := sql.Open("postgres", url)
+	if err != nil {
+		return nil, err
+	}
+
+	// Test the connection
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	migrator, err := NewPostgresMigrator(db)
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	applied, err := migrator.Up()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	log.Printf("PostgreSQL result store initialized (schema version %d, %d migrations applied)", migrator.Latest(), applied)
+	return db, nil
+}
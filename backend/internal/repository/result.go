@@ -2,36 +2,105 @@ package repository
 
 import (
 	"database/sql"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/thielel/voca/internal/domain"
 )
 
-// ErrNotFound is returned when a result is not found
-var ErrNotFound = errors.New("result not found")
+var (
+	// ErrNotFound is returned when a result is not found
+	ErrNotFound = errors.New("result not found")
+	// ErrInterpretationVersionNotFound is returned when a result has no
+	// interpretations of a version
+	ErrInterpretationVersionNotFound = errors.New("interpretation version not found")
+	// ErrInvalidCursor is returned when a result listing cursor is malformed
+	// or was returned for a different order
+	ErrInvalidCursor = errors.New("invalid result cursor")
+)
 
-// ResultRepository handles database operations for personality results
+// ResultRepository handles database operations for personality results. The
+// same SQL serves SQLite and PostgreSQL databases.
 type ResultRepository struct {
-	db *sql.DB
+	db      *sql.DB
+	dialect dialect
 }
 
 // NewResultRepository creates a new result repository
 func NewResultRepository(db *sql.DB) *ResultRepository {
-	return &ResultRepository{db: db}
+	return &ResultRepository{db: db, dialect: sqliteDialect}
+}
+
+// NewPostgresResultRepository creates a result repository for a PostgreSQL
+// database migrated with NewPostgresMigrator
+func NewPostgresResultRepository(db *sql.DB) *ResultRepository {
+	return &ResultRepository{db: db, dialect: postgresDialect}
+}
+
+// query runs a query written with ? placeholders
+func (r *ResultRepository) query(query string, args ...any) (*sql.Rows, error) {
+	return r.db.Query(r.dialect.rebind(query), args...)
+}
+
+// queryRow runs a single-row query written with ? placeholders
+func (r *ResultRepository) queryRow(query string, args ...any) *sql.Row {
+	return r.db.QueryRow(r.dialect.rebind(query), args...)
+}
+
+// exec runs a statement written with ? placeholders
+func (r *ResultRepository) exec(query string, args ...any) (sql.Result, error) {
+	return r.db.Exec(r.dialect.rebind(query), args...)
+}
+
+// Save stores a personality result and the raw answers it was calculated from
+// in a single transaction
+func (r *ResultRepository) Save(result *domain.PersonalityResult, answers []domain.Answer) error {
+	return inTransaction(r.db, func(tx *sql.Tx) error {
+		return r.saveResult(tx, result, answers)
+	})
 }
 
-// Save stores a personality result in the database
-func (r *ResultRepository) Save(result *domain.PersonalityResult) error {
+// saveResult inserts a result with its scores and answers
+func (r *ResultRepository) saveResult(tx *sql.Tx, result *domain.PersonalityResult, answers []domain.Answer) error {
 	query := `
 		INSERT INTO personality_results (
-			id, session_id, extraversion, agreeableness, 
-			conscientiousness, emotional_stability, openness, created_at
-		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+			id, tenant_id, session_id, instrument_id, instrument_version, holland_code,
+			age, language, country, norm_set_id, translation, quality_flag,
+			quality, started_at, completed_at, cohort_id, cohort_consent,
+			extraversion, agreeableness, conscientiousness, emotional_stability,
+			openness, created_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
-	_, err := r.db.Exec(query,
+	qualityFlag, quality, err := marshalQuality(result.Quality)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(r.dialect.rebind(query),
 		result.ID,
+		result.TenantID,
 		result.SessionID,
+		result.InstrumentID,
+		result.InstrumentVersion,
+		result.HollandCode,
+		result.Age,
+		result.Language,
+		result.Country,
+		result.NormSetID,
+		result.Translation,
+		qualityFlag,
+		quality,
+		formatOptionalTime(result.StartedAt),
+		formatOptionalTime(result.CompletedAt),
+		result.CohortID,
+		result.CohortConsent,
 		result.Extraversion,
 		result.Agreeableness,
 		result.Conscientiousness,
@@ -39,24 +108,91 @@ func (r *ResultRepository) Save(result *domain.PersonalityResult) error {
 		result.Openness,
 		result.CreatedAt.Format("2006-01-02 15:04:05"),
 	)
+	if err != nil {
+		return err
+	}
 
-	return err
+	// Results without per-scale scores are stored with their Big Five
+	// scores, so queries by scale find them
+	scores := result.Scores
+	if len(scores) == 0 {
+		scores = bigFiveScores(result)
+	}
+	for scale, score := range scores {
+		_, err := tx.Exec(r.dialect.rebind(`
+			INSERT INTO result_scores (result_id, scale, score) VALUES (?, ?, ?)
+		`), result.ID, string(scale), score)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, facet := range result.Facets {
+		_, err := tx.Exec(r.dialect.rebind(`
+			INSERT INTO result_facet_scores (result_id, facet, trait, score) VALUES (?, ?, ?, ?)
+		`), result.ID, facet.Facet, string(facet.Trait), facet.Score)
+		if err != nil {
+			return err
+		}
+	}
+
+	if len(answers) > 0 {
+		stmt, err := tx.Prepare(r.dialect.rebind(`
+			INSERT INTO answers (
+				result_id, question_id, value, latency_ms, answered_at
+			) VALUES (?, ?, ?, ?, ?)
+		`))
+		if err != nil {
+			return err
+		}
+		defer stmt.Close()
+
+		answeredAt := result.CreatedAt.Format("2006-01-02 15:04:05")
+		for _, answer := range answers {
+			if _, err := stmt.Exec(result.ID, answer.QuestionID, answer.Value, answer.LatencyMs, answeredAt); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
 }
 
-// GetByID retrieves a personality result by its ID
-func (r *ResultRepository) GetByID(id string) (*domain.PersonalityResult, error) {
-	query := `
-		SELECT id, session_id, extraversion, agreeableness, 
-			conscientiousness, emotional_stability, openness, created_at
-		FROM personality_results
-		WHERE id = ?
-	`
+// scoreBatchSize bounds the number of result IDs per result_scores query
+const scoreBatchSize = 500
 
+// resultColumns lists the personality_results columns read by scanResult
+const resultColumns = `id, tenant_id, session_id, instrument_id, instrument_version, holland_code,
+			age, language, country, norm_set_id, translation, quality, started_at, completed_at,
+			cohort_id, cohort_consent, extraversion, agreeableness, conscientiousness, emotional_stability,
+			openness, created_at`
+
+// rowScanner is implemented by *sql.Row and *sql.Rows
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+// scanResult reads a personality result selected with resultColumns
+func scanResult(row rowScanner) (*domain.PersonalityResult, error) {
 	result := &domain.PersonalityResult{}
-	var createdAtStr string
-	err := r.db.QueryRow(query, id).Scan(
+	var quality, startedAtStr, completedAtStr, createdAtStr string
+	err := row.Scan(
 		&result.ID,
+		&result.TenantID,
 		&result.SessionID,
+		&result.InstrumentID,
+		&result.InstrumentVersion,
+		&result.HollandCode,
+		&result.Age,
+		&result.Language,
+		&result.Country,
+		&result.NormSetID,
+		&result.Translation,
+		&quality,
+		&startedAtStr,
+		&completedAtStr,
+		&result.CohortID,
+		&result.CohortConsent,
 		&result.Extraversion,
 		&result.Agreeableness,
 		&result.Conscientiousness,
@@ -64,28 +200,36 @@ func (r *ResultRepository) GetByID(id string) (*domain.PersonalityResult, error)
 		&result.Openness,
 		&createdAtStr,
 	)
-
-	if err == sql.ErrNoRows {
-		return nil, ErrNotFound
-	}
 	if err != nil {
 		return nil, err
 	}
-
+	result.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", createdAtStr)
+	result.StartedAt = parseOptionalTime(startedAtStr)
+	result.CompletedAt = parseOptionalTime(completedAtStr)
+	if quality != "" {
+		if err := json.Unmarshal([]byte(quality), &result.Quality); err != nil {
+			return nil, err
+		}
+	}
 	return result, nil
 }
 
-// GetBySessionID retrieves all results for a session
-func (r *ResultRepository) GetBySessionID(sessionID string) ([]*domain.PersonalityResult, error) {
-	query := `
-		SELECT id, session_id, extraversion, agreeableness, 
-			conscientiousness, emotional_stability, openness, created_at
-		FROM personality_results
-		WHERE session_id = ?
-		ORDER BY created_at DESC
-	`
+// marshalQuality returns the quality flag and JSON indicators stored with a
+// result; results assessed before quality checks existed store neither
+func marshalQuality(quality *domain.ResponseQuality) (string, string, error) {
+	if quality == nil {
+		return "", "", nil
+	}
+	data, err := json.Marshal(quality)
+	if err != nil {
+		return "", "", err
+	}
+	return quality.Flag, string(data), nil
+}
 
-	rows, err := r.db.Query(query, sessionID)
+// queryResults runs a query selecting resultColumns and attaches per-scale scores
+func (r *ResultRepository) queryResults(query string, args ...any) ([]*domain.PersonalityResult, error) {
+	rows, err := r.query(query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -93,103 +237,463 @@ func (r *ResultRepository) GetBySessionID(sessionID string) ([]*domain.Personali
 
 	var results []*domain.PersonalityResult
 	for rows.Next() {
-		result := &domain.PersonalityResult{}
-		var createdAtStr string
-		err := rows.Scan(
-			&result.ID,
-			&result.SessionID,
-			&result.Extraversion,
-			&result.Agreeableness,
-			&result.Conscientiousness,
-			&result.EmotionalStability,
-			&result.Openness,
-			&createdAtStr,
-		)
+		result, err := scanResult(rows)
 		if err != nil {
 			return nil, err
 		}
 		results = append(results, result)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := r.attachScores(results); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// attachScores loads the per-scale and facet scores of the given results. Results stored
+// before scores were kept per scale fall back to the Big Five columns.
+func (r *ResultRepository) attachScores(results []*domain.PersonalityResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	byID := make(map[string]*domain.PersonalityResult, len(results))
+	for _, result := range results {
+		result.Scores = make(map[domain.Trait]float64)
+		byID[result.ID] = result
+	}
+
+	ids := make([]string, 0, len(results))
+	for id := range byID {
+		ids = append(ids, id)
+	}
+
+	for start := 0; start < len(ids); start += scoreBatchSize {
+		end := min(start+scoreBatchSize, len(ids))
+		if err := r.loadScores(ids[start:end], byID); err != nil {
+			return err
+		}
+		if err := r.loadFacetScores(ids[start:end], byID); err != nil {
+			return err
+		}
+	}
 
-	return results, rows.Err()
+	for _, result := range results {
+		if len(result.Scores) == 0 {
+			result.Scores = bigFiveScores(result)
+		}
+	}
+	return nil
 }
 
-// GetAll retrieves all personality results, ordered by created_at DESC
-func (r *ResultRepository) GetAll() ([]*domain.PersonalityResult, error) {
+// bigFiveScores returns a result's scores from its Big Five fields, for
+// results that were not scored per scale
+func bigFiveScores(result *domain.PersonalityResult) map[domain.Trait]float64 {
+	return map[domain.Trait]float64{
+		domain.TraitExtraversion:       result.Extraversion,
+		domain.TraitAgreeableness:      result.Agreeableness,
+		domain.TraitConscientiousness:  result.Conscientiousness,
+		domain.TraitEmotionalStability: result.EmotionalStability,
+		domain.TraitOpenness:           result.Openness,
+	}
+}
+
+// loadScores reads the result_scores rows of a batch of results into byID
+func (r *ResultRepository) loadScores(ids []string, byID map[string]*domain.PersonalityResult) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := r.query(`
+		SELECT result_id, scale, score
+		FROM result_scores
+		WHERE result_id IN (`+placeholders+`)
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resultID, scale string
+		var score float64
+		if err := rows.Scan(&resultID, &scale, &score); err != nil {
+			return err
+		}
+		if result, ok := byID[resultID]; ok {
+			result.Scores[domain.Trait(scale)] = score
+		}
+	}
+	return rows.Err()
+}
+
+// loadFacetScores reads the result_facet_scores rows of a batch of results into byID
+func (r *ResultRepository) loadFacetScores(ids []string, byID map[string]*domain.PersonalityResult) error {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+	args := make([]any, len(ids))
+	for i, id := range ids {
+		args[i] = id
+	}
+
+	rows, err := r.query(`
+		SELECT result_id, facet, trait, score
+		FROM result_facet_scores
+		WHERE result_id IN (`+placeholders+`)
+		ORDER BY `+r.dialect.insertOrder+`
+	`, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var resultID, trait string
+		facet := domain.FacetScore{}
+		if err := rows.Scan(&resultID, &facet.Facet, &trait, &facet.Score); err != nil {
+			return err
+		}
+		facet.Trait = domain.Trait(trait)
+		if result, ok := byID[resultID]; ok {
+			result.Facets = append(result.Facets, facet)
+		}
+	}
+	return rows.Err()
+}
+
+// GetByID retrieves a personality result of a tenant by its ID
+func (r *ResultRepository) GetByID(tenantID, id string) (*domain.PersonalityResult, error) {
+	query := `
+		SELECT ` + resultColumns + `
+		FROM personality_results
+		WHERE tenant_id = ? AND id = ?
+	`
+
+	result, err := scanResult(r.queryRow(query, tenantID, id))
+	if err == sql.ErrNoRows {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.attachScores([]*domain.PersonalityResult{result}); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetBySessionID retrieves all results of a tenant for a session
+func (r *ResultRepository) GetBySessionID(tenantID, sessionID string) ([]*domain.PersonalityResult, error) {
 	query := `
-		SELECT id, session_id, extraversion, agreeableness, 
-			conscientiousness, emotional_stability, openness, created_at
+		SELECT ` + resultColumns + `
 		FROM personality_results
+		WHERE tenant_id = ? AND session_id = ?
 		ORDER BY created_at DESC
 	`
 
-	rows, err := r.db.Query(query)
+	return r.queryResults(query, tenantID, sessionID)
+}
+
+// ListResults retrieves a page of the personality results of a tenant
+// matching the query, newest first unless the query orders them otherwise.
+// Pages continue after the sort key and ID of the previous page's last
+// result, so they stay stable while results are added. It returns
+// ErrInvalidCursor if the query's cursor was not returned for its order.
+func (r *ResultRepository) ListResults(tenantID string, query domain.ResultListQuery) (*domain.ResultPage, error) {
+	if query.Limit <= 0 {
+		return nil, fmt.Errorf("result page limit must be positive, got %d", query.Limit)
+	}
+	after, err := decodeResultCursor(query)
 	if err != nil {
 		return nil, err
 	}
-	defer rows.Close()
 
-	var results []*domain.PersonalityResult
-	for rows.Next() {
-		result := &domain.PersonalityResult{}
-		var createdAtStr string
-		err := rows.Scan(
-			&result.ID,
-			&result.SessionID,
-			&result.Extraversion,
-			&result.Agreeableness,
-			&result.Conscientiousness,
-			&result.EmotionalStability,
-			&result.Openness,
-			&createdAtStr,
-		)
-		if err != nil {
-			return nil, err
+	condition, args := resultListCondition(tenantID, query)
+	from, countFrom := "personality_results p", "personality_results p"
+	key, id := "p.created_at", "p.id"
+	if query.SortScale != "" {
+		// Pages walk the scale's scores in order, which SQLite only does with
+		// result_scores first; CROSS JOIN keeps that order in SQLite and is
+		// planned like any join by PostgreSQL. The count starts from the
+		// tenant's results instead.
+		from = "result_scores k CROSS JOIN personality_results p"
+		countFrom = "personality_results p JOIN result_scores k ON k.result_id = p.id"
+		key, id = "k.score", "k.result_id"
+		scoreCondition := []string{"k.result_id = p.id", "k.scale = ?"}
+		scoreArgs := []any{string(query.SortScale)}
+		// A range on the sorted scale also bounds the walk
+		for _, scoreRange := range query.Scores {
+			if scoreRange.Scale != query.SortScale {
+				continue
+			}
+			if scoreRange.Min != nil {
+				scoreCondition = append(scoreCondition, "k.score >= ?")
+				scoreArgs = append(scoreArgs, *scoreRange.Min)
+			}
+			if scoreRange.Max != nil {
+				scoreCondition = append(scoreCondition, "k.score <= ?")
+				scoreArgs = append(scoreArgs, *scoreRange.Max)
+			}
 		}
-		results = append(results, result)
+		condition = strings.Join(scoreCondition, " AND ") + " AND " + condition
+		args = append(scoreArgs, args...)
+	}
+
+	page := &domain.ResultPage{Results: []*domain.PersonalityResult{}}
+	if err := r.queryRow(`SELECT COUNT(*) FROM `+countFrom+` WHERE `+condition, args...).Scan(&page.Total); err != nil {
+		return nil, err
 	}
 
-	return results, rows.Err()
+	direction, compare := "DESC", "<"
+	if query.Ascending {
+		direction, compare = "ASC", ">"
+	}
+	if after != nil {
+		var value any = after.CreatedAt
+		if query.SortScale != "" {
+			value = after.Score
+		}
+		condition += " AND (" + key + " " + compare + " ? OR (" + key + " = ? AND " + id + " " + compare + " ?))"
+		args = append(args, value, value, after.ID)
+	}
+
+	// One result more than the page shows whether there is a next page
+	results, err := r.queryResults(`
+		SELECT `+resultColumns+`
+		FROM `+from+`
+		WHERE `+condition+`
+		ORDER BY `+key+` `+direction+`, `+id+` `+direction+`
+		LIMIT ?
+	`, append(args, query.Limit+1)...)
+	if err != nil {
+		return nil, err
+	}
+	if len(results) > query.Limit {
+		results = results[:query.Limit]
+		page.NextCursor = encodeResultCursor(query, results[len(results)-1])
+	}
+	page.Results = append(page.Results, results...)
+	return page, nil
+}
+
+// resultListCondition returns the condition on personality_results p
+// selecting a tenant's results matching a listing query
+func resultListCondition(tenantID string, query domain.ResultListQuery) (string, []any) {
+	where := []string{"p.tenant_id = ?"}
+	args := []any{tenantID}
+	if query.From != nil {
+		where = append(where, "p.created_at >= ?")
+		args = append(args, formatOptionalTime(query.From))
+	}
+	if query.To != nil {
+		where = append(where, "p.created_at < ?")
+		args = append(args, formatOptionalTime(query.To))
+	}
+	if query.Language != "" {
+		where = append(where, "p.language = ?")
+		args = append(args, query.Language)
+	}
+	if query.CohortID != "" {
+		where = append(where, "p.cohort_id = ?")
+		args = append(args, query.CohortID)
+	}
+	if query.QualityFlag != "" {
+		where = append(where, "p.quality_flag = ?")
+		args = append(args, query.QualityFlag)
+	}
+	if query.HasInterpretations != nil {
+		exists := "EXISTS (SELECT 1 FROM trait_interpretations i WHERE i.result_id = p.id AND i.active = ?)"
+		if !*query.HasInterpretations {
+			exists = "NOT " + exists
+		}
+		where = append(where, exists)
+		args = append(args, true)
+	}
+	// Score ranges select from the scale's scores in score order rather
+	// than looking up every result's score
+	for _, scoreRange := range query.Scores {
+		in := "p.id IN (SELECT result_id FROM result_scores WHERE scale = ?"
+		args = append(args, string(scoreRange.Scale))
+		if scoreRange.Min != nil {
+			in += " AND score >= ?"
+			args = append(args, *scoreRange.Min)
+		}
+		if scoreRange.Max != nil {
+			in += " AND score <= ?"
+			args = append(args, *scoreRange.Max)
+		}
+		where = append(where, in+")")
+	}
+	return strings.Join(where, " AND "), args
 }
 
-// SaveInterpretation stores a trait interpretation in the database
-func (r *ResultRepository) SaveInterpretation(interp *domain.TraitInterpretation) error {
+// GetByCohortID retrieves all results of a tenant's cohort, oldest first
+func (r *ResultRepository) GetByCohortID(tenantID, cohortID string) ([]*domain.PersonalityResult, error) {
 	query := `
-		INSERT INTO trait_interpretations (
-			id, result_id, trait, interpretation, created_at
-		) VALUES (?, ?, ?, ?, ?)
+		SELECT ` + resultColumns + `
+		FROM personality_results
+		WHERE tenant_id = ? AND cohort_id = ?
+		ORDER BY created_at
 	`
 
-	_, err := r.db.Exec(query,
-		interp.ID,
-		interp.ResultID,
-		string(interp.Trait),
-		interp.Interpretation,
-		interp.CreatedAt.Format("2006-01-02 15:04:05"),
-	)
+	return r.queryResults(query, tenantID, cohortID)
+}
 
-	return err
+// SetCohortConsent stores whether a cohort's teacher may see a result
+func (r *ResultRepository) SetCohortConsent(tenantID, id string, consent bool) error {
+	result, err := r.exec(`
+		UPDATE personality_results SET cohort_consent = ? WHERE tenant_id = ? AND id = ?
+	`, consent, tenantID, id)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// SaveInterpretationVersion stores interpretations of a result of a tenant as
+// the result's next version and makes that version active. Traits missing
+// from interpretations keep their active interpretation, which is copied into
+// the new version. It runs in a single transaction, so the previous version
+// stays active if the new one cannot be stored. It returns the new version,
+// or ErrNotFound if the tenant has no such result.
+func (r *ResultRepository) SaveInterpretationVersion(tenantID, resultID string, interpretations []*domain.TraitInterpretation) (int, error) {
+	if len(interpretations) == 0 {
+		return 0, fmt.Errorf("no interpretations to store for result %s", resultID)
+	}
+
+	var version int
+	err := inTransaction(r.db, func(tx *sql.Tx) error {
+		if err := r.checkResult(tx, tenantID, resultID); err != nil {
+			return err
+		}
+
+		if err := tx.QueryRow(r.dialect.rebind(`
+			SELECT COALESCE(MAX(version), 0) + 1 FROM trait_interpretations WHERE result_id = ?
+		`), resultID).Scan(&version); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(r.dialect.rebind(`
+			SELECT `+interpretationColumns+`
+			FROM trait_interpretations i
+			WHERE i.result_id = ? AND i.active = ?
+		`), resultID, true)
+		if err != nil {
+			return err
+		}
+		previous, err := scanInterpretations(rows)
+		if err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(r.dialect.rebind(`
+			UPDATE trait_interpretations SET active = ? WHERE result_id = ?
+		`), false, resultID); err != nil {
+			return err
+		}
+
+		query := `
+			INSERT INTO trait_interpretations (
+				id, result_id, version, trait, interpretation, active, language, model,
+				prompt_version, temperature, prompt_tokens, completion_tokens, generated_at
+			) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		`
+		for _, interp := range slices.Concat(interpretations, keptInterpretations(previous, interpretations)) {
+			interp.ResultID = resultID
+			interp.Version = version
+			interp.Active = true
+			_, err := tx.Exec(r.dialect.rebind(query),
+				interp.ID,
+				interp.ResultID,
+				interp.Version,
+				string(interp.Trait),
+				interp.Interpretation,
+				interp.Active,
+				interp.Language,
+				interp.Model,
+				interp.PromptVersion,
+				interp.Temperature,
+				interp.PromptTokens,
+				interp.CompletionTokens,
+				interp.GeneratedAt.Format("2006-01-02 15:04:05"),
+			)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return version, nil
 }
 
-// SaveInterpretations stores multiple trait interpretations in the database
-func (r *ResultRepository) SaveInterpretations(interpretations []*domain.TraitInterpretation) error {
-	for _, interp := range interpretations {
-		if err := r.SaveInterpretation(interp); err != nil {
+// ActivateInterpretationVersion makes an earlier version of the
+// interpretations of a result of a tenant the active one. It returns
+// ErrNotFound if the tenant has no such result and
+// ErrInterpretationVersionNotFound if the result has no such version.
+func (r *ResultRepository) ActivateInterpretationVersion(tenantID, resultID string, version int) error {
+	return inTransaction(r.db, func(tx *sql.Tx) error {
+		if err := r.checkResult(tx, tenantID, resultID); err != nil {
+			return err
+		}
+
+		var count int
+		if err := tx.QueryRow(r.dialect.rebind(`
+			SELECT COUNT(*) FROM trait_interpretations WHERE result_id = ? AND version = ?
+		`), resultID, version).Scan(&count); err != nil {
 			return err
 		}
+		if count == 0 {
+			return ErrInterpretationVersionNotFound
+		}
+
+		_, err := tx.Exec(r.dialect.rebind(`
+			UPDATE trait_interpretations SET active = (version = ?) WHERE result_id = ?
+		`), version, resultID)
+		return err
+	})
+}
+
+// checkResult returns ErrNotFound if the tenant has no such result
+func (r *ResultRepository) checkResult(tx *sql.Tx, tenantID, resultID string) error {
+	var count int
+	if err := tx.QueryRow(r.dialect.rebind(`
+		SELECT COUNT(*) FROM personality_results WHERE tenant_id = ? AND id = ?
+	`), tenantID, resultID).Scan(&count); err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrNotFound
 	}
 	return nil
 }
 
-// GetInterpretationsByResultID retrieves all interpretations for a result
-func (r *ResultRepository) GetInterpretationsByResultID(resultID string) (map[domain.Trait]string, error) {
+// GetInterpretationsByResultID retrieves the active interpretations for a result of a tenant
+func (r *ResultRepository) GetInterpretationsByResultID(tenantID, resultID string) (map[domain.Trait]string, error) {
 	query := `
-		SELECT trait, interpretation
-		FROM trait_interpretations
-		WHERE result_id = ?
+		SELECT i.trait, i.interpretation
+		FROM trait_interpretations i
+		JOIN personality_results p ON p.id = i.result_id
+		WHERE p.tenant_id = ? AND i.result_id = ? AND i.active = ?
 	`
 
-	rows, err := r.db.Query(query, resultID)
+	rows, err := r.query(query, tenantID, resultID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -209,14 +713,78 @@ func (r *ResultRepository) GetInterpretationsByResultID(resultID string) (map[do
 	return interpretations, rows.Err()
 }
 
-// GetByIDWithInterpretations retrieves a personality result by its ID including interpretations
-func (r *ResultRepository) GetByIDWithInterpretations(id string) (*domain.PersonalityResult, error) {
-	result, err := r.GetByID(id)
+// GetInterpretationHistory retrieves every version of the interpretations for
+// a result of a tenant, newest version first
+func (r *ResultRepository) GetInterpretationHistory(tenantID, resultID string) ([]*domain.TraitInterpretation, error) {
+	query := `
+		SELECT ` + interpretationColumns + `
+		FROM trait_interpretations i
+		JOIN personality_results p ON p.id = i.result_id
+		WHERE p.tenant_id = ? AND i.result_id = ?
+		ORDER BY i.version DESC, i.trait
+	`
+
+	rows, err := r.query(query, tenantID, resultID)
+	if err != nil {
+		return nil, err
+	}
+	return scanInterpretations(rows)
+}
+
+// interpretationColumns lists the trait_interpretations columns, as i, read
+// by scanInterpretations
+const interpretationColumns = `i.id, i.result_id, i.version, i.trait, i.interpretation, i.active, i.language, i.model,
+			i.prompt_version, i.temperature, i.prompt_tokens, i.completion_tokens, i.generated_at`
+
+// scanInterpretations reads and closes rows of interpretations selected with
+// interpretationColumns
+func scanInterpretations(rows *sql.Rows) ([]*domain.TraitInterpretation, error) {
+	defer rows.Close()
+
+	var interpretations []*domain.TraitInterpretation
+	for rows.Next() {
+		var interp domain.TraitInterpretation
+		var trait, generatedAt string
+		err := rows.Scan(&interp.ID, &interp.ResultID, &interp.Version, &trait, &interp.Interpretation,
+			&interp.Active, &interp.Language, &interp.Model, &interp.PromptVersion, &interp.Temperature,
+			&interp.PromptTokens, &interp.CompletionTokens, &generatedAt)
+		if err != nil {
+			return nil, err
+		}
+		interp.Trait = domain.Trait(trait)
+		interp.GeneratedAt, _ = time.Parse("2006-01-02 15:04:05", generatedAt)
+		interpretations = append(interpretations, &interp)
+	}
+
+	return interpretations, rows.Err()
+}
+
+// keptInterpretations returns copies of the active interpretations of traits
+// a new version leaves out, to be stored with that version. They keep the
+// text and provenance of the version they were generated for.
+func keptInterpretations(active, interpretations []*domain.TraitInterpretation) []*domain.TraitInterpretation {
+	var kept []*domain.TraitInterpretation
+	for _, previous := range active {
+		if slices.ContainsFunc(interpretations, func(interp *domain.TraitInterpretation) bool {
+			return interp.Trait == previous.Trait
+		}) {
+			continue
+		}
+		interp := *previous
+		interp.ID = uuid.New().String()
+		kept = append(kept, &interp)
+	}
+	return kept
+}
+
+// GetByIDWithInterpretations retrieves a personality result of a tenant by its ID including interpretations
+func (r *ResultRepository) GetByIDWithInterpretations(tenantID, id string) (*domain.PersonalityResult, error) {
+	result, err := r.GetByID(tenantID, id)
 	if err != nil {
 		return nil, err
 	}
 
-	interpretations, err := r.GetInterpretationsByResultID(id)
+	interpretations, err := r.GetInterpretationsByResultID(tenantID, id)
 	if err != nil {
 		return nil, err
 	}
@@ -225,9 +793,276 @@ func (r *ResultRepository) GetByIDWithInterpretations(id string) (*domain.Person
 	return result, nil
 }
 
-// DeleteInterpretationsByResultID deletes all interpretations for a result
-func (r *ResultRepository) DeleteInterpretationsByResultID(resultID string) error {
-	query := `DELETE FROM trait_interpretations WHERE result_id = ?`
-	_, err := r.db.Exec(query, resultID)
+// DeleteInterpretationsByResultID deletes all interpretations for a result of a tenant
+func (r *ResultRepository) DeleteInterpretationsByResultID(tenantID, resultID string) error {
+	query := `
+		DELETE FROM trait_interpretations
+		WHERE result_id IN (SELECT id FROM personality_results WHERE tenant_id = ? AND id = ?)
+	`
+	_, err := r.exec(query, tenantID, resultID)
 	return err
 }
+
+// CountResults counts a tenant's results matching the filter with their
+// answers and interpretations, i.e. what DeleteResults would delete
+func (r *ResultRepository) CountResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	condition, args := resultFilterCondition(tenantID, filter)
+	deletion := &domain.ResultDeletion{}
+	err := r.queryRow(`
+		SELECT
+			(SELECT COUNT(*) FROM personality_results WHERE `+condition+`),
+			(SELECT COUNT(*) FROM answers WHERE result_id IN (SELECT id FROM personality_results WHERE `+condition+`)),
+			(SELECT COUNT(*) FROM trait_interpretations WHERE result_id IN (SELECT id FROM personality_results WHERE `+condition+`))
+	`, append(append(append([]any{}, args...), args...), args...)...).Scan(&deletion.Results, &deletion.Answers, &deletion.Interpretations)
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// DeleteResults deletes a tenant's results matching the filter together with
+// their answers, scores and interpretations in a single transaction
+func (r *ResultRepository) DeleteResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	var deletion *domain.ResultDeletion
+	err := inTransaction(r.db, func(tx *sql.Tx) error {
+		var err error
+		deletion, err = r.deleteResults(tx, tenantID, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// deleteResults deletes the results matching the filter with their rows
+func (r *ResultRepository) deleteResults(tx *sql.Tx, tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	condition, args := resultFilterCondition(tenantID, filter)
+	rows, err := tx.Query(r.dialect.rebind(`SELECT id FROM personality_results WHERE `+condition), args...)
+	if err != nil {
+		return nil, err
+	}
+	deletion := &domain.ResultDeletion{ResultIDs: []string{}}
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		deletion.ResultIDs = append(deletion.ResultIDs, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(deletion.ResultIDs); start += scoreBatchSize {
+		ids := deletion.ResultIDs[start:min(start+scoreBatchSize, len(deletion.ResultIDs))]
+		placeholders := strings.TrimSuffix(strings.Repeat("?,", len(ids)), ",")
+		args := make([]any, len(ids))
+		for i, id := range ids {
+			args[i] = id
+		}
+
+		counts := []struct {
+			query string
+			count *int
+		}{
+			{`DELETE FROM trait_interpretations WHERE result_id IN (` + placeholders + `)`, &deletion.Interpretations},
+			{`DELETE FROM answers WHERE result_id IN (` + placeholders + `)`, &deletion.Answers},
+			{`DELETE FROM result_scores WHERE result_id IN (` + placeholders + `)`, nil},
+			{`DELETE FROM result_facet_scores WHERE result_id IN (` + placeholders + `)`, nil},
+			{`DELETE FROM personality_results WHERE id IN (` + placeholders + `)`, &deletion.Results},
+		}
+		for _, c := range counts {
+			result, err := tx.Exec(r.dialect.rebind(c.query), args...)
+			if err != nil {
+				return nil, err
+			}
+			affected, err := result.RowsAffected()
+			if err != nil {
+				return nil, err
+			}
+			if c.count != nil {
+				*c.count += int(affected)
+			}
+		}
+	}
+
+	return deletion, nil
+}
+
+// DeleteInterpretations deletes the interpretations of a tenant's results
+// matching the filter. Results counts the results that had interpretations.
+func (r *ResultRepository) DeleteInterpretations(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	var deletion *domain.ResultDeletion
+	err := inTransaction(r.db, func(tx *sql.Tx) error {
+		var err error
+		deletion, err = r.deleteInterpretations(tx, tenantID, filter)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return deletion, nil
+}
+
+// deleteInterpretations deletes the interpretations of the results matching the filter
+func (r *ResultRepository) deleteInterpretations(tx *sql.Tx, tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	condition, args := resultFilterCondition(tenantID, filter)
+	deletion := &domain.ResultDeletion{}
+	if err := tx.QueryRow(r.dialect.rebind(`
+		SELECT COUNT(DISTINCT result_id) FROM trait_interpretations
+		WHERE result_id IN (SELECT id FROM personality_results WHERE `+condition+`)
+	`), args...).Scan(&deletion.Results); err != nil {
+		return nil, err
+	}
+
+	result, err := tx.Exec(r.dialect.rebind(`
+		DELETE FROM trait_interpretations
+		WHERE result_id IN (SELECT id FROM personality_results WHERE `+condition+`)
+	`), args...)
+	if err != nil {
+		return nil, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+	deletion.Interpretations = int(affected)
+
+	return deletion, nil
+}
+
+// resultFilterCondition returns the personality_results condition selecting
+// a tenant's results matching the filter
+func resultFilterCondition(tenantID string, filter domain.ResultFilter) (string, []any) {
+	where := []string{"tenant_id = ?"}
+	args := []any{tenantID}
+	if filter.ID != "" {
+		where = append(where, "id = ?")
+		args = append(args, filter.ID)
+	}
+	if filter.SessionID != "" {
+		where = append(where, "session_id = ?")
+		args = append(args, filter.SessionID)
+	}
+	switch filter.Results {
+	case domain.RetentionResultsAnonymous:
+		where = append(where, "cohort_id = ''")
+	case domain.RetentionResultsCohort:
+		where = append(where, "cohort_id != ''")
+	}
+	if filter.CreatedBefore != nil {
+		where = append(where, "created_at < ?")
+		args = append(args, formatOptionalTime(filter.CreatedBefore))
+	}
+	if filter.WithInterpretations {
+		where = append(where, "id IN (SELECT result_id FROM trait_interpretations)")
+	}
+	return strings.Join(where, " AND "), args
+}
+
+// GetAnswersByResultID retrieves the raw answers stored for a result of a tenant
+func (r *ResultRepository) GetAnswersByResultID(tenantID, resultID string) ([]*domain.StoredAnswer, error) {
+	query := `
+		SELECT a.result_id, a.question_id, a.value, a.latency_ms, a.answered_at
+		FROM answers a
+		JOIN personality_results p ON p.id = a.result_id
+		WHERE p.tenant_id = ? AND a.result_id = ?
+		ORDER BY a.question_id
+	`
+
+	rows, err := r.query(query, tenantID, resultID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var answers []*domain.StoredAnswer
+	for rows.Next() {
+		answer := &domain.StoredAnswer{}
+		var answeredAtStr string
+		err := rows.Scan(
+			&answer.ResultID,
+			&answer.QuestionID,
+			&answer.Value,
+			&answer.LatencyMs,
+			&answeredAtStr,
+		)
+		if err != nil {
+			return nil, err
+		}
+		answer.AnsweredAt, _ = time.Parse("2006-01-02 15:04:05", answeredAtStr)
+		answers = append(answers, answer)
+	}
+
+	return answers, rows.Err()
+}
+
+// ScoreDistribution computes the sample size and per-scale mean and standard
+// deviation of a tenant's results matching the filter. Results are included if
+// created_at is within [From, To). Protocols flagged invalid for careless
+// responding are excluded.
+func (r *ResultRepository) ScoreDistribution(tenantID string, filter domain.NormSnapshotFilter) (int, map[domain.Trait]domain.ScaleNorm, error) {
+	where := []string{"p.tenant_id = ?", "p.instrument_id = ?", "p.quality_flag != ?"}
+	args := []any{tenantID, filter.InstrumentID, domain.QualityInvalid}
+	if filter.Language != "" {
+		where = append(where, "p.language = ?")
+		args = append(args, filter.Language)
+	}
+	if filter.AgeMin > 0 {
+		where = append(where, "p.age >= ?")
+		args = append(args, filter.AgeMin)
+	}
+	if filter.AgeMax > 0 {
+		where = append(where, "p.age > 0 AND p.age <= ?")
+		args = append(args, filter.AgeMax)
+	}
+	if filter.From != nil {
+		where = append(where, "p.created_at >= ?")
+		args = append(args, formatOptionalTime(filter.From))
+	}
+	if filter.To != nil {
+		where = append(where, "p.created_at < ?")
+		args = append(args, formatOptionalTime(filter.To))
+	}
+
+	query := `
+		SELECT s.scale, COUNT(*), AVG(s.score), AVG(s.score * s.score)
+		FROM result_scores s
+		JOIN personality_results p ON p.id = s.result_id
+		WHERE ` + strings.Join(where, " AND ") + `
+		GROUP BY s.scale
+	`
+
+	rows, err := r.query(query, args...)
+	if err != nil {
+		return 0, nil, err
+	}
+	defer rows.Close()
+
+	n := 0
+	scales := make(map[domain.Trait]domain.ScaleNorm)
+	for rows.Next() {
+		var scale string
+		var count int
+		var mean, meanSquares float64
+		if err := rows.Scan(&scale, &count, &mean, &meanSquares); err != nil {
+			return 0, nil, err
+		}
+
+		// Sample standard deviation from the aggregated moments
+		variance := 0.0
+		if count > 1 {
+			variance = (meanSquares - mean*mean) * float64(count) / float64(count-1)
+		}
+		scales[domain.Trait(scale)] = domain.ScaleNorm{
+			Mean: mean,
+			SD:   math.Sqrt(math.Max(variance, 0)),
+		}
+		n = max(n, count)
+	}
+
+	return n, scales, rows.Err()
+}
@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// resultCursor is the position a result listing continues after: the sort
+// key and ID of the last result of the previous page. Order records the
+// order the cursor was returned for, so it is not applied to another one.
+type resultCursor struct {
+	Order     string  `json:"o"`
+	CreatedAt string  `json:"c,omitempty"`
+	Score     float64 `json:"s,omitempty"`
+	ID        string  `json:"id"`
+}
+
+// resultOrder names the order of a listing query, e.g. "created_at desc"
+func resultOrder(query domain.ResultListQuery) string {
+	order := "created_at"
+	if query.SortScale != "" {
+		order = string(query.SortScale)
+	}
+	if query.Ascending {
+		return order + " asc"
+	}
+	return order + " desc"
+}
+
+// encodeResultCursor returns the opaque cursor continuing a listing after a result
+func encodeResultCursor(query domain.ResultListQuery, last *domain.PersonalityResult) string {
+	cursor := resultCursor{Order: resultOrder(query), ID: last.ID}
+	if query.SortScale != "" {
+		cursor.Score = last.Scores[query.SortScale]
+	} else {
+		cursor.CreatedAt = createdAt(last)
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeResultCursor returns the position of a listing query's cursor, or nil
+// if it has none
+func decodeResultCursor(query domain.ResultListQuery) (*resultCursor, error) {
+	if query.Cursor == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil {
+		return nil, ErrInvalidCursor
+	}
+	var cursor resultCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.Order != resultOrder(query) {
+		return nil, ErrInvalidCursor
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,587 @@
+package repository
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// MemoryResultRepository keeps personality results in memory, for tests and
+// demos. It behaves like ResultRepository but loses all results on restart.
+type MemoryResultRepository struct {
+	mu      sync.RWMutex
+	results map[string]*memoryResult
+	// seq orders results that were created in the same second
+	seq int
+}
+
+// memoryResult is a stored result with the rows kept next to it
+type memoryResult struct {
+	result  *domain.PersonalityResult
+	seq     int
+	answers []*domain.StoredAnswer
+	// interpretations holds every version of the result's interpretations
+	interpretations []*domain.TraitInterpretation
+}
+
+// NewMemoryResultRepository creates an empty in-memory result repository
+func NewMemoryResultRepository() *MemoryResultRepository {
+	return &MemoryResultRepository{results: make(map[string]*memoryResult)}
+}
+
+// Save stores a personality result and the raw answers it was calculated from
+func (r *MemoryResultRepository) Save(result *domain.PersonalityResult, answers []domain.Answer) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, exists := r.results[result.ID]; exists {
+		return fmt.Errorf("result %s already exists", result.ID)
+	}
+
+	// Times are kept at the precision and in the zone ResultRepository
+	// reads them back with
+	stored := copyResult(result)
+	stored.CreatedAt, _ = time.Parse("2006-01-02 15:04:05", result.CreatedAt.Format("2006-01-02 15:04:05"))
+	stored.StartedAt = parseOptionalTime(formatOptionalTime(result.StartedAt))
+	stored.CompletedAt = parseOptionalTime(formatOptionalTime(result.CompletedAt))
+	if len(stored.Scores) == 0 {
+		stored.Scores = bigFiveScores(stored)
+	}
+	stored.NormGroup = ""
+	stored.Norms = nil
+	stored.Interpretations = nil
+	for i := range stored.Facets {
+		stored.Facets[i].Name = ""
+	}
+
+	r.seq++
+	entry := &memoryResult{
+		result: stored,
+		seq:    r.seq,
+	}
+	for _, answer := range answers {
+		entry.answers = append(entry.answers, &domain.StoredAnswer{
+			ResultID:   result.ID,
+			QuestionID: answer.QuestionID,
+			Value:      answer.Value,
+			LatencyMs:  answer.LatencyMs,
+			AnsweredAt: stored.CreatedAt,
+		})
+	}
+	r.results[result.ID] = entry
+	return nil
+}
+
+// copyResult copies a result so callers cannot change a stored one
+func copyResult(result *domain.PersonalityResult) *domain.PersonalityResult {
+	copied := *result
+	copied.Scores = make(map[domain.Trait]float64, len(result.Scores))
+	for scale, score := range result.Scores {
+		copied.Scores[scale] = score
+	}
+	copied.Facets = slices.Clone(result.Facets)
+	if result.StartedAt != nil {
+		startedAt := *result.StartedAt
+		copied.StartedAt = &startedAt
+	}
+	if result.CompletedAt != nil {
+		completedAt := *result.CompletedAt
+		copied.CompletedAt = &completedAt
+	}
+	if result.Quality != nil {
+		quality := *result.Quality
+		quality.Reasons = slices.Clone(result.Quality.Reasons)
+		if quality.Inconsistency != nil {
+			inconsistency := *quality.Inconsistency
+			quality.Inconsistency = &inconsistency
+		}
+		if quality.SecondsPerItem != nil {
+			secondsPerItem := *quality.SecondsPerItem
+			quality.SecondsPerItem = &secondsPerItem
+		}
+		copied.Quality = &quality
+	}
+	if len(result.Interpretations) > 0 {
+		copied.Interpretations = make(map[domain.Trait]string, len(result.Interpretations))
+		for trait, interpretation := range result.Interpretations {
+			copied.Interpretations[trait] = interpretation
+		}
+	}
+	return &copied
+}
+
+// load returns a copy of a stored result
+func (e *memoryResult) load() *domain.PersonalityResult {
+	return copyResult(e.result)
+}
+
+// get returns a tenant's stored result
+func (r *MemoryResultRepository) get(tenantID, id string) (*memoryResult, bool) {
+	entry, ok := r.results[id]
+	if !ok || entry.result.TenantID != tenantID {
+		return nil, false
+	}
+	return entry, true
+}
+
+// find returns copies of a tenant's results accepted by match, newest first
+// or, with oldestFirst, oldest first
+func (r *MemoryResultRepository) find(tenantID string, oldestFirst bool, match func(*memoryResult) bool) []*domain.PersonalityResult {
+	var entries []*memoryResult
+	for _, entry := range r.results {
+		if entry.result.TenantID == tenantID && match(entry) {
+			entries = append(entries, entry)
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if !a.result.CreatedAt.Equal(b.result.CreatedAt) {
+			return a.result.CreatedAt.Before(b.result.CreatedAt) == oldestFirst
+		}
+		return (a.seq < b.seq) == oldestFirst
+	})
+
+	var results []*domain.PersonalityResult
+	for _, entry := range entries {
+		results = append(results, entry.load())
+	}
+	return results
+}
+
+// GetByID retrieves a personality result of a tenant by its ID
+func (r *MemoryResultRepository) GetByID(tenantID, id string) (*domain.PersonalityResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.get(tenantID, id)
+	if !ok {
+		return nil, ErrNotFound
+	}
+	return entry.load(), nil
+}
+
+// GetBySessionID retrieves all results of a tenant for a session
+func (r *MemoryResultRepository) GetBySessionID(tenantID, sessionID string) ([]*domain.PersonalityResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(tenantID, false, func(e *memoryResult) bool { return e.result.SessionID == sessionID }), nil
+}
+
+// GetAll retrieves the personality results of a tenant, newest first. An
+// ListResults retrieves a page of the personality results of a tenant
+// matching the query, ordered like ResultRepository orders them. It returns
+// ErrInvalidCursor if the query's cursor was not returned for its order.
+func (r *MemoryResultRepository) ListResults(tenantID string, query domain.ResultListQuery) (*domain.ResultPage, error) {
+	if query.Limit <= 0 {
+		return nil, fmt.Errorf("result page limit must be positive, got %d", query.Limit)
+	}
+	after, err := decodeResultCursor(query)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var entries []*memoryResult
+	for _, entry := range r.results {
+		if entry.result.TenantID == tenantID && entry.listed(query) {
+			entries = append(entries, entry)
+		}
+	}
+
+	// compare orders two results by the query's sort key, then by ID
+	compare := func(a *domain.PersonalityResult, createdAtB string, scoreB float64, idB string) int {
+		var c int
+		if query.SortScale != "" {
+			c = cmp.Compare(a.Scores[query.SortScale], scoreB)
+		} else {
+			c = cmp.Compare(createdAt(a), createdAtB)
+		}
+		if c == 0 {
+			c = cmp.Compare(a.ID, idB)
+		}
+		if !query.Ascending {
+			c = -c
+		}
+		return c
+	}
+	slices.SortFunc(entries, func(a, b *memoryResult) int {
+		return compare(a.result, createdAt(b.result), b.result.Scores[query.SortScale], b.result.ID)
+	})
+
+	page := &domain.ResultPage{Results: []*domain.PersonalityResult{}, Total: len(entries)}
+	for _, entry := range entries {
+		if after != nil && compare(entry.result, after.CreatedAt, after.Score, after.ID) <= 0 {
+			continue
+		}
+		if len(page.Results) == query.Limit {
+			page.NextCursor = encodeResultCursor(query, page.Results[len(page.Results)-1])
+			break
+		}
+		page.Results = append(page.Results, entry.load())
+	}
+	return page, nil
+}
+
+// listed reports whether a stored result matches a listing query. Score
+// filters and orders see the scores stored per scale, like the result_scores
+// rows of ResultRepository.
+func (e *memoryResult) listed(query domain.ResultListQuery) bool {
+	result := e.result
+	switch {
+	case query.From != nil && createdAt(result) < formatOptionalTime(query.From),
+		query.To != nil && createdAt(result) >= formatOptionalTime(query.To),
+		query.Language != "" && result.Language != query.Language,
+		query.CohortID != "" && result.CohortID != query.CohortID,
+		query.QualityFlag != "" && qualityFlagOf(result) != query.QualityFlag,
+		query.HasInterpretations != nil && e.interpreted() != *query.HasInterpretations:
+		return false
+	}
+	if query.SortScale != "" {
+		if _, ok := result.Scores[query.SortScale]; !ok {
+			return false
+		}
+	}
+	for _, scoreRange := range query.Scores {
+		score, ok := result.Scores[scoreRange.Scale]
+		if !ok ||
+			scoreRange.Min != nil && score < *scoreRange.Min ||
+			scoreRange.Max != nil && score > *scoreRange.Max {
+			return false
+		}
+	}
+	return true
+}
+
+// interpreted reports whether a stored result has active interpretations
+func (e *memoryResult) interpreted() bool {
+	return slices.ContainsFunc(e.interpretations, func(interp *domain.TraitInterpretation) bool {
+		return interp.Active
+	})
+}
+
+// createdAt returns a result's created_at column as ResultRepository stores
+// it, which time filters compare against
+func createdAt(result *domain.PersonalityResult) string {
+	return result.CreatedAt.Format("2006-01-02 15:04:05")
+}
+
+// qualityFlagOf returns the quality flag a result is stored with
+func qualityFlagOf(result *domain.PersonalityResult) string {
+	if result.Quality == nil {
+		return ""
+	}
+	return result.Quality.Flag
+}
+
+// GetByCohortID retrieves all results of a tenant's cohort, oldest first
+func (r *MemoryResultRepository) GetByCohortID(tenantID, cohortID string) ([]*domain.PersonalityResult, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.find(tenantID, true, func(e *memoryResult) bool { return e.result.CohortID == cohortID }), nil
+}
+
+// SetCohortConsent stores whether a cohort's teacher may see a result
+func (r *MemoryResultRepository) SetCohortConsent(tenantID, id string, consent bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.get(tenantID, id)
+	if !ok {
+		return ErrNotFound
+	}
+	entry.result.CohortConsent = consent
+	return nil
+}
+
+// SaveInterpretationVersion stores interpretations of a result of a tenant as
+// the result's next version and makes that version active, either all of them
+// or none. Traits missing from interpretations keep their active
+// interpretation, which is copied into the new version. It returns the new
+// version, or ErrNotFound if the tenant has no such result.
+func (r *MemoryResultRepository) SaveInterpretationVersion(tenantID, resultID string, interpretations []*domain.TraitInterpretation) (int, error) {
+	if len(interpretations) == 0 {
+		return 0, fmt.Errorf("no interpretations to store for result %s", resultID)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.get(tenantID, resultID)
+	if !ok {
+		return 0, ErrNotFound
+	}
+	seen := make(map[domain.Trait]bool)
+	for _, interp := range interpretations {
+		if seen[interp.Trait] {
+			return 0, fmt.Errorf("result %s has two %s interpretations in one version", resultID, interp.Trait)
+		}
+		seen[interp.Trait] = true
+	}
+
+	version := 1
+	var active []*domain.TraitInterpretation
+	for _, stored := range entry.interpretations {
+		version = max(version, stored.Version+1)
+		if stored.Active {
+			active = append(active, stored)
+		}
+		stored.Active = false
+	}
+	for _, interp := range slices.Concat(interpretations, keptInterpretations(active, interpretations)) {
+		interp.ResultID = resultID
+		interp.Version = version
+		interp.Active = true
+		stored := *interp
+		stored.GeneratedAt, _ = time.Parse("2006-01-02 15:04:05", interp.GeneratedAt.Format("2006-01-02 15:04:05"))
+		entry.interpretations = append(entry.interpretations, &stored)
+	}
+	return version, nil
+}
+
+// ActivateInterpretationVersion makes an earlier version of the
+// interpretations of a result of a tenant the active one. It returns
+// ErrNotFound if the tenant has no such result and
+// ErrInterpretationVersionNotFound if the result has no such version.
+func (r *MemoryResultRepository) ActivateInterpretationVersion(tenantID, resultID string, version int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.get(tenantID, resultID)
+	if !ok {
+		return ErrNotFound
+	}
+	if !slices.ContainsFunc(entry.interpretations, func(interp *domain.TraitInterpretation) bool {
+		return interp.Version == version
+	}) {
+		return ErrInterpretationVersionNotFound
+	}
+	for _, interp := range entry.interpretations {
+		interp.Active = interp.Version == version
+	}
+	return nil
+}
+
+// GetInterpretationsByResultID retrieves the active interpretations for a result of a tenant
+func (r *MemoryResultRepository) GetInterpretationsByResultID(tenantID, resultID string) (map[domain.Trait]string, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	interpretations := make(map[domain.Trait]string)
+	if entry, ok := r.get(tenantID, resultID); ok {
+		for _, interp := range entry.interpretations {
+			if interp.Active {
+				interpretations[interp.Trait] = interp.Interpretation
+			}
+		}
+	}
+	return interpretations, nil
+}
+
+// GetInterpretationHistory retrieves every version of the interpretations for
+// a result of a tenant, newest version first
+func (r *MemoryResultRepository) GetInterpretationHistory(tenantID, resultID string) ([]*domain.TraitInterpretation, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.get(tenantID, resultID)
+	if !ok {
+		return nil, nil
+	}
+
+	var interpretations []*domain.TraitInterpretation
+	for _, interp := range entry.interpretations {
+		copied := *interp
+		interpretations = append(interpretations, &copied)
+	}
+	sort.SliceStable(interpretations, func(i, j int) bool {
+		if interpretations[i].Version != interpretations[j].Version {
+			return interpretations[i].Version > interpretations[j].Version
+		}
+		return interpretations[i].Trait < interpretations[j].Trait
+	})
+	return interpretations, nil
+}
+
+// GetByIDWithInterpretations retrieves a personality result of a tenant by its ID including interpretations
+func (r *MemoryResultRepository) GetByIDWithInterpretations(tenantID, id string) (*domain.PersonalityResult, error) {
+	result, err := r.GetByID(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	interpretations, err := r.GetInterpretationsByResultID(tenantID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	result.Interpretations = interpretations
+	return result, nil
+}
+
+// DeleteInterpretationsByResultID deletes all interpretations for a result of a tenant
+func (r *MemoryResultRepository) DeleteInterpretationsByResultID(tenantID, resultID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if entry, ok := r.get(tenantID, resultID); ok {
+		entry.interpretations = nil
+	}
+	return nil
+}
+
+// matching returns a tenant's stored results matching the filter
+func (r *MemoryResultRepository) matching(tenantID string, filter domain.ResultFilter) []*memoryResult {
+	var entries []*memoryResult
+	for _, entry := range r.results {
+		result := entry.result
+		switch {
+		case result.TenantID != tenantID,
+			filter.ID != "" && result.ID != filter.ID,
+			filter.SessionID != "" && result.SessionID != filter.SessionID,
+			filter.Results == domain.RetentionResultsAnonymous && result.CohortID != "",
+			filter.Results == domain.RetentionResultsCohort && result.CohortID == "",
+			filter.CreatedBefore != nil && createdAt(result) >= formatOptionalTime(filter.CreatedBefore),
+			filter.WithInterpretations && len(entry.interpretations) == 0:
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// CountResults counts a tenant's results matching the filter with their
+// answers and interpretations, i.e. what DeleteResults would delete
+func (r *MemoryResultRepository) CountResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	deletion := &domain.ResultDeletion{}
+	for _, entry := range r.matching(tenantID, filter) {
+		deletion.Results++
+		deletion.Answers += len(entry.answers)
+		deletion.Interpretations += len(entry.interpretations)
+	}
+	return deletion, nil
+}
+
+// DeleteResults deletes a tenant's results matching the filter together with
+// their answers, scores and interpretations
+func (r *MemoryResultRepository) DeleteResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deletion := &domain.ResultDeletion{ResultIDs: []string{}}
+	for _, entry := range r.matching(tenantID, filter) {
+		deletion.ResultIDs = append(deletion.ResultIDs, entry.result.ID)
+		deletion.Results++
+		deletion.Answers += len(entry.answers)
+		deletion.Interpretations += len(entry.interpretations)
+		delete(r.results, entry.result.ID)
+	}
+	return deletion, nil
+}
+
+// DeleteInterpretations deletes the interpretations of a tenant's results
+// matching the filter. Results counts the results that had interpretations.
+func (r *MemoryResultRepository) DeleteInterpretations(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deletion := &domain.ResultDeletion{}
+	for _, entry := range r.matching(tenantID, filter) {
+		if len(entry.interpretations) == 0 {
+			continue
+		}
+		deletion.Results++
+		deletion.Interpretations += len(entry.interpretations)
+		entry.interpretations = nil
+	}
+	return deletion, nil
+}
+
+// GetAnswersByResultID retrieves the raw answers stored for a result of a tenant
+func (r *MemoryResultRepository) GetAnswersByResultID(tenantID, resultID string) ([]*domain.StoredAnswer, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	entry, ok := r.get(tenantID, resultID)
+	if !ok {
+		return nil, nil
+	}
+
+	var answers []*domain.StoredAnswer
+	for _, answer := range entry.answers {
+		copied := *answer
+		answers = append(answers, &copied)
+	}
+	sort.SliceStable(answers, func(i, j int) bool { return answers[i].QuestionID < answers[j].QuestionID })
+	return answers, nil
+}
+
+// ScoreDistribution computes the sample size and per-scale mean and standard
+// deviation of a tenant's results matching the filter. Results are included if
+// created_at is within [From, To). Protocols flagged invalid for careless
+// responding are excluded.
+func (r *MemoryResultRepository) ScoreDistribution(tenantID string, filter domain.NormSnapshotFilter) (int, map[domain.Trait]domain.ScaleNorm, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	type moments struct {
+		count             int
+		sum, sumOfSquares float64
+	}
+	byScale := make(map[domain.Trait]*moments)
+	for _, entry := range r.results {
+		result := entry.result
+		switch {
+		case result.TenantID != tenantID,
+			result.InstrumentID != filter.InstrumentID,
+			qualityFlagOf(result) == domain.QualityInvalid,
+			filter.Language != "" && result.Language != filter.Language,
+			filter.AgeMin > 0 && result.Age < filter.AgeMin,
+			filter.AgeMax > 0 && (result.Age <= 0 || result.Age > filter.AgeMax),
+			filter.From != nil && createdAt(result) < formatOptionalTime(filter.From),
+			filter.To != nil && createdAt(result) >= formatOptionalTime(filter.To):
+			continue
+		}
+		for scale, score := range result.Scores {
+			m, ok := byScale[scale]
+			if !ok {
+				m = &moments{}
+				byScale[scale] = m
+			}
+			m.count++
+			m.sum += score
+			m.sumOfSquares += score * score
+		}
+	}
+
+	n := 0
+	scales := make(map[domain.Trait]domain.ScaleNorm)
+	for scale, m := range byScale {
+		mean := m.sum / float64(m.count)
+		meanSquares := m.sumOfSquares / float64(m.count)
+
+		// Sample standard deviation from the aggregated moments
+		variance := 0.0
+		if m.count > 1 {
+			variance = (meanSquares - mean*mean) * float64(m.count) / float64(m.count-1)
+		}
+		scales[scale] = domain.ScaleNorm{
+			Mean: mean,
+			SD:   math.Sqrt(math.Max(variance, 0)),
+		}
+		n = max(n, m.count)
+	}
+	return n, scales, nil
+}
@@ -0,0 +1,52 @@
+package repository_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+	"github.com/thielel/voca/internal/service/storetest"
+)
+
+// TestResultStores runs the storetest conformance suite against every
+// result store. PostgreSQL is only checked if POSTGRES_URL is set; the checks
+// use tenants of their own and delete their results afterwards.
+func TestResultStores(t *testing.T) {
+	t.Run("sqlite", func(t *testing.T) {
+		db, err := repository.InitDB(filepath.Join(t.TempDir(), "voca.db"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		checkStore(t, func() (service.ResultStore, error) { return repository.NewResultRepository(db), nil })
+	})
+
+	t.Run("postgres", func(t *testing.T) {
+		url := os.Getenv("POSTGRES_URL")
+		if url == "" {
+			t.Skip("POSTGRES_URL not set")
+		}
+		db, err := repository.InitPostgres(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer db.Close()
+
+		checkStore(t, func() (service.ResultStore, error) { return repository.NewPostgresResultRepository(db), nil })
+	})
+
+	t.Run("memory", func(t *testing.T) {
+		checkStore(t, func() (service.ResultStore, error) { return repository.NewMemoryResultRepository(), nil })
+	})
+}
+
+// checkStore fails the test with every conformance check a store fails
+func checkStore(t *testing.T, newStore func() (service.ResultStore, error)) {
+	t.Helper()
+	if err := storetest.Run(newStore); err != nil {
+		t.Errorf("result store does not conform:\n%v", err)
+	}
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// RetentionRepository keeps the reports of retention purges
+type RetentionRepository struct {
+	db *sql.DB
+}
+
+// NewRetentionRepository creates a new retention repository
+func NewRetentionRepository(db *sql.DB) *RetentionRepository {
+	return &RetentionRepository{db: db}
+}
+
+// Create stores the report of a purge
+func (r *RetentionRepository) Create(report *domain.PurgeReport) error {
+	rules, err := json.Marshal(report.Rules)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.db.Exec(`
+		INSERT INTO purges (id, tenant_id, rules, ran_at)
+		VALUES (?, ?, ?, ?)
+	`, report.ID, report.TenantID, string(rules), formatOptionalTime(&report.RanAt))
+	return err
+}
+
+// GetAll retrieves the purge reports of a tenant, newest first
+func (r *RetentionRepository) GetAll(tenantID string) ([]*domain.PurgeReport, error) {
+	rows, err := r.db.Query(`
+		SELECT id, tenant_id, rules, ran_at
+		FROM purges
+		WHERE tenant_id = ?
+		ORDER BY ran_at DESC
+	`, tenantID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var reports []*domain.PurgeReport
+	for rows.Next() {
+		report := &domain.PurgeReport{}
+		var rules, ranAtStr string
+		if err := rows.Scan(&report.ID, &report.TenantID, &rules, &ranAtStr); err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal([]byte(rules), &report.Rules); err != nil {
+			return nil, err
+		}
+		if ranAt := parseOptionalTime(ranAtStr); ranAt != nil {
+			report.RanAt = *ranAt
+		}
+		reports = append(reports, report)
+	}
+
+	return reports, rows.Err()
+}
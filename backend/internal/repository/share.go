@@ -0,0 +1,173 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrShareNotFound is returned when a share link is not found
+var ErrShareNotFound = errors.New("share link not found")
+
+// ShareRepository handles database operations for result share links
+type ShareRepository struct {
+	db *sql.DB
+}
+
+// NewShareRepository creates a new share link repository
+func NewShareRepository(db *sql.DB) *ShareRepository {
+	return &ShareRepository{db: db}
+}
+
+// shareColumns lists the share_links columns read by scanShare
+const shareColumns = `id, tenant_id, result_id, scope, created_at, expires_at, revoked_at,
+			access_count, last_accessed_at`
+
+// Create stores a share link under the hash of its token
+func (r *ShareRepository) Create(link *domain.ShareLink, tokenHash string) error {
+	query := `
+		INSERT INTO share_links (
+			id, tenant_id, token_hash, result_id, scope, created_at, expires_at
+		) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`
+
+	_, err := r.db.Exec(query,
+		link.ID,
+		link.TenantID,
+		tokenHash,
+		link.ResultID,
+		link.Scope,
+		formatOptionalTime(&link.CreatedAt),
+		formatOptionalTime(link.ExpiresAt),
+	)
+
+	return err
+}
+
+// GetByTokenHash retrieves a share link of a tenant by the hash of its token
+func (r *ShareRepository) GetByTokenHash(tenantID, tokenHash string) (*domain.ShareLink, error) {
+	query := `
+		SELECT ` + shareColumns + `
+		FROM share_links
+		WHERE tenant_id = ? AND token_hash = ?
+	`
+
+	link, err := scanShare(r.db.QueryRow(query, tenantID, tokenHash))
+	if err == sql.ErrNoRows {
+		return nil, ErrShareNotFound
+	}
+	return link, err
+}
+
+// GetByResultID retrieves all share links of a tenant's result, newest first
+func (r *ShareRepository) GetByResultID(tenantID, resultID string) ([]*domain.ShareLink, error) {
+	query := `
+		SELECT ` + shareColumns + `
+		FROM share_links
+		WHERE tenant_id = ? AND result_id = ?
+		ORDER BY created_at DESC
+	`
+
+	rows, err := r.db.Query(query, tenantID, resultID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var links []*domain.ShareLink
+	for rows.Next() {
+		link, err := scanShare(rows)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, link)
+	}
+
+	return links, rows.Err()
+}
+
+// scanShare reads a share link selected with shareColumns
+func scanShare(row rowScanner) (*domain.ShareLink, error) {
+	link := &domain.ShareLink{}
+	var createdAtStr, expiresAtStr, revokedAtStr, lastAccessedAtStr string
+	err := row.Scan(
+		&link.ID,
+		&link.TenantID,
+		&link.ResultID,
+		&link.Scope,
+		&createdAtStr,
+		&expiresAtStr,
+		&revokedAtStr,
+		&link.AccessCount,
+		&lastAccessedAtStr,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if createdAt := parseOptionalTime(createdAtStr); createdAt != nil {
+		link.CreatedAt = *createdAt
+	}
+	link.ExpiresAt = parseOptionalTime(expiresAtStr)
+	link.RevokedAt = parseOptionalTime(revokedAtStr)
+	link.LastAccessedAt = parseOptionalTime(lastAccessedAtStr)
+	return link, nil
+}
+
+// Revoke marks a share link of a tenant's result as revoked. Revoking twice
+// keeps the original revocation time.
+func (r *ShareRepository) Revoke(tenantID, id, resultID string, revokedAt time.Time) error {
+	result, err := r.db.Exec(`
+		UPDATE share_links
+		SET revoked_at = CASE WHEN revoked_at = '' THEN ? ELSE revoked_at END
+		WHERE tenant_id = ? AND id = ? AND result_id = ?
+	`, formatOptionalTime(&revokedAt), tenantID, id, resultID)
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return ErrShareNotFound
+	}
+	return nil
+}
+
+// RecordAccess counts one use of a share link
+func (r *ShareRepository) RecordAccess(id string, accessedAt time.Time) error {
+	_, err := r.db.Exec(`
+		UPDATE share_links
+		SET access_count = access_count + 1, last_accessed_at = ?
+		WHERE id = ?
+	`, formatOptionalTime(&accessedAt), id)
+	return err
+}
+
+// DeleteByResultIDs deletes the share links of a tenant's results and
+// returns how many were deleted
+func (r *ShareRepository) DeleteByResultIDs(tenantID string, resultIDs []string) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	deleted, err := deleteShareLinks(tx, tenantID, resultIDs)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, tx.Commit()
+}
+
+// deleteShareLinks deletes the share links of a tenant's results
+func deleteShareLinks(tx *sql.Tx, tenantID string, resultIDs []string) (int, error) {
+	return forResultIDs(resultIDs, func(placeholders string, args []any) (int, error) {
+		return execCount(tx, `
+			DELETE FROM share_links WHERE tenant_id = ? AND result_id IN (`+placeholders+`)
+		`, append([]any{tenantID}, args...)...)
+	})
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/thielel/voca/data"
+	"github.com/thielel/voca/internal/domain"
+)
+
+// LoadTenants reads the tenant configuration from a JSON file. An empty path
+// loads the bundled configuration, which has only the default tenant.
+func LoadTenants(path string) ([]*domain.Tenant, error) {
+	var r io.ReadCloser
+	var err error
+	if path == "" {
+		r, err = data.FS.Open(data.TenantsFile)
+	} else {
+		r, err = os.Open(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	var config domain.TenantConfig
+	if err := json.NewDecoder(r).Decode(&config); err != nil {
+		return nil, fmt.Errorf("invalid tenant configuration: %w", err)
+	}
+	return config.Tenants, nil
+}
@@ -0,0 +1,64 @@
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+
+	"github.com/thielel/voca/data"
+	"github.com/thielel/voca/internal/domain"
+)
+
+// LoadTranslations reads the bundled item translations and, if dir is set,
+// every *.json translation in that directory. Files are returned in name order.
+func LoadTranslations(dir string) ([]*domain.Translation, error) {
+	bundled, err := fs.Sub(data.FS, data.TranslationsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := readTranslations(bundled)
+	if err != nil {
+		return nil, err
+	}
+
+	if dir != "" {
+		extra, err := readTranslations(os.DirFS(dir))
+		if err != nil {
+			return nil, err
+		}
+		translations = append(translations, extra...)
+	}
+
+	return translations, nil
+}
+
+// readTranslations parses all *.json files at the root of fsys
+func readTranslations(fsys fs.FS) ([]*domain.Translation, error) {
+	names, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(names)
+
+	var translations []*domain.Translation
+	for _, name := range names {
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return nil, err
+		}
+
+		var translation domain.Translation
+		if err := json.Unmarshal(content, &translation); err != nil {
+			return nil, fmt.Errorf("invalid translation %s: %w", path.Base(name), err)
+		}
+		if translation.InstrumentID == "" || translation.Language == "" || translation.Version == "" {
+			return nil, fmt.Errorf("invalid translation %s: instrument_id, language and version are required", path.Base(name))
+		}
+		translations = append(translations, &translation)
+	}
+	return translations, nil
+}
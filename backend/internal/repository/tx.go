@@ -0,0 +1,18 @@
+package repository
+
+import "database/sql"
+
+// inTransaction runs fn as a unit of work: its writes are committed together
+// if it returns nil and rolled back together if it returns an error or panics
+func inTransaction(db *sql.DB, fn func(tx *sql.Tx) error) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := fn(tx); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
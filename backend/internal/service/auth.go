@@ -0,0 +1,248 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var (
+	// ErrInvalidCredentials is returned when a username or password is wrong
+	ErrInvalidCredentials = errors.New("invalid username or password")
+	// ErrUnauthenticated is returned for unknown, expired or revoked admin
+	// sessions and API keys
+	ErrUnauthenticated = errors.New("not authenticated")
+	// ErrInvalidAdminRequest is returned when an admin user or API key request is invalid
+	ErrInvalidAdminRequest = errors.New("invalid request")
+	// ErrUserExists is returned when an admin username is already taken
+	ErrUserExists = errors.New("username already taken")
+)
+
+const (
+	// APIKeyPrefix starts every API key so leaked keys are easy to recognize
+	APIKeyPrefix = "voca_"
+	// apiKeyDisplayLength is how much of a key is stored in clear for listing
+	apiKeyDisplayLength = len(APIKeyPrefix) + 6
+	// minPasswordLength is the shortest accepted admin password
+	minPasswordLength = 10
+	// maxPasswordLength is the longest password bcrypt takes into account
+	maxPasswordLength = 72
+)
+
+// usernamePattern restricts admin usernames to a safe, case-insensitive set
+var usernamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{2,63}$`)
+
+// AuthService authenticates admin users and API keys. Passwords are stored
+// as bcrypt hashes; session tokens and API keys are random and stored as
+// SHA-256 hashes.
+type AuthService struct {
+	repo       *repository.AuthRepository
+	sessionTTL time.Duration
+	// dummyHash is compared against when a username does not exist, so
+	// unknown and known usernames take equally long to reject
+	dummyHash []byte
+}
+
+// NewAuthService creates a new auth service. Admin sessions are valid for
+// sessionTTL after sign-in.
+func NewAuthService(repo *repository.AuthRepository, sessionTTL time.Duration) *AuthService {
+	dummyHash, _ := bcrypt.GenerateFromPassword([]byte("voca-dummy-password"), bcrypt.DefaultCost)
+	return &AuthService{repo: repo, sessionTTL: sessionTTL, dummyHash: dummyHash}
+}
+
+// CreateUser validates and stores a new admin user of a tenant. Usernames are
+// unique across tenants.
+func (s *AuthService) CreateUser(tenantID string, req domain.CreateAdminUserRequest) (*domain.AdminUser, error) {
+	username := normalizeUsername(req.Username)
+	if !usernamePattern.MatchString(username) {
+		return nil, fmt.Errorf("%w: username must be 3-64 letters, digits, '.', '_' or '-'", ErrInvalidAdminRequest)
+	}
+	if len(req.Password) < minPasswordLength || len(req.Password) > maxPasswordLength {
+		return nil, fmt.Errorf("%w: password must be %d-%d bytes long", ErrInvalidAdminRequest, minPasswordLength, maxPasswordLength)
+	}
+	if !req.Role.Valid() {
+		return nil, fmt.Errorf("%w: role must be one of %v", ErrInvalidAdminRequest, domain.Roles)
+	}
+
+	exists, err := s.repo.UsernameExists(username)
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrUserExists
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		return nil, err
+	}
+
+	user := &domain.AdminUser{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Username:  username,
+		Role:      req.Role,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateUser(user, string(hash)); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// GetUsers lists the admin users of a tenant
+func (s *AuthService) GetUsers(tenantID string) ([]*domain.AdminUser, error) {
+	return s.repo.GetUsers(tenantID)
+}
+
+// Login checks a username and password and starts an admin session. Users
+// can only sign in to their own tenant. It returns the session token, which
+// is not stored and cannot be retrieved again.
+func (s *AuthService) Login(tenantID string, req domain.LoginRequest) (*domain.LoginResponse, string, error) {
+	user, hash, err := s.repo.GetUserByUsername(tenantID, normalizeUsername(req.Username))
+	if errors.Is(err, repository.ErrUserNotFound) {
+		bcrypt.CompareHashAndPassword(s.dummyHash, []byte(req.Password))
+		return nil, "", ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, "", err
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(req.Password)); err != nil {
+		return nil, "", ErrInvalidCredentials
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(s.sessionTTL)
+	if err := s.repo.DeleteExpiredSessions(now); err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.CreateSession(hashToken(token), user.ID, now, expiresAt); err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.RecordLogin(user.ID, now); err != nil {
+		return nil, "", err
+	}
+	user.LastLoginAt = &now
+
+	return &domain.LoginResponse{User: user, ExpiresAt: expiresAt}, token, nil
+}
+
+// Logout ends the admin session of a token
+func (s *AuthService) Logout(token string) error {
+	return s.repo.DeleteSession(hashToken(token))
+}
+
+// AuthenticateSession resolves an admin session token to its user. Sessions
+// of another tenant's users are rejected.
+func (s *AuthService) AuthenticateSession(tenantID, token string) (*domain.Principal, error) {
+	user, expiresAt, err := s.repo.GetSessionUser(tenantID, hashToken(token))
+	if errors.Is(err, repository.ErrAdminSessionNotFound) {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !time.Now().Before(expiresAt) {
+		return nil, ErrUnauthenticated
+	}
+
+	return &domain.Principal{
+		ID:       user.ID,
+		TenantID: user.TenantID,
+		Name:     user.Username,
+		Role:     user.Role,
+		Method:   domain.AuthMethodSession,
+	}, nil
+}
+
+// AuthenticateAPIKey resolves an API key to its principal and records its
+// use. Keys of another tenant are rejected.
+func (s *AuthService) AuthenticateAPIKey(tenantID, key string) (*domain.Principal, error) {
+	if !strings.HasPrefix(key, APIKeyPrefix) {
+		return nil, ErrUnauthenticated
+	}
+
+	apiKey, err := s.repo.GetAPIKeyByHash(tenantID, hashToken(key))
+	if errors.Is(err, repository.ErrAPIKeyNotFound) {
+		return nil, ErrUnauthenticated
+	}
+	if err != nil {
+		return nil, err
+	}
+	if apiKey.RevokedAt != nil {
+		return nil, ErrUnauthenticated
+	}
+
+	if err := s.repo.RecordAPIKeyUse(apiKey.ID, time.Now()); err != nil {
+		return nil, err
+	}
+
+	return &domain.Principal{
+		ID:       apiKey.ID,
+		TenantID: apiKey.TenantID,
+		Name:     apiKey.Name,
+		Role:     apiKey.Role,
+		Method:   domain.AuthMethodAPIKey,
+	}, nil
+}
+
+// CreateAPIKey creates an API key of a tenant on behalf of createdBy. The
+// returned key carries its secret, which is not stored and cannot be
+// retrieved again.
+func (s *AuthService) CreateAPIKey(tenantID string, req domain.CreateAPIKeyRequest, createdBy string) (*domain.APIKey, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 100 {
+		return nil, fmt.Errorf("%w: name must be 1-100 characters", ErrInvalidAdminRequest)
+	}
+	if !req.Role.Valid() {
+		return nil, fmt.Errorf("%w: role must be one of %v", ErrInvalidAdminRequest, domain.Roles)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+	secret := APIKeyPrefix + token
+
+	key := &domain.APIKey{
+		ID:        uuid.New().String(),
+		TenantID:  tenantID,
+		Name:      name,
+		Prefix:    secret[:apiKeyDisplayLength],
+		Role:      req.Role,
+		Key:       secret,
+		CreatedBy: createdBy,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateAPIKey(key, hashToken(secret)); err != nil {
+		return nil, err
+	}
+	return key, nil
+}
+
+// GetAPIKeys lists the API keys of a tenant without their secrets
+func (s *AuthService) GetAPIKeys(tenantID string) ([]*domain.APIKey, error) {
+	return s.repo.GetAPIKeys(tenantID)
+}
+
+// RevokeAPIKey revokes an API key of a tenant
+func (s *AuthService) RevokeAPIKey(tenantID, id string) error {
+	return s.repo.RevokeAPIKey(tenantID, id, time.Now())
+}
+
+// normalizeUsername makes usernames case-insensitive
+func normalizeUsername(username string) string {
+	return strings.ToLower(strings.TrimSpace(username))
+}
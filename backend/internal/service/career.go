@@ -0,0 +1,146 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+const (
+	// Default and maximum number of occupations returned per request
+	defaultCareerLimit = 10
+	maxCareerLimit     = 50
+	// Differences up to this many points count as a close match on a scale
+	closeMatchThreshold = 10
+)
+
+// CareerService ranks occupations against personality and interest results
+type CareerService struct {
+	repo    ResultStore
+	catalog *domain.OccupationCatalog
+}
+
+// NewCareerService creates a new career service
+func NewCareerService(repo ResultStore, catalog *domain.OccupationCatalog) *CareerService {
+	return &CareerService{
+		repo:    repo,
+		catalog: catalog,
+	}
+}
+
+// MatchCareers ranks the catalog against a result. If the same session also
+// has a result from another instrument (e.g. interests next to personality),
+// its scales are included in the comparison.
+func (s *CareerService) MatchCareers(tenant *domain.Tenant, resultID string, limit int) (*domain.GetCareersResponse, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+
+	result, err := s.repo.GetByID(tenant.ID, resultID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := make(map[domain.Trait]float64, len(result.Scores))
+	for trait, score := range result.Scores {
+		profile[trait] = score
+	}
+
+	if result.SessionID != "" {
+		sessionResults, err := s.repo.GetBySessionID(tenant.ID, result.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		// Session results are newest first, so the latest score per scale wins
+		for _, other := range sessionResults {
+			for trait, score := range other.Scores {
+				if _, exists := profile[trait]; !exists {
+					profile[trait] = score
+				}
+			}
+		}
+	}
+
+	return &domain.GetCareersResponse{
+		ResultID:       result.ID,
+		CatalogID:      s.catalog.ID,
+		CatalogVersion: s.catalog.Version,
+		Careers:        MatchOccupations(profile, s.catalog.Occupations, limit),
+	}, nil
+}
+
+// MatchOccupations ranks occupations by the root mean squared difference
+// between the profile and each occupation's targets on the scales both share.
+// Occupations without any shared scale are skipped.
+func MatchOccupations(profile map[domain.Trait]float64, occupations []*domain.Occupation, limit int) []domain.CareerMatch {
+	if limit <= 0 {
+		limit = defaultCareerLimit
+	}
+	limit = min(limit, maxCareerLimit)
+
+	var matches []domain.CareerMatch
+	for _, occupation := range occupations {
+		var traits []domain.TraitMatch
+		var sumSquares float64
+		for trait, target := range occupation.Profile() {
+			score, ok := profile[trait]
+			if !ok {
+				continue
+			}
+			difference := score - target
+			sumSquares += difference * difference
+			traits = append(traits, domain.TraitMatch{
+				Trait:       trait,
+				Score:       score,
+				Target:      target,
+				Difference:  difference,
+				Explanation: explainTraitMatch(trait, score, target),
+			})
+		}
+		if len(traits) == 0 {
+			continue
+		}
+
+		// Closest scales first: they are the reasons the occupation ranks well
+		sort.Slice(traits, func(i, j int) bool {
+			di, dj := math.Abs(traits[i].Difference), math.Abs(traits[j].Difference)
+			if di != dj {
+				return di < dj
+			}
+			return traits[i].Trait < traits[j].Trait
+		})
+
+		distance := math.Sqrt(sumSquares / float64(len(traits)))
+		matches = append(matches, domain.CareerMatch{
+			Occupation: occupation,
+			Match:      math.Round(math.Max(0, 100-distance)),
+			Distance:   math.Round(distance*10) / 10,
+			Traits:     traits,
+		})
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	if len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
+
+// explainTraitMatch describes how a score compares to an occupation's target
+func explainTraitMatch(trait domain.Trait, score, target float64) string {
+	name := domain.ScaleName(trait)
+	difference := score - target
+	switch {
+	case math.Abs(difference) <= closeMatchThreshold:
+		return fmt.Sprintf("Your %s (%.0f) is close to the typical profile (%.0f).", name, score, target)
+	case difference > 0:
+		return fmt.Sprintf("Your %s (%.0f) is higher than the typical profile (%.0f).", name, score, target)
+	default:
+		return fmt.Sprintf("Your %s (%.0f) is lower than the typical profile (%.0f).", name, score, target)
+	}
+}
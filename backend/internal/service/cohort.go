@@ -0,0 +1,293 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+var (
+	// ErrInvalidCohort is returned when an organization or cohort request is invalid
+	ErrInvalidCohort = errors.New("invalid cohort")
+	// ErrCohortInstrumentMismatch is returned when answers are submitted to a
+	// cohort that takes a different instrument
+	ErrCohortInstrumentMismatch = errors.New("cohort takes a different instrument")
+	// ErrNotCohortOwner is returned when a teacher accesses another teacher's cohort
+	ErrNotCohortOwner = errors.New("cohort belongs to another teacher")
+	// ErrNoCohort is returned when consent is changed for a result without a cohort
+	ErrNoCohort = errors.New("result was not submitted to a cohort")
+)
+
+// cohortDistributionBins is the number of equal-width bands in a cohort's
+// score distributions
+const cohortDistributionBins = 5
+
+// CohortService manages organizations, cohorts and anonymized cohort summaries
+type CohortService struct {
+	repo         *repository.CohortRepository
+	results      ResultStore
+	drafts       *repository.DraftRepository
+	instruments  *InstrumentRegistry
+	minGroupSize int
+}
+
+// NewCohortService creates a new cohort service. Summaries suppress score
+// distributions of cohorts with fewer than minGroupSize valid results.
+func NewCohortService(repo *repository.CohortRepository, results ResultStore, drafts *repository.DraftRepository, instruments *InstrumentRegistry, minGroupSize int) *CohortService {
+	return &CohortService{
+		repo:         repo,
+		results:      results,
+		drafts:       drafts,
+		instruments:  instruments,
+		minGroupSize: minGroupSize,
+	}
+}
+
+// CreateOrganization stores a new organization of a tenant
+func (s *CohortService) CreateOrganization(tenant *domain.Tenant, req domain.CreateOrganizationRequest) (*domain.Organization, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 200 {
+		return nil, fmt.Errorf("%w: name must be 1-200 characters", ErrInvalidCohort)
+	}
+
+	org := &domain.Organization{
+		ID:        uuid.New().String(),
+		TenantID:  tenant.ID,
+		Name:      name,
+		CreatedAt: time.Now(),
+	}
+	if err := s.repo.CreateOrganization(org); err != nil {
+		return nil, err
+	}
+	return org, nil
+}
+
+// GetOrganizations lists the organizations of a tenant
+func (s *CohortService) GetOrganizations(tenant *domain.Tenant) ([]*domain.Organization, error) {
+	return s.repo.GetOrganizations(tenant.ID)
+}
+
+// CreateCohort creates a cohort of a tenant owned by principal and assigns it a join code
+func (s *CohortService) CreateCohort(tenant *domain.Tenant, req domain.CreateCohortRequest, principal *domain.Principal) (*domain.Cohort, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" || len(name) > 200 {
+		return nil, fmt.Errorf("%w: name must be 1-200 characters", ErrInvalidCohort)
+	}
+	if _, err := s.repo.GetOrganization(tenant.ID, req.OrganizationID); err != nil {
+		if errors.Is(err, repository.ErrOrganizationNotFound) {
+			return nil, fmt.Errorf("%w: unknown organization %q", ErrInvalidCohort, req.OrganizationID)
+		}
+		return nil, err
+	}
+	instrument, err := tenantInstrument(s.instruments, tenant, req.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+
+	code, err := s.newJoinCode()
+	if err != nil {
+		return nil, err
+	}
+
+	cohort := &domain.Cohort{
+		ID:             uuid.New().String(),
+		TenantID:       tenant.ID,
+		OrganizationID: req.OrganizationID,
+		Name:           name,
+		InstrumentID:   instrument.ID,
+		JoinCode:       code,
+		CreatedBy:      principal.ID,
+		CreatedAt:      time.Now(),
+	}
+	if err := s.repo.Create(cohort); err != nil {
+		return nil, err
+	}
+	return cohort, nil
+}
+
+// newJoinCode generates a join code that is not in use yet
+func (s *CohortService) newJoinCode() (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := randomCode(resumeCodeAlphabet, resumeCodeLength)
+		if err != nil {
+			return "", err
+		}
+		exists, err := s.repo.JoinCodeExists(code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate a unique join code")
+}
+
+// GetCohorts lists the cohorts of a tenant a principal may see: teachers
+// their own, counselors and admins all
+func (s *CohortService) GetCohorts(tenant *domain.Tenant, principal *domain.Principal) ([]*domain.Cohort, error) {
+	createdBy := principal.ID
+	if principal.Role.Allows(domain.RoleCounselor) {
+		createdBy = ""
+	}
+	return s.repo.GetCohorts(tenant.ID, createdBy)
+}
+
+// Join describes the cohort of a join code to a respondent
+func (s *CohortService) Join(tenant *domain.Tenant, code string) (*domain.CohortInfo, error) {
+	cohort, err := s.repo.GetByJoinCode(tenant.ID, normalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	org, err := s.repo.GetOrganization(tenant.ID, cohort.OrganizationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &domain.CohortInfo{
+		Name:         cohort.Name,
+		Organization: org.Name,
+		InstrumentID: cohort.InstrumentID,
+	}, nil
+}
+
+// Resolve returns the cohort of a tenant's join code, checking that it takes
+// the instrument being answered. An empty instrumentID means the tenant's
+// default instrument.
+func (s *CohortService) Resolve(tenant *domain.Tenant, code, instrumentID string) (*domain.Cohort, error) {
+	cohort, err := s.repo.GetByJoinCode(tenant.ID, normalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	instrument, err := tenantInstrument(s.instruments, tenant, instrumentID)
+	if err != nil {
+		return nil, err
+	}
+	if cohort.InstrumentID != instrument.ID {
+		return nil, fmt.Errorf("%w: %s", ErrCohortInstrumentMismatch, cohort.InstrumentID)
+	}
+	return cohort, nil
+}
+
+// SetConsent changes whether the teacher of a result's cohort may see it
+func (s *CohortService) SetConsent(tenant *domain.Tenant, resultID string, consent bool) error {
+	result, err := s.results.GetByID(tenant.ID, resultID)
+	if err != nil {
+		return err
+	}
+	if result.CohortID == "" {
+		return ErrNoCohort
+	}
+	return s.results.SetCohortConsent(tenant.ID, resultID, consent)
+}
+
+// GetSummary reports completion counts and score distributions of a cohort,
+// and the individual results of respondents who consented. Teachers may only
+// summarize their own cohorts.
+func (s *CohortService) GetSummary(tenant *domain.Tenant, id string, principal *domain.Principal) (*domain.CohortSummary, error) {
+	cohort, err := s.repo.GetByID(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if cohort.CreatedBy != principal.ID && !principal.Role.Allows(domain.RoleCounselor) {
+		return nil, ErrNotCohortOwner
+	}
+
+	results, err := s.results.GetByCohortID(tenant.ID, cohort.ID)
+	if err != nil {
+		return nil, err
+	}
+	inProgress, err := s.drafts.CountInProgressByCohort(tenant.ID, cohort.ID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &domain.CohortSummary{
+		Cohort:       cohort,
+		MinGroupSize: s.minGroupSize,
+		Completed:    len(results),
+		InProgress:   inProgress,
+		Consented:    []*domain.CohortMember{},
+	}
+
+	var valid []*domain.PersonalityResult
+	for _, result := range results {
+		if result.Quality.Valid() {
+			valid = append(valid, result)
+		}
+		if !result.CohortConsent {
+			continue
+		}
+		interpretations, err := s.results.GetInterpretationsByResultID(tenant.ID, result.ID)
+		if err != nil {
+			return nil, err
+		}
+		summary.Consented = append(summary.Consented, &domain.CohortMember{
+			ResultID:        result.ID,
+			Scores:          result.Scores,
+			Interpretations: interpretations,
+			CreatedAt:       result.CreatedAt,
+		})
+	}
+
+	summary.Valid = len(valid)
+	summary.Suppressed = len(valid) < s.minGroupSize
+	if !summary.Suppressed {
+		instrument, err := s.instruments.Get(cohort.InstrumentID)
+		if err != nil {
+			return nil, err
+		}
+		summary.Distributions = scoreDistributions(instrument, valid)
+	}
+
+	return summary, nil
+}
+
+// scoreDistributions computes the mean, sample standard deviation and
+// banded counts of every scale of an instrument
+func scoreDistributions(instrument *domain.Instrument, results []*domain.PersonalityResult) map[domain.Trait]domain.ScoreDistribution {
+	distributions := make(map[domain.Trait]domain.ScoreDistribution, len(instrument.Scales))
+	for _, scale := range instrument.Scales {
+		var scores []float64
+		for _, result := range results {
+			if score, ok := result.Scores[scale.ID]; ok {
+				scores = append(scores, score)
+			}
+		}
+		if len(scores) == 0 {
+			continue
+		}
+
+		distribution := domain.ScoreDistribution{Bins: make([]int, cohortDistributionBins)}
+		sum := 0.0
+		for _, score := range scores {
+			sum += score
+			bin := int(score / (100.0 / cohortDistributionBins))
+			distribution.Bins[min(max(bin, 0), cohortDistributionBins-1)]++
+		}
+		distribution.Mean = sum / float64(len(scores))
+
+		if len(scores) > 1 {
+			squares := 0.0
+			for _, score := range scores {
+				squares += (score - distribution.Mean) * (score - distribution.Mean)
+			}
+			distribution.SD = math.Sqrt(squares / float64(len(scores)-1))
+		}
+
+		distributions[scale.ID] = distribution
+	}
+	return distributions
+}
+
+// normalizeCode makes resume and join codes case-insensitive and tolerant of
+// spaces and dashes
+func normalizeCode(code string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
@@ -0,0 +1,435 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+var (
+	// ErrUnknownConsentText is returned when a consent text key is not registered
+	ErrUnknownConsentText = errors.New("unknown consent text")
+	// ErrInvalidConsent is returned when a consent request is invalid
+	ErrInvalidConsent = errors.New("invalid consent")
+	// ErrConsentExists is returned when consent is granted for a session that
+	// already has consent in effect or awaiting its guardian
+	ErrConsentExists = errors.New("consent already recorded; withdraw it to change it")
+	// ErrConsentRequired is returned when data would be stored or sent to the
+	// language model without the respondent's consent
+	ErrConsentRequired = errors.New("consent required")
+	// ErrGuardianConsentPending is returned while a minor's consent awaits
+	// confirmation by a guardian
+	ErrGuardianConsentPending = errors.New("waiting for guardian consent")
+	// ErrGuardianCodeExpired is returned when a guardian confirms too late
+	ErrGuardianCodeExpired = errors.New("guardian code expired")
+	// ErrConsentWithdrawn is returned when a guardian acts on a withdrawn consent
+	ErrConsentWithdrawn = errors.New("consent was withdrawn")
+)
+
+// guardianCodeLength is the number of characters in a guardian code. Guardian
+// codes grant consent, so they are longer than resume codes.
+const guardianCodeLength = 12
+
+// ConsentRegistry holds the consent texts available in each language. Texts
+// are registered at startup; the registry is read-only afterwards.
+type ConsentRegistry struct {
+	texts   map[string]*domain.ConsentText
+	current map[string]*domain.ConsentText
+}
+
+// NewConsentRegistry creates a registry containing the given consent texts.
+// For each language, the last registered version is shown to respondents.
+// A text in the source language is required as the final fallback.
+func NewConsentRegistry(texts ...*domain.ConsentText) (*ConsentRegistry, error) {
+	r := &ConsentRegistry{
+		texts:   make(map[string]*domain.ConsentText),
+		current: make(map[string]*domain.ConsentText),
+	}
+	for _, text := range texts {
+		if err := r.register(text); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := r.current[domain.SourceLanguage]; !ok {
+		return nil, fmt.Errorf("consent texts must include the %q language", domain.SourceLanguage)
+	}
+	return r, nil
+}
+
+// register validates a consent text and makes it the current version for its language
+func (r *ConsentRegistry) register(text *domain.ConsentText) error {
+	if strings.TrimSpace(text.Title) == "" {
+		return fmt.Errorf("consent text %s: missing title", text.Key())
+	}
+	if strings.TrimSpace(text.Guardian) == "" {
+		return fmt.Errorf("consent text %s: missing guardian text", text.Key())
+	}
+	for _, purpose := range domain.ConsentPurposes {
+		if strings.TrimSpace(text.Purposes[purpose]) == "" {
+			return fmt.Errorf("consent text %s: missing purpose %s", text.Key(), purpose)
+		}
+	}
+
+	r.texts[text.Key()] = text
+	r.current[baseLanguage(text.Language)] = text
+	return nil
+}
+
+// Get returns the consent text with the given key (language@version)
+func (r *ConsentRegistry) Get(key string) (*domain.ConsentText, error) {
+	text, ok := r.texts[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownConsentText, key)
+	}
+	return text, nil
+}
+
+// Negotiate returns the current consent text for the first of the preferred
+// languages that has one, or else the source language text
+func (r *ConsentRegistry) Negotiate(languages []string) *domain.ConsentText {
+	for _, language := range languages {
+		if text, ok := r.current[baseLanguage(language)]; ok {
+			return text
+		}
+	}
+	return r.current[domain.SourceLanguage]
+}
+
+// ConsentService records what respondents consented to, checks it before
+// their data is stored or sent to the language model, and deletes data when
+// consent is withdrawn
+type ConsentService struct {
+	repo    *repository.ConsentRepository
+	results ResultStore
+	drafts  *repository.DraftRepository
+	shares  *repository.ShareRepository
+	texts   *ConsentRegistry
+	// required blocks storage and interpretations of sessions without a consent record
+	required    bool
+	guardianAge int
+	guardianTTL time.Duration
+}
+
+// NewConsentService creates a new consent service. Respondents younger than
+// guardianAge need a guardian to confirm their consent within guardianTTL.
+// Sessions with a consent record are always bound by it; sessions without one
+// are only blocked if required is set.
+func NewConsentService(repo *repository.ConsentRepository, results ResultStore, drafts *repository.DraftRepository, shares *repository.ShareRepository, texts *ConsentRegistry, required bool, guardianAge int, guardianTTL time.Duration) *ConsentService {
+	return &ConsentService{
+		repo:        repo,
+		results:     results,
+		drafts:      drafts,
+		shares:      shares,
+		texts:       texts,
+		required:    required,
+		guardianAge: guardianAge,
+		guardianTTL: guardianTTL,
+	}
+}
+
+// GetText returns the consent form for the first of the preferred languages
+// that has one, then for the tenant's default language
+func (s *ConsentService) GetText(tenant *domain.Tenant, languages []string) *domain.GetConsentTextResponse {
+	if tenant.DefaultLanguage != "" {
+		languages = append(languages[:len(languages):len(languages)], tenant.DefaultLanguage)
+	}
+	text := s.texts.Negotiate(languages)
+	return &domain.GetConsentTextResponse{
+		ConsentText:        *text,
+		Key:                text.Key(),
+		GuardianConsentAge: s.guardianConsentAge(tenant),
+	}
+}
+
+// guardianConsentAge returns the age below which a tenant's respondents need
+// a guardian to confirm their consent
+func (s *ConsentService) guardianConsentAge(tenant *domain.Tenant) int {
+	if tenant.GuardianConsentAge > 0 {
+		return tenant.GuardianConsentAge
+	}
+	return s.guardianAge
+}
+
+// Grant records the consent of a session's respondent. Respondents below the
+// guardian consent age get a guardian code, which is returned once; their
+// consent takes effect when a guardian confirms it.
+func (s *ConsentService) Grant(tenant *domain.Tenant, sessionID string, req domain.GrantConsentRequest) (*domain.ConsentRecord, error) {
+	purposes, err := parsePurposes(req.Purposes)
+	if err != nil {
+		return nil, err
+	}
+	if len(purposes) == 0 {
+		return nil, fmt.Errorf("%w: purposes are required", ErrInvalidConsent)
+	}
+	if !slices.Contains(purposes, domain.ConsentPurposeStorage) {
+		return nil, fmt.Errorf("%w: every consent includes %q", ErrInvalidConsent, domain.ConsentPurposeStorage)
+	}
+	if req.Age < 1 || req.Age > 120 {
+		return nil, fmt.Errorf("%w: age must be between 1 and 120", ErrInvalidConsent)
+	}
+
+	text := s.GetText(tenant, []string{req.Language}).Key
+	if req.Text != "" {
+		consentText, err := s.texts.Get(req.Text)
+		if err != nil {
+			return nil, err
+		}
+		text = consentText.Key()
+	}
+
+	now := time.Now()
+	latest, err := s.repo.GetLatest(tenant.ID, sessionID)
+	if err != nil && !errors.Is(err, repository.ErrConsentNotFound) {
+		return nil, err
+	}
+	if latest != nil {
+		if status := latest.StatusAt(now); status == domain.ConsentStatusActive || status == domain.ConsentStatusPendingGuardian {
+			return nil, ErrConsentExists
+		}
+	}
+
+	record := &domain.ConsentRecord{
+		ID:               uuid.New().String(),
+		TenantID:         tenant.ID,
+		SessionID:        sessionID,
+		Text:             text,
+		Purposes:         purposes,
+		Age:              req.Age,
+		GuardianRequired: req.Age < s.guardianConsentAge(tenant),
+		CreatedAt:        now,
+	}
+
+	var codeHash string
+	if record.GuardianRequired {
+		code, err := randomCode(resumeCodeAlphabet, guardianCodeLength)
+		if err != nil {
+			return nil, err
+		}
+		expiresAt := now.Add(s.guardianTTL)
+		record.GuardianCode = code
+		record.GuardianExpiresAt = &expiresAt
+		codeHash = hashToken(code)
+	}
+
+	if err := s.repo.Create(record, codeHash); err != nil {
+		return nil, err
+	}
+	record.Status = record.StatusAt(now)
+	return record, nil
+}
+
+// parsePurposes validates consent purposes and removes duplicates
+func parsePurposes(purposes []string) ([]string, error) {
+	var parsed []string
+	for _, purpose := range purposes {
+		if !slices.Contains(domain.ConsentPurposes, purpose) {
+			return nil, fmt.Errorf("%w: unknown purpose %q", ErrInvalidConsent, purpose)
+		}
+		if !slices.Contains(parsed, purpose) {
+			parsed = append(parsed, purpose)
+		}
+	}
+	return parsed, nil
+}
+
+// Get returns the most recent consent record of a session within a tenant
+func (s *ConsentService) Get(tenant *domain.Tenant, sessionID string) (*domain.ConsentRecord, error) {
+	record, err := s.repo.GetLatest(tenant.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	record.Status = record.StatusAt(time.Now())
+	return record, nil
+}
+
+// Check returns nil if a session's respondent consented to a purpose. It
+// returns ErrGuardianConsentPending while a guardian has yet to confirm and
+// ErrConsentRequired if consent is missing, withdrawn or expired. Sessions
+// without a consent record may only be stored, and only unless consent is
+// required: scores are never sent for interpretation without consent.
+func (s *ConsentService) Check(tenant *domain.Tenant, sessionID, purpose string) error {
+	record, err := s.repo.GetLatest(tenant.ID, sessionID)
+	if errors.Is(err, repository.ErrConsentNotFound) {
+		if s.required || purpose == domain.ConsentPurposeInterpretation {
+			return ErrConsentRequired
+		}
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	if record.Allows(purpose, now) {
+		return nil
+	}
+	if record.StatusAt(now) == domain.ConsentStatusPendingGuardian && slices.Contains(record.Purposes, purpose) {
+		return ErrGuardianConsentPending
+	}
+	return ErrConsentRequired
+}
+
+// Withdraw withdraws some or all purposes of a session's consent and deletes
+// the data they covered: withdrawing storage deletes the session's results
+// and drafts, withdrawing AI interpretation deletes the interpretations.
+func (s *ConsentService) Withdraw(tenant *domain.Tenant, sessionID string, req domain.WithdrawConsentRequest) (*domain.WithdrawConsentResponse, error) {
+	purposes, err := parsePurposes(req.Purposes)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.repo.GetLatest(tenant.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	return s.withdraw(tenant, record, purposes)
+}
+
+// withdraw withdraws purposes of a consent record, all of them if purposes
+// is empty or includes storage. The rest of the consent is kept in a new
+// record. Withdrawing an already withdrawn record deletes its data again, so
+// a withdrawal interrupted by an error can be retried.
+func (s *ConsentService) withdraw(tenant *domain.Tenant, record *domain.ConsentRecord, purposes []string) (*domain.WithdrawConsentResponse, error) {
+	if len(purposes) == 0 || slices.Contains(purposes, domain.ConsentPurposeStorage) {
+		purposes = domain.ConsentPurposes
+	}
+
+	now := time.Now()
+	response := &domain.WithdrawConsentResponse{Withdrawn: purposes}
+
+	var remaining []string
+	for _, purpose := range record.Purposes {
+		if !slices.Contains(purposes, purpose) {
+			remaining = append(remaining, purpose)
+		}
+	}
+
+	if record.WithdrawnAt == nil {
+		// The successor keeps the record's guardian code, so the guardian can
+		// still withdraw the remaining purposes
+		var successor *domain.ConsentRecord
+		if len(remaining) > 0 {
+			successor = &domain.ConsentRecord{
+				ID:                  uuid.New().String(),
+				TenantID:            record.TenantID,
+				SessionID:           record.SessionID,
+				Text:                record.Text,
+				Purposes:            remaining,
+				Age:                 record.Age,
+				GuardianRequired:    record.GuardianRequired,
+				GuardianExpiresAt:   record.GuardianExpiresAt,
+				GuardianConfirmedAt: record.GuardianConfirmedAt,
+				CreatedAt:           now,
+			}
+		}
+		// The record is withdrawn before data is deleted, so interpretations
+		// still being generated are not saved after the deletion
+		if err := s.repo.Withdraw(tenant.ID, record.ID, now, successor); err != nil {
+			return nil, err
+		}
+		if successor != nil {
+			successor.Status = successor.StatusAt(now)
+			response.Consent = successor
+		}
+	}
+
+	filter := domain.ResultFilter{SessionID: record.SessionID}
+	if slices.Contains(purposes, domain.ConsentPurposeStorage) {
+		deletion, err := s.results.DeleteResults(tenant.ID, filter)
+		if err != nil {
+			return nil, err
+		}
+		response.DeletedResults = deletion.Results
+		response.DeletedInterpretations = deletion.Interpretations
+		if _, err := s.shares.DeleteByResultIDs(tenant.ID, deletion.ResultIDs); err != nil {
+			return nil, err
+		}
+		if response.DeletedDrafts, err = s.drafts.DeleteBySessionID(tenant.ID, record.SessionID); err != nil {
+			return nil, err
+		}
+	} else if slices.Contains(purposes, domain.ConsentPurposeInterpretation) {
+		deletion, err := s.results.DeleteInterpretations(tenant.ID, filter)
+		if err != nil {
+			return nil, err
+		}
+		response.DeletedInterpretations = deletion.Interpretations
+	}
+
+	return response, nil
+}
+
+// GetGuardianConsent returns the consent a guardian code confirms, in the
+// wording the respondent was shown. The session is not disclosed.
+func (s *ConsentService) GetGuardianConsent(tenant *domain.Tenant, code string) (*domain.GuardianConsentResponse, error) {
+	record, err := s.guardianRecord(tenant, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.guardianResponse(record)
+}
+
+// ConfirmGuardian records a guardian's confirmation, putting a minor's consent into effect
+func (s *ConsentService) ConfirmGuardian(tenant *domain.Tenant, code string) (*domain.GuardianConsentResponse, error) {
+	record, err := s.guardianRecord(tenant, code)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	switch record.StatusAt(now) {
+	case domain.ConsentStatusWithdrawn:
+		return nil, ErrConsentWithdrawn
+	case domain.ConsentStatusExpired:
+		return nil, ErrGuardianCodeExpired
+	}
+
+	if record.GuardianConfirmedAt == nil {
+		if err := s.repo.ConfirmGuardian(tenant.ID, record.ID, now); err != nil {
+			return nil, err
+		}
+		record.GuardianConfirmedAt = &now
+	}
+	return s.guardianResponse(record)
+}
+
+// WithdrawGuardian withdraws a minor's consent on behalf of their guardian
+// and deletes the data it covered
+func (s *ConsentService) WithdrawGuardian(tenant *domain.Tenant, code string) (*domain.WithdrawConsentResponse, error) {
+	record, err := s.guardianRecord(tenant, code)
+	if err != nil {
+		return nil, err
+	}
+	return s.withdraw(tenant, record, nil)
+}
+
+// guardianRecord resolves a guardian code to the consent record it confirms:
+// the session's current record, which carries the code on through partial
+// withdrawals. A code no longer applies once the respondent gave consent anew.
+func (s *ConsentService) guardianRecord(tenant *domain.Tenant, code string) (*domain.ConsentRecord, error) {
+	record, current, err := s.repo.GetByGuardianCodeHash(tenant.ID, hashToken(normalizeCode(code)))
+	if err != nil {
+		return nil, err
+	}
+	if !current {
+		return nil, ErrConsentWithdrawn
+	}
+	return record, nil
+}
+
+// guardianResponse describes a consent record to a guardian
+func (s *ConsentService) guardianResponse(record *domain.ConsentRecord) (*domain.GuardianConsentResponse, error) {
+	text, err := s.texts.Get(record.Text)
+	if err != nil {
+		return nil, err
+	}
+
+	consent := *record
+	consent.SessionID = ""
+	consent.Status = consent.StatusAt(time.Now())
+	return &domain.GuardianConsentResponse{Consent: &consent, Text: text}, nil
+}
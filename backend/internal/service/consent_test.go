@@ -0,0 +1,77 @@
+package service
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+func TestConsentCheck(t *testing.T) {
+	db, err := repository.InitDB(filepath.Join(t.TempDir(), "voca.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	texts, err := NewConsentRegistry(&domain.ConsentText{
+		Language: domain.SourceLanguage,
+		Version:  "1",
+		Title:    "Consent",
+		Purposes: map[string]string{
+			domain.ConsentPurposeStorage:        "Store the results",
+			domain.ConsentPurposeInterpretation: "Interpret the results",
+		},
+		Guardian: "Confirm",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	tenant := &domain.Tenant{ID: "default"}
+
+	// newService returns a consent service with sessions that consented to
+	// storage, to both purposes and to both purposes pending a guardian
+	newService := func(required bool) *ConsentService {
+		s := NewConsentService(repository.NewConsentRepository(db), nil, nil, nil, texts, required, 16, time.Hour)
+		grants := map[string]domain.GrantConsentRequest{
+			"storage": {Purposes: []string{domain.ConsentPurposeStorage}, Age: 30},
+			"both":    {Purposes: domain.ConsentPurposes, Age: 30},
+			"minor":   {Purposes: domain.ConsentPurposes, Age: 12},
+		}
+		for session, grant := range grants {
+			if _, err := s.Grant(tenant, session, grant); err != nil && !errors.Is(err, ErrConsentExists) {
+				t.Fatal(err)
+			}
+		}
+		return s
+	}
+	optional, required := newService(false), newService(true)
+
+	tests := []struct {
+		name    string
+		service *ConsentService
+		session string
+		purpose string
+		wantErr error
+	}{
+		{"storage without record", optional, "none", domain.ConsentPurposeStorage, nil},
+		{"interpretation without record", optional, "none", domain.ConsentPurposeInterpretation, ErrConsentRequired},
+		{"required storage without record", required, "none", domain.ConsentPurposeStorage, ErrConsentRequired},
+		{"required interpretation without record", required, "none", domain.ConsentPurposeInterpretation, ErrConsentRequired},
+		{"storage consented", required, "storage", domain.ConsentPurposeStorage, nil},
+		{"interpretation not consented", optional, "storage", domain.ConsentPurposeInterpretation, ErrConsentRequired},
+		{"interpretation consented", required, "both", domain.ConsentPurposeInterpretation, nil},
+		{"pending guardian", optional, "minor", domain.ConsentPurposeStorage, ErrGuardianConsentPending},
+		{"interpretation pending guardian", optional, "minor", domain.ConsentPurposeInterpretation, ErrGuardianConsentPending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.service.Check(tenant, tt.session, tt.purpose); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Check(%s, %s) = %v, want %v", tt.session, tt.purpose, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,302 @@
+package service
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+var (
+	// ErrDraftExpired is returned when a draft session has passed its expiry
+	ErrDraftExpired = errors.New("draft session expired")
+	// ErrDraftCompleted is returned when a finalized draft session is modified
+	ErrDraftCompleted = errors.New("draft session already finalized")
+)
+
+// draftAbandonAfter is how long an unfinished draft may be idle before it
+// counts as abandoned in the statistics
+const draftAbandonAfter = 24 * time.Hour
+
+// resumeCodeAlphabet omits characters that are easily confused (0/O, 1/I/L)
+const resumeCodeAlphabet = "ABCDEFGHJKMNPQRSTUVWXYZ23456789"
+
+// resumeCodeLength is the number of characters in a resume code
+const resumeCodeLength = 8
+
+// DraftService manages resumable, not yet submitted questionnaires
+type DraftService struct {
+	repo        *repository.DraftRepository
+	personality *PersonalityService
+	instruments *InstrumentRegistry
+	ttl         time.Duration
+}
+
+// NewDraftService creates a new draft service. Drafts expire ttl after their
+// last change.
+func NewDraftService(repo *repository.DraftRepository, personality *PersonalityService, instruments *InstrumentRegistry, ttl time.Duration) *DraftService {
+	return &DraftService{
+		repo:        repo,
+		personality: personality,
+		instruments: instruments,
+		ttl:         ttl,
+	}
+}
+
+// CreateDraft starts a new draft session of a tenant for a verified session
+// whose respondent consented to storing their answers
+func (s *DraftService) CreateDraft(tenant *domain.Tenant, sessionID string, req domain.CreateDraftSessionRequest) (*domain.DraftSession, error) {
+	instrument, err := tenantInstrument(s.instruments, tenant, req.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.personality.checkConsent(tenant, sessionID, domain.ConsentPurposeStorage); err != nil {
+		return nil, err
+	}
+
+	code, err := s.newResumeCode()
+	if err != nil {
+		return nil, err
+	}
+
+	// Draft timestamps are kept in UTC so expiry checks survive the round trip
+	now := time.Now().UTC()
+	draft := &domain.DraftSession{
+		ID:            uuid.New().String(),
+		TenantID:      tenant.ID,
+		ResumeCode:    code,
+		SessionID:     sessionID,
+		InstrumentID:  instrument.ID,
+		Language:      req.Language,
+		Translation:   req.Translation,
+		Age:           req.Age,
+		Country:       req.Country,
+		CohortID:      req.CohortID,
+		CohortConsent: req.CohortConsent,
+		Status:        domain.DraftStatusInProgress,
+		Answers:       []domain.Answer{},
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ExpiresAt:     now.Add(s.ttl),
+	}
+	if err := s.repo.Create(draft); err != nil {
+		return nil, err
+	}
+
+	draft.Progress = draftProgress(draft, instrument)
+	return draft, nil
+}
+
+// newResumeCode generates a resume code that is not in use yet
+func (s *DraftService) newResumeCode() (string, error) {
+	for attempt := 0; attempt < 5; attempt++ {
+		code, err := randomCode(resumeCodeAlphabet, resumeCodeLength)
+		if err != nil {
+			return "", err
+		}
+		exists, err := s.repo.ResumeCodeExists(code)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return code, nil
+		}
+	}
+	return "", fmt.Errorf("could not generate a unique resume code")
+}
+
+// randomCode returns a random string of length n drawn uniformly from alphabet
+func randomCode(alphabet string, n int) (string, error) {
+	code := make([]byte, n)
+	size := big.NewInt(int64(len(alphabet)))
+	for i := range code {
+		index, err := rand.Int(rand.Reader, size)
+		if err != nil {
+			return "", err
+		}
+		code[i] = alphabet[index.Int64()]
+	}
+	return string(code), nil
+}
+
+// GetDraft retrieves a draft session of a tenant with its answers and progress
+func (s *DraftService) GetDraft(tenant *domain.Tenant, id string) (*domain.DraftSession, error) {
+	draft, err := s.repo.GetByID(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	return s.describeDraft(draft)
+}
+
+// ResumeDraft retrieves a draft session of a tenant by its resume code.
+// Codes are case-insensitive and may contain spaces or dashes.
+func (s *DraftService) ResumeDraft(tenant *domain.Tenant, code string) (*domain.DraftSession, error) {
+	draft, err := s.repo.GetByResumeCode(tenant.ID, normalizeCode(code))
+	if err != nil {
+		return nil, err
+	}
+	return s.describeDraft(draft)
+}
+
+// describeDraft rejects expired drafts and fills in their progress
+func (s *DraftService) describeDraft(draft *domain.DraftSession) (*domain.DraftSession, error) {
+	if draftExpired(draft, time.Now().UTC()) {
+		return nil, ErrDraftExpired
+	}
+	instrument, err := s.instruments.Get(draft.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+	draft.Progress = draftProgress(draft, instrument)
+	return draft, nil
+}
+
+// SaveAnswers stores partial answers of a tenant's draft session and extends its expiry
+func (s *DraftService) SaveAnswers(tenant *domain.Tenant, id string, answers []domain.Answer) (*domain.DraftSession, error) {
+	draft, err := s.GetDraft(tenant, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == domain.DraftStatusCompleted {
+		return nil, ErrDraftCompleted
+	}
+
+	instrument, err := s.instruments.Get(draft.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+	if err := ValidatePartialAnswers(answers, instrument); err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if err := s.repo.SaveAnswers(tenant.ID, id, answers, now, now.Add(s.ttl)); err != nil {
+		return nil, err
+	}
+
+	return s.GetDraft(tenant, id)
+}
+
+// Finalize scores a tenant's draft session like a regular submission and marks it completed
+func (s *DraftService) Finalize(tenant *domain.Tenant, id string) (*domain.PersonalityResult, error) {
+	draft, err := s.GetDraft(tenant, id)
+	if err != nil {
+		return nil, err
+	}
+	if draft.Status == domain.DraftStatusCompleted {
+		return nil, ErrDraftCompleted
+	}
+
+	completedAt := time.Now().UTC()
+	result, err := s.personality.CalculateResults(tenant, domain.SubmitAnswersRequest{
+		SessionID:     draft.SessionID,
+		InstrumentID:  draft.InstrumentID,
+		Answers:       draft.Answers,
+		Language:      draft.Language,
+		Translation:   draft.Translation,
+		Age:           draft.Age,
+		Country:       draft.Country,
+		CohortID:      draft.CohortID,
+		CohortConsent: draft.CohortConsent,
+		StartedAt:     &draft.CreatedAt,
+		CompletedAt:   &completedAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Complete(tenant.ID, id, result.ID, completedAt); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetStats reports per instrument how many drafts of a tenant were completed
+// or abandoned, and after how many answers abandoned drafts stopped
+func (s *DraftService) GetStats(tenant *domain.Tenant) (*domain.GetDraftStatsResponse, error) {
+	summaries, err := s.repo.Summaries(tenant.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	byInstrument := make(map[string]*domain.DraftSessionStats)
+	dropOffs := make(map[string]map[int]int)
+	var order []string
+	for _, summary := range summaries {
+		stats, ok := byInstrument[summary.InstrumentID]
+		if !ok {
+			stats = &domain.DraftSessionStats{InstrumentID: summary.InstrumentID}
+			byInstrument[summary.InstrumentID] = stats
+			dropOffs[summary.InstrumentID] = make(map[int]int)
+			order = append(order, summary.InstrumentID)
+		}
+
+		stats.Total++
+		switch {
+		case summary.Status == domain.DraftStatusCompleted:
+			stats.Completed++
+		case now.Sub(summary.UpdatedAt) > draftAbandonAfter || now.After(summary.ExpiresAt):
+			stats.Abandoned++
+			dropOffs[summary.InstrumentID][summary.Answered]++
+		default:
+			stats.InProgress++
+		}
+	}
+
+	response := &domain.GetDraftStatsResponse{
+		AbandonAfterHours: draftAbandonAfter.Hours(),
+		Instruments:       []domain.DraftSessionStats{},
+	}
+	for _, instrumentID := range order {
+		stats := byInstrument[instrumentID]
+		if finished := stats.Completed + stats.Abandoned; finished > 0 {
+			stats.CompletionRate = math.Round(float64(stats.Completed)/float64(finished)*1000) / 1000
+		}
+
+		stats.DropOffs = []domain.DraftDropOff{}
+		for answered, count := range dropOffs[instrumentID] {
+			stats.DropOffs = append(stats.DropOffs, domain.DraftDropOff{Answered: answered, Count: count})
+		}
+		sort.Slice(stats.DropOffs, func(i, j int) bool {
+			return stats.DropOffs[i].Answered < stats.DropOffs[j].Answered
+		})
+
+		response.Instruments = append(response.Instruments, *stats)
+	}
+
+	return response, nil
+}
+
+// draftExpired reports whether an unfinished draft has passed its expiry
+func draftExpired(draft *domain.DraftSession, now time.Time) bool {
+	return draft.Status != domain.DraftStatusCompleted && now.After(draft.ExpiresAt)
+}
+
+// draftProgress computes how many items of the instrument a draft has answered
+func draftProgress(draft *domain.DraftSession, instrument *domain.Instrument) domain.DraftProgress {
+	answered := make(map[int]bool, len(draft.Answers))
+	for _, answer := range draft.Answers {
+		answered[answer.QuestionID] = true
+	}
+
+	progress := domain.DraftProgress{Total: instrument.ItemCount()}
+	for _, item := range instrument.Items {
+		if answered[item.ID] {
+			progress.Answered++
+		} else if progress.NextQuestionID == 0 {
+			progress.NextQuestionID = item.ID
+		}
+	}
+	if progress.Total > 0 {
+		progress.Percent = math.Round(float64(progress.Answered)/float64(progress.Total)*1000) / 10
+	}
+	return progress
+}
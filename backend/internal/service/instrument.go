@@ -0,0 +1,60 @@
+package service
+
+import (
+	"errors"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrUnknownInstrument is returned when an instrument is not registered
+var ErrUnknownInstrument = errors.New("unknown instrument")
+
+// InstrumentRegistry holds the instruments that can be administered.
+// Instruments are registered at startup; the registry is read-only afterwards.
+type InstrumentRegistry struct {
+	instruments map[string]*domain.Instrument
+	order       []string
+}
+
+// NewInstrumentRegistry creates a registry containing the given instruments
+func NewInstrumentRegistry(instruments ...*domain.Instrument) *InstrumentRegistry {
+	r := &InstrumentRegistry{instruments: make(map[string]*domain.Instrument)}
+	for _, instrument := range instruments {
+		r.Register(instrument)
+	}
+	return r
+}
+
+// NewDefaultInstrumentRegistry creates a registry with all built-in instruments
+func NewDefaultInstrumentRegistry() *InstrumentRegistry {
+	return NewInstrumentRegistry(domain.BuiltinInstruments()...)
+}
+
+// Register adds an instrument, replacing any instrument with the same ID
+func (r *InstrumentRegistry) Register(instrument *domain.Instrument) {
+	if _, exists := r.instruments[instrument.ID]; !exists {
+		r.order = append(r.order, instrument.ID)
+	}
+	r.instruments[instrument.ID] = instrument
+}
+
+// Get returns the instrument with the given ID, or the default instrument if id is empty
+func (r *InstrumentRegistry) Get(id string) (*domain.Instrument, error) {
+	if id == "" {
+		id = domain.DefaultInstrumentID
+	}
+	instrument, exists := r.instruments[id]
+	if !exists {
+		return nil, ErrUnknownInstrument
+	}
+	return instrument, nil
+}
+
+// List returns all registered instruments in registration order
+func (r *InstrumentRegistry) List() []*domain.Instrument {
+	instruments := make([]*domain.Instrument, 0, len(r.order))
+	for _, id := range r.order {
+		instruments = append(instruments, r.instruments[id])
+	}
+	return instruments
+}
@@ -0,0 +1,107 @@
+package service
+
+import (
+	"math"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// NormRegistry holds the norm sets available for scoring. Sets are
+// registered at startup; the registry is read-only afterwards.
+type NormRegistry struct {
+	sets     map[string]*domain.NormSet
+	defaults map[string]*domain.NormSet
+}
+
+// NewNormRegistry creates a registry containing the given norm sets. For each
+// instrument, the last registered set becomes the default for new results.
+func NewNormRegistry(sets ...*domain.NormSet) *NormRegistry {
+	r := &NormRegistry{
+		sets:     make(map[string]*domain.NormSet),
+		defaults: make(map[string]*domain.NormSet),
+	}
+	for _, set := range sets {
+		r.Register(set)
+	}
+	return r
+}
+
+// Register adds a norm set and makes it the default for its instrument
+func (r *NormRegistry) Register(set *domain.NormSet) {
+	r.sets[set.Key()] = set
+	r.defaults[set.InstrumentID] = set
+}
+
+// Get returns the norm set with the given key (id@version), or nil
+func (r *NormRegistry) Get(key string) *domain.NormSet {
+	return r.sets[key]
+}
+
+// Default returns the norm set used for new results of an instrument, or nil
+func (r *NormRegistry) Default(instrumentID string) *domain.NormSet {
+	return r.defaults[instrumentID]
+}
+
+// ApplyNorms fills in the norm group and per-scale norm scores of a result
+// from the given set. Scales without a reference distribution are skipped.
+func ApplyNorms(result *domain.PersonalityResult, set *domain.NormSet) {
+	group := selectNormGroup(set, result.Age, result.Language, result.Country)
+	if group == nil {
+		return
+	}
+
+	result.NormSetID = set.Key()
+	result.NormGroup = group.Label
+	result.Norms = make(map[domain.Trait]domain.NormScore)
+	for trait, score := range result.Scores {
+		norm, ok := group.Scales[trait]
+		if !ok {
+			continue
+		}
+		result.Norms[trait] = calculateNormScore(score, norm)
+	}
+}
+
+// selectNormGroup returns the most specific group that applies to the respondent
+func selectNormGroup(set *domain.NormSet, age int, language, country string) *domain.NormGroup {
+	var best *domain.NormGroup
+	bestSpecificity := -1
+	for i := range set.Groups {
+		ok, specificity := set.Groups[i].Match(age, language, country)
+		if ok && specificity > bestSpecificity {
+			best = &set.Groups[i]
+			bestSpecificity = specificity
+		}
+	}
+	return best
+}
+
+// calculateNormScore converts a 0-100 score into a percentile, T-score and band
+// assuming a normal distribution within the norm group
+func calculateNormScore(score float64, norm domain.ScaleNorm) domain.NormScore {
+	z := (score - norm.Mean) / norm.SD
+	percentile := 50 * (1 + math.Erf(z/math.Sqrt2))
+	tScore := 50 + 10*z
+
+	return domain.NormScore{
+		Percentile: math.Round(math.Min(math.Max(percentile, 1), 99)),
+		TScore:     math.Round(tScore),
+		Band:       normBand(tScore),
+	}
+}
+
+// normBand labels a T-score in bands of one standard deviation around the mean
+func normBand(tScore float64) string {
+	switch {
+	case tScore < 35:
+		return domain.NormBandVeryLow
+	case tScore < 45:
+		return domain.NormBandLow
+	case tScore <= 55:
+		return domain.NormBandAverage
+	case tScore <= 65:
+		return domain.NormBandHigh
+	default:
+		return domain.NormBandVeryHigh
+	}
+}
@@ -0,0 +1,196 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"regexp"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+var (
+	// ErrInsufficientSample is returned when a norm snapshot has too few results to compare against
+	ErrInsufficientSample = errors.New("norm group too small")
+	// ErrInstrumentMismatch is returned when a result and a norm snapshot use different instruments
+	ErrInstrumentMismatch = errors.New("result and norm use different instruments")
+	// ErrInvalidSnapshot is returned when a snapshot definition is invalid
+	ErrInvalidSnapshot = errors.New("invalid norm snapshot")
+)
+
+// snapshotNamePattern restricts snapshot names to URL-friendly identifiers
+var snapshotNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// NormSnapshotService computes empirical norms from stored results
+type NormSnapshotService struct {
+	results       ResultStore
+	snapshots     *repository.NormSnapshotRepository
+	instruments   *InstrumentRegistry
+	minSampleSize int
+}
+
+// NewNormSnapshotService creates a new norm snapshot service. Snapshots with
+// fewer than minSampleSize results never expose their distribution.
+func NewNormSnapshotService(results ResultStore, snapshots *repository.NormSnapshotRepository, instruments *InstrumentRegistry, minSampleSize int) *NormSnapshotService {
+	return &NormSnapshotService{
+		results:       results,
+		snapshots:     snapshots,
+		instruments:   instruments,
+		minSampleSize: minSampleSize,
+	}
+}
+
+// CreateSnapshot defines a named snapshot of a tenant's results and computes
+// it immediately. An existing snapshot with the same name is redefined.
+func (s *NormSnapshotService) CreateSnapshot(tenant *domain.Tenant, req domain.CreateNormSnapshotRequest) (*domain.NormSnapshot, error) {
+	if !snapshotNamePattern.MatchString(req.Name) {
+		return nil, fmt.Errorf("%w: name must be lowercase letters, digits, '.', '_' or '-'", ErrInvalidSnapshot)
+	}
+	instrument, err := s.instruments.Get(req.InstrumentID)
+	if err != nil {
+		return nil, err
+	}
+	if req.AgeMin < 0 || req.AgeMax < 0 || (req.AgeMax > 0 && req.AgeMax < req.AgeMin) {
+		return nil, fmt.Errorf("%w: invalid age range", ErrInvalidSnapshot)
+	}
+	if req.From != nil && req.To != nil && !req.To.After(*req.From) {
+		return nil, fmt.Errorf("%w: to must be after from", ErrInvalidSnapshot)
+	}
+
+	filter := req.NormSnapshotFilter
+	filter.InstrumentID = instrument.ID
+
+	snapshot := &domain.NormSnapshot{
+		TenantID:  tenant.ID,
+		Name:      req.Name,
+		Filter:    filter,
+		CreatedAt: time.Now(),
+	}
+	if existing, err := s.snapshots.GetByName(tenant.ID, req.Name); err == nil {
+		snapshot.CreatedAt = existing.CreatedAt
+	}
+
+	if err := s.compute(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// GetSnapshots returns the norm snapshots of a tenant
+func (s *NormSnapshotService) GetSnapshots(tenant *domain.Tenant) ([]*domain.NormSnapshot, error) {
+	return s.snapshots.GetAll(tenant.ID)
+}
+
+// RefreshSnapshot recomputes a snapshot of a tenant from its current results
+func (s *NormSnapshotService) RefreshSnapshot(tenant *domain.Tenant, name string) (*domain.NormSnapshot, error) {
+	snapshot, err := s.snapshots.GetByName(tenant.ID, name)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.compute(snapshot); err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// RefreshAll recomputes every snapshot of every tenant, each from its own
+// tenant's results, and returns the number refreshed
+func (s *NormSnapshotService) RefreshAll() (int, error) {
+	snapshots, err := s.snapshots.GetAllTenants()
+	if err != nil {
+		return 0, err
+	}
+
+	refreshed := 0
+	for _, snapshot := range snapshots {
+		if err := s.compute(snapshot); err != nil {
+			log.Printf("Warning: Failed to refresh norm snapshot %s/%s: %v", snapshot.TenantID, snapshot.Name, err)
+			continue
+		}
+		refreshed++
+	}
+	return refreshed, nil
+}
+
+// RunPeriodicRefresh recomputes all snapshots every interval until ctx is done
+func (s *NormSnapshotService) RunPeriodicRefresh(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			refreshed, err := s.RefreshAll()
+			if err != nil {
+				log.Printf("Warning: Failed to refresh norm snapshots: %v", err)
+				continue
+			}
+			log.Printf("Refreshed %d norm snapshots", refreshed)
+		}
+	}
+}
+
+// compute fills in a snapshot's distribution from the results of its tenant
+// and stores it. The distribution is dropped when the sample is too small, so
+// small groups never expose scores.
+func (s *NormSnapshotService) compute(snapshot *domain.NormSnapshot) error {
+	n, scales, err := s.results.ScoreDistribution(snapshot.TenantID, snapshot.Filter)
+	if err != nil {
+		return err
+	}
+
+	snapshot.N = n
+	snapshot.Scales = scales
+	if n < s.minSampleSize {
+		snapshot.Scales = nil
+	}
+	snapshot.ComputedAt = time.Now()
+
+	return s.snapshots.Save(snapshot)
+}
+
+// Compare expresses a result's scores relative to a norm snapshot of the same tenant
+func (s *NormSnapshotService) Compare(tenant *domain.Tenant, resultID string, name string) (*domain.CompareResponse, error) {
+	result, err := s.results.GetByID(tenant.ID, resultID)
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := s.snapshots.GetByName(tenant.ID, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if snapshot.Filter.InstrumentID != result.InstrumentID {
+		return nil, ErrInstrumentMismatch
+	}
+	if snapshot.N < s.minSampleSize || len(snapshot.Scales) == 0 {
+		return nil, ErrInsufficientSample
+	}
+
+	response := &domain.CompareResponse{
+		ResultID:   result.ID,
+		Norm:       snapshot.Name,
+		N:          snapshot.N,
+		ComputedAt: snapshot.ComputedAt,
+		Scores:     make(map[domain.Trait]domain.ScoreComparison),
+	}
+	for trait, score := range result.Scores {
+		norm, ok := snapshot.Scales[trait]
+		if !ok || norm.SD <= 0 {
+			continue
+		}
+		response.Scores[trait] = domain.ScoreComparison{
+			Score:     score,
+			Mean:      norm.Mean,
+			SD:        norm.SD,
+			NormScore: calculateNormScore(score, norm),
+		}
+	}
+
+	return response, nil
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
@@ -15,6 +16,9 @@ import (
 )
 
 const (
+	// Chat model and sampling temperature used for interpretations
+	interpretationModel       = "gpt-4o-mini"
+	interpretationTemperature = 1.0
 	// Timeout for individual API calls
 	apiCallTimeout = 60 * time.Second
 	// Maximum tokens for interpretation (keeps responses concise and fast)
@@ -27,6 +31,10 @@ const (
 	maxConcurrentCalls = 5
 )
 
+// ErrIncompleteInterpretations is returned along with the interpretations
+// that were generated when the others failed
+var ErrIncompleteInterpretations = errors.New("interpretations could not be generated for every trait")
+
 // OpenAIService handles AI-powered interpretation generation
 type OpenAIService struct {
 	client *openai.Client
@@ -63,14 +71,33 @@ func NewOpenAIService(apiKey string) *OpenAIService {
 
 // GenerateInterpretation creates an AI interpretation for a specific trait and score
 // Includes retry logic with exponential backoff
-func (s *OpenAIService) GenerateInterpretation(ctx context.Context, trait domain.Trait, score float64, language string) (string, error) {
+func (s *OpenAIService) GenerateInterpretation(ctx context.Context, trait domain.Trait, score float64, facets []domain.FacetScore, language string, branding string) (*domain.TraitInterpretation, error) {
 	if s == nil || s.client == nil {
-		return "", fmt.Errorf("OpenAI service not configured")
+		return nil, fmt.Errorf("OpenAI service not configured")
 	}
 
-	systemPrompt := GetSystemPrompt(language)
-	prompt := BuildInterpretationPrompt(trait, score, language)
+	systemPrompt := BrandSystemPrompt(GetSystemPrompt(language), branding)
+	prompt := BuildInterpretationPrompt(trait, score, facets, language)
+
+	return s.complete(ctx, trait, systemPrompt, prompt, language)
+}
+
+// GenerateInterestInterpretation creates an AI interpretation of a RIASEC interest profile
+func (s *OpenAIService) GenerateInterestInterpretation(ctx context.Context, scores map[domain.Trait]float64, hollandCode string, language string, branding string) (*domain.TraitInterpretation, error) {
+	if s == nil || s.client == nil {
+		return nil, fmt.Errorf("OpenAI service not configured")
+	}
+
+	systemPrompt := BrandSystemPrompt(GetSystemPrompt(language), branding)
+	prompt := BuildInterestPrompt(scores, hollandCode, language)
+
+	return s.complete(ctx, domain.TraitInterests, systemPrompt, prompt, language)
+}
 
+// complete sends a prompt to the chat completion API and returns the
+// interpretation of a trait with the model, prompt version and tokens used
+// Includes retry logic with exponential backoff
+func (s *OpenAIService) complete(ctx context.Context, trait domain.Trait, systemPrompt string, prompt string, language string) (*domain.TraitInterpretation, error) {
 	var lastErr error
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
@@ -80,7 +107,7 @@ func (s *OpenAIService) GenerateInterpretation(ctx context.Context, trait domain
 			
 			select {
 			case <-ctx.Done():
-				return "", ctx.Err()
+				return nil, ctx.Err()
 			case <-time.After(delay):
 			}
 		}
@@ -91,7 +118,8 @@ func (s *OpenAIService) GenerateInterpretation(ctx context.Context, trait domain
 		resp, err := s.client.CreateChatCompletion(
 			callCtx,
 			openai.ChatCompletionRequest{
-				Model: "gpt-4o-mini",
+				Model:       interpretationModel,
+				Temperature: interpretationTemperature,
 				Messages: []openai.ChatCompletionMessage{
 					{
 						Role:    openai.ChatMessageRoleSystem,
@@ -122,19 +150,47 @@ func (s *OpenAIService) GenerateInterpretation(ctx context.Context, trait domain
 		log.Printf("OpenAI response for %s (lang=%s): finish_reason=%s, content_length=%d, attempt=%d",
 			trait, language, resp.Choices[0].FinishReason, len(content), attempt+1)
 
-		return content, nil
+		// The response names the model snapshot that answered
+		model := resp.Model
+		if model == "" {
+			model = interpretationModel
+		}
+
+		return &domain.TraitInterpretation{
+			ID:               uuid.New().String(),
+			Trait:            trait,
+			Interpretation:   content,
+			Language:         language,
+			Model:            model,
+			PromptVersion:    PromptVersion,
+			Temperature:      interpretationTemperature,
+			PromptTokens:     resp.Usage.PromptTokens,
+			CompletionTokens: resp.Usage.CompletionTokens,
+			GeneratedAt:      time.Now(),
+		}, nil
 	}
 
-	return "", fmt.Errorf("failed to generate interpretation after %d attempts: %w", maxRetries, lastErr)
+	return nil, fmt.Errorf("failed to generate interpretation after %d attempts: %w", maxRetries, lastErr)
 }
 
 // GenerateAllInterpretations generates interpretations for all traits in a result
 // Uses bounded parallelism and saves partial results on failure
-func (s *OpenAIService) GenerateAllInterpretations(ctx context.Context, result *domain.PersonalityResult, language string) ([]*domain.TraitInterpretation, error) {
+// branding is added to the system prompt; it may be empty
+func (s *OpenAIService) GenerateAllInterpretations(ctx context.Context, result *domain.PersonalityResult, language string, branding string) ([]*domain.TraitInterpretation, error) {
 	if s == nil || s.client == nil {
 		return nil, fmt.Errorf("OpenAI service not configured")
 	}
 
+	// Interest profiles get a single interpretation of the whole Holland code
+	if result.HollandCode != "" {
+		interpretation, err := s.GenerateInterestInterpretation(ctx, result.Scores, result.HollandCode, language, branding)
+		if err != nil {
+			return nil, err
+		}
+		interpretation.ResultID = result.ID
+		return []*domain.TraitInterpretation{interpretation}, nil
+	}
+
 	traits := []struct {
 		trait domain.Trait
 		score float64
@@ -170,20 +226,15 @@ func (s *OpenAIService) GenerateAllInterpretations(ctx context.Context, result *
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
 
-			interpretation, err := s.GenerateInterpretation(ctx, trait, score, language)
+			interpretation, err := s.GenerateInterpretation(ctx, trait, score, result.FacetsOf(trait), language, branding)
 			if err != nil {
 				errors[idx] = err
 				log.Printf("Failed to generate interpretation for %s: %v", trait, err)
 				return
 			}
 
-			interpretations[idx] = &domain.TraitInterpretation{
-				ID:             uuid.New().String(),
-				ResultID:       result.ID,
-				Trait:          trait,
-				Interpretation: interpretation,
-				CreatedAt:      time.Now(),
-			}
+			interpretation.ResultID = result.ID
+			interpretations[idx] = interpretation
 		}(i, t.trait, t.score)
 	}
 
@@ -206,8 +257,12 @@ func (s *OpenAIService) GenerateAllInterpretations(ctx context.Context, result *
 		log.Printf("Failed traits: %v", failedTraits)
 	}
 
-	// Return partial results if we have any
+	// Return partial results if we have any, with an error so that callers
+	// replacing existing interpretations can keep them instead
 	if len(successful) > 0 {
+		if len(failedTraits) > 0 {
+			return successful, fmt.Errorf("%w: %v failed", ErrIncompleteInterpretations, failedTraits)
+		}
 		return successful, nil
 	}
 
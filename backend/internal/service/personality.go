@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"math"
@@ -9,103 +10,299 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/thielel/voca/internal/domain"
-	"github.com/thielel/voca/internal/repository"
 )
 
 // PersonalityService handles personality test business logic
 type PersonalityService struct {
-	repo      *repository.ResultRepository
-	openaiSvc *OpenAIService
+	repo         ResultStore
+	openaiSvc    *OpenAIService
+	instruments  *InstrumentRegistry
+	norms        *NormRegistry
+	translations *TranslationRegistry
+	consents     *ConsentService
+	// suppressInvalid skips AI interpretations for protocols flagged invalid
+	suppressInvalid bool
 }
 
 // NewPersonalityService creates a new personality service
-func NewPersonalityService(repo *repository.ResultRepository, openaiSvc *OpenAIService) *PersonalityService {
+func NewPersonalityService(repo ResultStore, openaiSvc *OpenAIService, instruments *InstrumentRegistry, norms *NormRegistry, translations *TranslationRegistry, consents *ConsentService, suppressInvalid bool) *PersonalityService {
 	return &PersonalityService{
-		repo:      repo,
-		openaiSvc: openaiSvc,
+		repo:            repo,
+		openaiSvc:       openaiSvc,
+		instruments:     instruments,
+		norms:           norms,
+		translations:    translations,
+		consents:        consents,
+		suppressInvalid: suppressInvalid,
 	}
 }
 
-// GetQuestions returns all questionnaire items
-func (s *PersonalityService) GetQuestions() []domain.Question {
-	return domain.GetQuestions()
+// ErrNotOwner is returned when a session accesses a result of another session
+var ErrNotOwner = errors.New("result belongs to another session")
+
+// ErrInvalidProtocol is returned when interpretations are suppressed for a
+// result flagged for careless responding
+var ErrInvalidProtocol = errors.New("responses were flagged as careless; no interpretation is generated")
+
+var (
+	// ErrInvalidFilter is returned when a result listing is filtered by an
+	// invalid value or by a scale none of the tenant's instruments has
+	ErrInvalidFilter = errors.New("invalid filter")
+	// ErrInvalidSort is returned when a result listing is sorted by an
+	// invalid order or by a scale none of the tenant's instruments has
+	ErrInvalidSort = errors.New("invalid sort")
+)
+
+// GetQuestions returns all items of a tenant's default instrument in the
+// first of the preferred languages that has a translation
+func (s *PersonalityService) GetQuestions(tenant *domain.Tenant, languages []string) domain.GetQuestionsResponse {
+	response, err := s.GetInstrumentQuestions(tenant, "", languages)
+	if err != nil {
+		return domain.GetQuestionsResponse{Questions: domain.GetQuestions(), Language: domain.SourceLanguage}
+	}
+	return domain.GetQuestionsResponse{
+		Questions:   response.Questions,
+		Language:    response.Language,
+		Translation: response.Translation,
+		Options:     response.Options,
+	}
 }
 
-// CalculateResults processes answers and calculates personality scores
-// Interpretations are generated in the background and won't be included in the returned result
-func (s *PersonalityService) CalculateResults(sessionID string, answers []domain.Answer, language string) (*domain.PersonalityResult, error) {
-	questions := domain.GetQuestions()
-	questionMap := make(map[int]domain.Question)
-	for _, q := range questions {
-		questionMap[q.ID] = q
+// GetInstrumentQuestions returns the items of an instrument in the first of
+// the preferred languages that has a translation, then in the tenant's
+// default language, or else in the source language
+func (s *PersonalityService) GetInstrumentQuestions(tenant *domain.Tenant, id string, languages []string) (*domain.GetInstrumentQuestionsResponse, error) {
+	instrument, err := tenantInstrument(s.instruments, tenant, id)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate scores for each trait
-	traitScores := make(map[domain.Trait][]float64)
-	for _, answer := range answers {
-		question, exists := questionMap[answer.QuestionID]
-		if !exists {
-			continue
-		}
+	response := &domain.GetInstrumentQuestionsResponse{
+		InstrumentID:      instrument.ID,
+		InstrumentVersion: instrument.Version,
+		MinValue:          instrument.MinValue,
+		MaxValue:          instrument.MaxValue,
+		Language:          domain.SourceLanguage,
+	}
 
-		score := float64(answer.Value)
-		if question.Reversed {
-			score = 6 - score // Reverse the score (1->5, 2->4, 3->3, 4->2, 5->1)
+	if tenant.DefaultLanguage != "" {
+		languages = append(languages[:len(languages):len(languages)], tenant.DefaultLanguage)
+	}
+	translation := s.translations.Negotiate(instrument.ID, languages)
+	response.Questions = Localize(instrument, translation)
+	if translation != nil {
+		response.Language = translation.Language
+		response.Translation = translation.Key()
+		response.Options = ResponseOptions(instrument, translation)
+	}
+
+	return response, nil
+}
+
+// GetInstruments returns the instruments enabled for a tenant
+func (s *PersonalityService) GetInstruments(tenant *domain.Tenant) []*domain.Instrument {
+	var instruments []*domain.Instrument
+	for _, instrument := range s.instruments.List() {
+		if tenant.InstrumentEnabled(instrument.ID) {
+			instruments = append(instruments, instrument)
 		}
+	}
+	return instruments
+}
+
+// GetInstrument returns an instrument enabled for a tenant by ID
+func (s *PersonalityService) GetInstrument(tenant *domain.Tenant, id string) (*domain.Instrument, error) {
+	return tenantInstrument(s.instruments, tenant, id)
+}
 
-		traitScores[question.Trait] = append(traitScores[question.Trait], score)
+// CalculateResults processes answers and calculates personality scores
+// Answers are validated first; invalid submissions return a *ValidationError
+// Results are only stored if the session's respondent consented to it
+// Interpretations are generated in the background and won't be included in the returned result
+func (s *PersonalityService) CalculateResults(tenant *domain.Tenant, req domain.SubmitAnswersRequest) (*domain.PersonalityResult, error) {
+	instrument, err := tenantInstrument(s.instruments, tenant, req.InstrumentID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Calculate normalized scores (0-100)
-	calculateNormalizedScore := func(scores []float64) float64 {
-		if len(scores) == 0 {
-			return 0
-		}
-		var sum float64
-		for _, s := range scores {
-			sum += s
-		}
-		average := sum / float64(len(scores))
-		// Normalize from 1-5 scale to 0-100
-		return math.Round(((average - 1) / 4) * 100)
+	if err := s.checkConsent(tenant, req.SessionID, domain.ConsentPurposeStorage); err != nil {
+		return nil, err
 	}
 
+	answers := req.Answers
+	if err := ValidateAnswers(answers, instrument); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.resolveTranslation(instrument, req)
+	if err != nil {
+		return nil, err
+	}
+
+	scores, facets := calculateScores(instrument, answers)
+
 	result := &domain.PersonalityResult{
 		ID:                 uuid.New().String(),
-		SessionID:          sessionID,
-		Extraversion:       calculateNormalizedScore(traitScores[domain.TraitExtraversion]),
-		Agreeableness:      calculateNormalizedScore(traitScores[domain.TraitAgreeableness]),
-		Conscientiousness:  calculateNormalizedScore(traitScores[domain.TraitConscientiousness]),
-		EmotionalStability: calculateNormalizedScore(traitScores[domain.TraitEmotionalStability]),
-		Openness:           calculateNormalizedScore(traitScores[domain.TraitOpenness]),
+		TenantID:           tenant.ID,
+		SessionID:          req.SessionID,
+		InstrumentID:       instrument.ID,
+		InstrumentVersion:  instrument.Version,
+		Scores:             scores,
+		Extraversion:       scores[domain.TraitExtraversion],
+		Agreeableness:      scores[domain.TraitAgreeableness],
+		Conscientiousness:  scores[domain.TraitConscientiousness],
+		EmotionalStability: scores[domain.TraitEmotionalStability],
+		Openness:           scores[domain.TraitOpenness],
+		Facets:             facets,
+		Age:                req.Age,
+		Language:           req.Language,
+		Country:            req.Country,
+		Translation:        translation,
+		Quality:            AssessResponseQuality(answers, instrument, submissionDuration(req)),
+		StartedAt:          req.StartedAt,
+		CompletedAt:        req.CompletedAt,
+		CohortID:           req.CohortID,
+		CohortConsent:      req.CohortID != "" && req.CohortConsent,
 		CreatedAt:          time.Now(),
 	}
+	if instrument.Kind == domain.InstrumentKindInterests {
+		result.HollandCode = domain.HollandCode(scores)
+	}
+	if set := s.norms.Default(instrument.ID); set != nil {
+		ApplyNorms(result, set)
+	}
 
 	// Save to repository
 	if s.repo != nil {
-		if err := s.repo.Save(result); err != nil {
+		if err := s.repo.Save(result, answers); err != nil {
 			return nil, err
 		}
 	}
 
-	// Default language to German if not specified
+	// Default language to the tenant's, or German if neither is specified
+	language := req.Language
+	if language == "" {
+		language = tenant.DefaultLanguage
+	}
 	if language == "" {
 		language = "de"
 	}
 
 	// Generate AI interpretations in the background (non-blocking)
-	if s.openaiSvc != nil && s.repo != nil {
-		go s.generateInterpretationsInBackground(result.ID, result, language)
+	if tenant.DisableInterpretations {
+		log.Printf("Skipping interpretations for result %s: disabled for tenant %s", result.ID, tenant.ID)
+	} else if s.suppressInvalid && !result.Quality.Valid() {
+		log.Printf("Skipping interpretations for result %s: responses flagged %v", result.ID, result.Quality.Reasons)
+	} else if err := s.checkConsent(tenant, result.SessionID, domain.ConsentPurposeInterpretation); err != nil {
+		log.Printf("Skipping interpretations for result %s: %v", result.ID, err)
+	} else if s.openaiSvc != nil && s.repo != nil {
+		go s.generateInterpretationsInBackground(result.ID, result, language, tenant)
 	}
 
 	return result, nil
 }
 
+// checkConsent returns nil if a session's respondent consented to a purpose
+func (s *PersonalityService) checkConsent(tenant *domain.Tenant, sessionID, purpose string) error {
+	if s.consents == nil {
+		return nil
+	}
+	return s.consents.Check(tenant, sessionID, purpose)
+}
+
+// resolveTranslation returns the key of the translation a submission's items
+// were shown in: the one the client reports, or else the current translation
+// for the submission's language. It is empty for the source wording.
+func (s *PersonalityService) resolveTranslation(instrument *domain.Instrument, req domain.SubmitAnswersRequest) (string, error) {
+	if req.Translation != "" {
+		translation, err := s.translations.Get(req.Translation)
+		if err != nil {
+			return "", err
+		}
+		if translation.InstrumentID != instrument.ID {
+			return "", fmt.Errorf("%w: %s is not a translation of %s", ErrUnknownTranslation, req.Translation, instrument.ID)
+		}
+		return translation.Key(), nil
+	}
+
+	if translation := s.translations.Current(instrument.ID, req.Language); translation != nil {
+		return translation.Key(), nil
+	}
+	return "", nil
+}
+
+// calculateScores scores validated answers on every scale and facet of an instrument
+func calculateScores(instrument *domain.Instrument, answers []domain.Answer) (map[domain.Trait]float64, []domain.FacetScore) {
+	questionMap := make(map[int]domain.Question)
+	for _, q := range instrument.Items {
+		questionMap[q.ID] = q
+	}
+
+	// Collect item scores for each scale and facet
+	scaleScores := make(map[domain.Trait][]float64)
+	facetScores := make(map[string][]float64)
+	for _, answer := range answers {
+		question := questionMap[answer.QuestionID]
+
+		score := float64(answer.Value)
+		if question.Reversed {
+			// Reverse the score (1->5, 2->4, 3->3, 4->2, 5->1 on a 1-5 scale)
+			score = float64(instrument.MinValue+instrument.MaxValue) - score
+		}
+
+		scaleScores[question.Trait] = append(scaleScores[question.Trait], score)
+		if question.Facet != "" {
+			facetScores[question.Facet] = append(facetScores[question.Facet], score)
+		}
+	}
+
+	scores := make(map[domain.Trait]float64, len(instrument.Scales))
+	for _, scale := range instrument.Scales {
+		scores[scale.ID] = normalizeScore(scaleScores[scale.ID], instrument.MinValue, instrument.MaxValue)
+	}
+
+	var facets []domain.FacetScore
+	for _, facet := range instrument.Facets {
+		items := facetScores[facet.ID]
+		if len(items) == 0 {
+			continue
+		}
+
+		// Item scores are keyed towards the trait; flip facets named after the opposite pole
+		score := normalizeScore(items, instrument.MinValue, instrument.MaxValue)
+		if facet.Inverted {
+			score = 100 - score
+		}
+
+		facets = append(facets, domain.FacetScore{
+			Facet: facet.ID,
+			Name:  facet.Name,
+			Trait: facet.Trait,
+			Score: score,
+		})
+	}
+
+	return scores, facets
+}
+
+// normalizeScore maps the mean of item scores from the answer range to 0-100
+func normalizeScore(scores []float64, minValue, maxValue int) float64 {
+	if len(scores) == 0 {
+		return 0
+	}
+	var sum float64
+	for _, s := range scores {
+		sum += s
+	}
+	average := sum / float64(len(scores))
+	return math.Round(((average - float64(minValue)) / float64(maxValue-minValue)) * 100)
+}
+
 // Background generation timeout (should be longer than individual call timeouts * retries)
 const backgroundGenerationTimeout = 5 * time.Minute
 
 // generateInterpretationsInBackground generates and saves AI interpretations asynchronously
-func (s *PersonalityService) generateInterpretationsInBackground(resultID string, result *domain.PersonalityResult, language string) {
+func (s *PersonalityService) generateInterpretationsInBackground(resultID string, result *domain.PersonalityResult, language string, tenant *domain.Tenant) {
 	// Panic recovery - don't let a panic crash the background goroutine
 	defer func() {
 		if r := recover(); r != nil {
@@ -120,11 +317,16 @@ func (s *PersonalityService) generateInterpretationsInBackground(resultID string
 	ctx, cancel := context.WithTimeout(context.Background(), backgroundGenerationTimeout)
 	defer cancel()
 
-	interpretations, err := s.openaiSvc.GenerateAllInterpretations(ctx, result, language)
-	if err != nil {
+	interpretations, err := s.openaiSvc.GenerateAllInterpretations(ctx, result, language, tenant.PromptBranding)
+	if err != nil && !errors.Is(err, ErrIncompleteInterpretations) {
 		log.Printf("Warning: Failed to generate interpretations for result %s: %v (elapsed: %v)", resultID, err, time.Since(startTime))
 		return
 	}
+	// The result has no interpretations yet, so the traits that were
+	// generated are stored; a regeneration can complete them
+	if err != nil {
+		log.Printf("Warning: %v for result %s", err, resultID)
+	}
 
 	// Check if we got any interpretations
 	if len(interpretations) == 0 {
@@ -132,8 +334,20 @@ func (s *PersonalityService) generateInterpretationsInBackground(resultID string
 		return
 	}
 
-	// Save interpretations to database
-	if err := s.repo.SaveInterpretations(interpretations); err != nil {
+	// Consent may have been withdrawn while the interpretations were generated
+	if err := s.checkConsent(tenant, result.SessionID, domain.ConsentPurposeInterpretation); err != nil {
+		log.Printf("Discarding interpretations for result %s: %v (elapsed: %v)", resultID, err, time.Since(startTime))
+		return
+	}
+
+	// The result may have been erased while the interpretations were generated
+	if _, err := s.repo.GetByID(tenant.ID, resultID); err != nil {
+		log.Printf("Discarding interpretations for result %s: %v (elapsed: %v)", resultID, err, time.Since(startTime))
+		return
+	}
+
+	// Save interpretations to database as the result's first version
+	if _, err := s.repo.SaveInterpretationVersion(tenant.ID, resultID, interpretations); err != nil {
 		log.Printf("Warning: Failed to save interpretations for result %s: %v (elapsed: %v)", resultID, err, time.Since(startTime))
 		return
 	}
@@ -141,56 +355,191 @@ func (s *PersonalityService) generateInterpretationsInBackground(resultID string
 	log.Printf("Successfully generated and saved %d interpretations for result %s (elapsed: %v)", len(interpretations), resultID, time.Since(startTime))
 }
 
-// GetResult retrieves a personality result by ID (including interpretations)
-func (s *PersonalityService) GetResult(id string) (*domain.PersonalityResult, error) {
+// GetResult retrieves a personality result of a tenant by ID (including interpretations)
+func (s *PersonalityService) GetResult(tenant *domain.Tenant, id string) (*domain.PersonalityResult, error) {
 	if s.repo == nil {
 		return nil, nil
 	}
-	return s.repo.GetByIDWithInterpretations(id)
+	result, err := s.repo.GetByIDWithInterpretations(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	s.describeResults(result)
+	return result, nil
+}
+
+// CheckOwner verifies that a result of a tenant was created in the given session
+func (s *PersonalityService) CheckOwner(tenant *domain.Tenant, resultID, sessionID string) error {
+	if s.repo == nil {
+		return nil
+	}
+	result, err := s.repo.GetByID(tenant.ID, resultID)
+	if err != nil {
+		return err
+	}
+	if result.SessionID != sessionID {
+		return ErrNotOwner
+	}
+	return nil
+}
+
+// describeResults fills in derived, non-stored fields of results: norm scores
+// from the norm set recorded with each result and facet names from its instrument
+func (s *PersonalityService) describeResults(results ...*domain.PersonalityResult) {
+	for _, result := range results {
+		if result.NormSetID != "" {
+			if set := s.norms.Get(result.NormSetID); set != nil {
+				ApplyNorms(result, set)
+			}
+		}
+
+		if len(result.Facets) == 0 {
+			continue
+		}
+		instrument, err := s.instruments.Get(result.InstrumentID)
+		if err != nil {
+			continue
+		}
+		names := make(map[string]string, len(instrument.Facets))
+		for _, facet := range instrument.Facets {
+			names[facet.ID] = facet.Name
+		}
+		for i := range result.Facets {
+			result.Facets[i].Name = names[result.Facets[i].Facet]
+		}
+	}
 }
 
-// GetAllResults retrieves all personality results
-func (s *PersonalityService) GetAllResults() ([]*domain.PersonalityResult, error) {
+// GetAnswers retrieves the raw answers stored for a result of a tenant
+func (s *PersonalityService) GetAnswers(tenant *domain.Tenant, resultID string) ([]*domain.StoredAnswer, error) {
 	if s.repo == nil {
 		return nil, nil
 	}
-	return s.repo.GetAll()
+	if _, err := s.repo.GetByID(tenant.ID, resultID); err != nil {
+		return nil, err
+	}
+	return s.repo.GetAnswersByResultID(tenant.ID, resultID)
 }
 
-// RegenerateInterpretations regenerates AI interpretations for an existing result
-func (s *PersonalityService) RegenerateInterpretations(id string, language string) (*domain.PersonalityResult, error) {
+// GetAdminResult retrieves a result of a tenant with its raw answers and aggregated timing
+func (s *PersonalityService) GetAdminResult(tenant *domain.Tenant, id string) (*domain.AdminResultResponse, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	result, err := s.repo.GetByIDWithInterpretations(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	s.describeResults(result)
+
+	answers, err := s.repo.GetAnswersByResultID(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	if answers == nil {
+		answers = []*domain.StoredAnswer{}
+	}
+
+	return &domain.AdminResultResponse{
+		PersonalityResult: result,
+		Timing:            SummarizeTiming(result, answers),
+		Answers:           answers,
+	}, nil
+}
+
+// GetSessionResults retrieves all results of a session within a tenant
+func (s *PersonalityService) GetSessionResults(tenant *domain.Tenant, sessionID string) ([]*domain.PersonalityResult, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	results, err := s.repo.GetBySessionID(tenant.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	s.describeResults(results...)
+	return results, nil
+}
+
+// ListResults retrieves a page of a tenant's results for the admin listing
+func (s *PersonalityService) ListResults(tenant *domain.Tenant, query domain.ResultListQuery) (*domain.ResultPage, error) {
+	if s.repo == nil {
+		return &domain.ResultPage{Results: []*domain.PersonalityResult{}}, nil
+	}
+	if err := s.checkScales(tenant, query); err != nil {
+		return nil, err
+	}
+	page, err := s.repo.ListResults(tenant.ID, query)
+	if err != nil {
+		return nil, err
+	}
+	s.describeResults(page.Results...)
+	return page, nil
+}
+
+// checkScales returns ErrInvalidSort or ErrInvalidFilter if a listing query
+// sorts or filters by a scale none of the tenant's instruments has
+func (s *PersonalityService) checkScales(tenant *domain.Tenant, query domain.ResultListQuery) error {
+	scales := make(map[domain.Trait]bool)
+	for _, instrument := range s.GetInstruments(tenant) {
+		for _, scale := range instrument.Scales {
+			scales[scale.ID] = true
+		}
+	}
+
+	if query.SortScale != "" && !scales[query.SortScale] {
+		return fmt.Errorf("%w: unknown scale %q", ErrInvalidSort, query.SortScale)
+	}
+	for _, scoreRange := range query.Scores {
+		if !scales[scoreRange.Scale] {
+			return fmt.Errorf("%w: unknown scale %q", ErrInvalidFilter, scoreRange.Scale)
+		}
+	}
+	return nil
+}
+
+// RegenerateInterpretations regenerates AI interpretations for an existing result of a tenant
+func (s *PersonalityService) RegenerateInterpretations(tenant *domain.Tenant, id string, language string) (*domain.PersonalityResult, error) {
 	if s.repo == nil {
 		return nil, nil
 	}
 
 	// Get the existing result (without interpretations)
-	result, err := s.repo.GetByID(id)
+	result, err := s.repo.GetByID(tenant.ID, id)
 	if err != nil {
 		return nil, err
 	}
 	if result == nil {
 		return nil, nil
 	}
+	s.describeResults(result)
+
+	if tenant.DisableInterpretations {
+		return nil, ErrInterpretationsDisabled
+	}
+	if s.suppressInvalid && !result.Quality.Valid() {
+		return nil, ErrInvalidProtocol
+	}
+	if err := s.checkConsent(tenant, result.SessionID, domain.ConsentPurposeInterpretation); err != nil {
+		return nil, err
+	}
 
 	// Check if OpenAI service is available
 	if s.openaiSvc == nil {
 		return nil, fmt.Errorf("OpenAI service not configured")
 	}
 
-	// Delete existing interpretations
-	if err := s.repo.DeleteInterpretationsByResultID(id); err != nil {
-		return nil, fmt.Errorf("failed to delete existing interpretations: %w", err)
-	}
-
-	// Generate new interpretations with the specified language
+	// Generate new interpretations with the specified language. The existing
+	// interpretations stay active until the new ones are stored, and are
+	// kept if any trait fails rather than replaced by an incomplete set.
 	ctx := context.Background()
-	interpretations, err := s.openaiSvc.GenerateAllInterpretations(ctx, result, language)
+	interpretations, err := s.openaiSvc.GenerateAllInterpretations(ctx, result, language, tenant.PromptBranding)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate interpretations: %w", err)
 	}
 
-	// Save new interpretations
-	if err := s.repo.SaveInterpretations(interpretations); err != nil {
+	// Store the new interpretations as the result's next version; earlier
+	// versions are kept for the history
+	if _, err := s.repo.SaveInterpretationVersion(tenant.ID, id, interpretations); err != nil {
 		return nil, fmt.Errorf("failed to save interpretations: %w", err)
 	}
 
@@ -202,3 +551,60 @@ func (s *PersonalityService) RegenerateInterpretations(id string, language strin
 
 	return result, nil
 }
+
+// GetInterpretationHistory retrieves every version of the interpretations of a
+// result of a tenant, newest first
+func (s *PersonalityService) GetInterpretationHistory(tenant *domain.Tenant, id string) ([]*domain.InterpretationVersion, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	if _, err := s.repo.GetByID(tenant.ID, id); err != nil {
+		return nil, err
+	}
+	interpretations, err := s.repo.GetInterpretationHistory(tenant.ID, id)
+	if err != nil {
+		return nil, err
+	}
+	return interpretationVersions(interpretations), nil
+}
+
+// RollbackInterpretations makes an earlier version of the interpretations of
+// a result of a tenant active again and returns the result with them
+func (s *PersonalityService) RollbackInterpretations(tenant *domain.Tenant, id string, version int) (*domain.PersonalityResult, error) {
+	if s.repo == nil {
+		return nil, nil
+	}
+	if err := s.repo.ActivateInterpretationVersion(tenant.ID, id, version); err != nil {
+		return nil, err
+	}
+	return s.GetResult(tenant, id)
+}
+
+// interpretationVersions groups interpretations ordered by version, newest
+// first, into their versions
+func interpretationVersions(interpretations []*domain.TraitInterpretation) []*domain.InterpretationVersion {
+	versions := []*domain.InterpretationVersion{}
+	var current *domain.InterpretationVersion
+	for _, interp := range interpretations {
+		if current == nil || current.Version != interp.Version {
+			current = &domain.InterpretationVersion{
+				Version:         interp.Version,
+				Active:          interp.Active,
+				Language:        interp.Language,
+				Model:           interp.Model,
+				PromptVersion:   interp.PromptVersion,
+				Temperature:     interp.Temperature,
+				GeneratedAt:     interp.GeneratedAt,
+				Interpretations: make(map[domain.Trait]string),
+			}
+			versions = append(versions, current)
+		}
+		current.PromptTokens += interp.PromptTokens
+		current.CompletionTokens += interp.CompletionTokens
+		if interp.GeneratedAt.After(current.GeneratedAt) {
+			current.GeneratedAt = interp.GeneratedAt
+		}
+		current.Interpretations[interp.Trait] = interp.Interpretation
+	}
+	return versions
+}
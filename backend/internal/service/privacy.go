@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+// PrivacyService exports and erases respondent data on request of the
+// respondent or an admin
+type PrivacyService struct {
+	personality *PersonalityService
+	results     ResultStore
+	drafts      *repository.DraftRepository
+	shares      *repository.ShareRepository
+	consents    *repository.ConsentRepository
+	erasures    *repository.ErasureRepository
+}
+
+// NewPrivacyService creates a new privacy service
+func NewPrivacyService(personality *PersonalityService, results ResultStore, drafts *repository.DraftRepository, shares *repository.ShareRepository, consents *repository.ConsentRepository, erasures *repository.ErasureRepository) *PrivacyService {
+	return &PrivacyService{
+		personality: personality,
+		results:     results,
+		drafts:      drafts,
+		shares:      shares,
+		consents:    consents,
+		erasures:    erasures,
+	}
+}
+
+// Export collects everything stored for a session within a tenant: its
+// results with interpretations and their earlier versions, raw answers and
+// share links, its draft sessions and its consent records
+func (s *PrivacyService) Export(tenant *domain.Tenant, sessionID string) (*domain.DataExport, error) {
+	now := time.Now()
+	export := &domain.DataExport{
+		SessionID:  sessionID,
+		ExportedAt: now,
+		Results:    []*domain.ExportedResult{},
+		Drafts:     []*domain.DraftSession{},
+		Consents:   []*domain.ConsentRecord{},
+	}
+
+	results, err := s.personality.GetSessionResults(tenant, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, result := range results {
+		if result.Interpretations, err = s.results.GetInterpretationsByResultID(tenant.ID, result.ID); err != nil {
+			return nil, err
+		}
+		history, err := s.results.GetInterpretationHistory(tenant.ID, result.ID)
+		if err != nil {
+			return nil, err
+		}
+		answers, err := s.results.GetAnswersByResultID(tenant.ID, result.ID)
+		if err != nil {
+			return nil, err
+		}
+		shares, err := s.shares.GetByResultID(tenant.ID, result.ID)
+		if err != nil {
+			return nil, err
+		}
+		if answers == nil {
+			answers = []*domain.StoredAnswer{}
+		}
+		if shares == nil {
+			shares = []*domain.ShareLink{}
+		}
+		export.Results = append(export.Results, &domain.ExportedResult{
+			PersonalityResult:     result,
+			InterpretationHistory: interpretationVersions(history),
+			Answers:               answers,
+			Shares:                shares,
+		})
+	}
+
+	drafts, err := s.drafts.GetBySessionID(tenant.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	export.Drafts = append(export.Drafts, drafts...)
+
+	consents, err := s.consents.GetBySessionID(tenant.ID, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	for _, consent := range consents {
+		consent.Status = consent.StatusAt(now)
+	}
+	export.Consents = append(export.Consents, consents...)
+
+	return export, nil
+}
+
+// EraseSession hard-deletes everything stored for a session within a tenant
+// and returns the tombstone recording the erasure. requestedBy is
+// domain.ErasureRequestedBySubject or the name of the requesting admin.
+func (s *PrivacyService) EraseSession(tenant *domain.Tenant, sessionID, requestedBy string) (*domain.Erasure, error) {
+	erasure := newErasure(tenant, domain.ErasureSubjectSession, sessionID, requestedBy)
+	return s.erase(erasure, domain.ResultFilter{SessionID: sessionID})
+}
+
+// EraseResult hard-deletes a result of a tenant with its interpretations and
+// answers on request of an admin, and returns the tombstone recording the
+// erasure. A result that is already gone can only be erased again to
+// complete a pending erasure.
+func (s *PrivacyService) EraseResult(tenant *domain.Tenant, resultID, requestedBy string) (*domain.Erasure, error) {
+	if _, err := s.results.GetByID(tenant.ID, resultID); err != nil {
+		if !errors.Is(err, repository.ErrNotFound) {
+			return nil, err
+		}
+		if _, pendingErr := s.erasures.GetPending(tenant.ID, domain.ErasureSubjectResult, resultID); pendingErr != nil {
+			return nil, err
+		}
+	}
+
+	erasure := newErasure(tenant, domain.ErasureSubjectResult, resultID, requestedBy)
+	return s.erase(erasure, domain.ResultFilter{ID: resultID})
+}
+
+// erase stores the tombstone as pending, deletes the results matching the
+// filter from the result store, then the share links, drafts and consent
+// records kept with them, and completes the tombstone. Repeating an erasure
+// interrupted by an error continues its pending tombstone.
+func (s *PrivacyService) erase(erasure *domain.Erasure, filter domain.ResultFilter) (*domain.Erasure, error) {
+	erasure, err := s.erasures.Start(erasure)
+	if err != nil {
+		return nil, err
+	}
+
+	deletion, err := s.results.DeleteResults(erasure.TenantID, filter)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.erasures.RecordResults(erasure, deletion); err != nil {
+		return nil, err
+	}
+	if err := s.erasures.Complete(erasure); err != nil {
+		return nil, err
+	}
+	return erasure, nil
+}
+
+// GetErasures lists the erasure tombstones of a tenant
+func (s *PrivacyService) GetErasures(tenant *domain.Tenant) ([]*domain.Erasure, error) {
+	return s.erasures.GetAll(tenant.ID)
+}
+
+// newErasure creates the tombstone of an erasure that is about to happen
+func newErasure(tenant *domain.Tenant, subject, subjectID, requestedBy string) *domain.Erasure {
+	return &domain.Erasure{
+		ID:          uuid.New().String(),
+		TenantID:    tenant.ID,
+		Subject:     subject,
+		SubjectID:   subjectID,
+		RequestedBy: requestedBy,
+		ErasedAt:    time.Now(),
+	}
+}
@@ -2,10 +2,16 @@ package service
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/thielel/voca/internal/domain"
 )
 
+// PromptVersion identifies the prompt templates in this file and is stored
+// with every generated interpretation. Change it whenever a system prompt or
+// prompt builder changes the text sent to the model.
+const PromptVersion = "2026.1"
+
 // Language configuration for supported languages
 type LanguageConfig struct {
 	SystemPrompt       string
@@ -23,6 +29,16 @@ func GetSystemPrompt(language string) string {
 	return config.SystemPrompt
 }
 
+// BrandSystemPrompt appends a tenant's branding text, e.g. the name of the
+// school's counseling service, to a system prompt
+func BrandSystemPrompt(systemPrompt, branding string) string {
+	branding = strings.TrimSpace(branding)
+	if branding == "" {
+		return systemPrompt
+	}
+	return systemPrompt + "\n\n" + branding
+}
+
 // getLanguageConfig returns the language configuration for a given language code
 func getLanguageConfig(language string) LanguageConfig {
 	switch language {
@@ -416,13 +432,140 @@ func bulgarianConfig() LanguageConfig {
 // BuildInterpretationPrompt creates the user prompt for generating a trait interpretation.
 // It instructs the AI to produce flowing, narrative text suitable for young people
 // seeking career orientation.
-func BuildInterpretationPrompt(trait domain.Trait, score float64, language string) string {
+// Facet scores are appended when the instrument measures them.
+func BuildInterpretationPrompt(trait domain.Trait, score float64, facets []domain.FacetScore, language string) string {
 	traitName := getTraitName(trait, language)
 	scoreDescription := describeScore(score, language)
 	scoreContext := getScoreContext(trait, score, language)
 	config := getLanguageConfig(language)
 
-	return buildPromptForLanguage(traitName, score, scoreDescription, scoreContext, config)
+	prompt := buildPromptForLanguage(traitName, score, scoreDescription, scoreContext, config)
+	if len(facets) > 0 {
+		prompt += "\n\n" + buildFacetSection(facets, language)
+	}
+	return prompt
+}
+
+// buildFacetSection lists a trait's facet scores and asks the AI to weave
+// noticeable differences between them into the text. Facet names stay in
+// English; the AI is asked to translate them.
+func buildFacetSection(facets []domain.FacetScore, language string) string {
+	texts := map[string][2]string{
+		"de": {
+			"Diese Eigenschaft besteht aus mehreren Teilbereichen (Facetten), jeweils von 0 bis 100:",
+			"Wenn sich die Facetten deutlich unterscheiden, greife das in deinem Text auf (zum Beispiel: meistens entspannt, aber schnell genervt). Übersetze die Facettennamen ins Deutsche und nenne keine Zahlen.",
+		},
+		"en": {
+			"This trait is made up of several sub-dimensions (facets), each scored from 0 to 100:",
+			"Where the facets differ noticeably, weave that into your text (for example: mostly relaxed, but quick to get annoyed). Do not mention the numbers.",
+		},
+		"tr": {
+			"Bu özellik, her biri 0 ile 100 arasında puanlanan birkaç alt boyuttan (faset) oluşur:",
+			"Fasetler belirgin şekilde farklıysa bunu metnine yansıt (örneğin: çoğunlukla rahat ama çabuk sinirlenen). Faset adlarını Türkçeye çevir ve sayıları belirtme.",
+		},
+		"ar": {
+			"تتكون هذه السمة من عدة أبعاد فرعية (جوانب)، لكل منها درجة من 0 إلى 100:",
+			"إذا كانت الجوانب مختلفة بشكل واضح، فادمج ذلك في نصك (مثلاً: هادئ في الغالب لكنه ينزعج بسرعة). ترجم أسماء الجوانب إلى العربية ولا تذكر الأرقام.",
+		},
+		"ru": {
+			"Эта черта состоит из нескольких составляющих (фасет), каждая оценена от 0 до 100:",
+			"Если фасеты заметно различаются, отрази это в тексте (например: в основном спокойный, но быстро раздражаешься). Переведи названия фасет на русский и не называй цифры.",
+		},
+		"pl": {
+			"Ta cecha składa się z kilku podwymiarów (aspektów), każdy oceniony od 0 do 100:",
+			"Jeśli aspekty wyraźnie się różnią, uwzględnij to w tekście (na przykład: zwykle spokojny, ale szybko się irytujesz). Przetłumacz nazwy aspektów na polski i nie podawaj liczb.",
+		},
+		"ro": {
+			"Această trăsătură este alcătuită din mai multe subdimensiuni (fațete), fiecare notată de la 0 la 100:",
+			"Dacă fațetele diferă vizibil, integrează asta în text (de exemplu: de obicei relaxat, dar te enervezi repede). Tradu numele fațetelor în română și nu menționa cifrele.",
+		},
+		"it": {
+			"Questo tratto è composto da diverse sottodimensioni (sfaccettature), ognuna con un punteggio da 0 a 100:",
+			"Se le sfaccettature sono nettamente diverse, inseriscilo nel testo (per esempio: di solito rilassato, ma ti irriti in fretta). Traduci i nomi delle sfaccettature in italiano e non citare i numeri.",
+		},
+		"uk": {
+			"Ця риса складається з кількох складових (фасет), кожна оцінена від 0 до 100:",
+			"Якщо фасети помітно різняться, відобрази це в тексті (наприклад: здебільшого спокійний, але швидко дратуєшся). Переклади назви фасет українською і не називай цифри.",
+		},
+		"bg": {
+			"Тази черта се състои от няколко поддимензии (фасети), всяка оценена от 0 до 100:",
+			"Ако фасетите се различават осезаемо, вплети това в текста (например: обикновено спокоен, но бързо се дразниш). Преведи имената на фасетите на български и не споменавай числата.",
+		},
+	}
+
+	text, ok := texts[language]
+	if !ok {
+		text = texts["de"]
+	}
+
+	var b strings.Builder
+	b.WriteString(text[0])
+	b.WriteString("\n")
+	for _, facet := range facets {
+		name := facet.Name
+		if name == "" {
+			name = facet.Facet
+		}
+		fmt.Fprintf(&b, "- %s: %.0f\n", name, facet.Score)
+	}
+	b.WriteString(text[1])
+	return b.String()
+}
+
+// BuildInterestPrompt creates the user prompt for interpreting a RIASEC interest
+// profile. The instructions are in English; the answer is requested in the
+// configured response language.
+func BuildInterestPrompt(scores map[domain.Trait]float64, hollandCode string, language string) string {
+	config := getLanguageConfig(language)
+
+	var profile strings.Builder
+	for _, trait := range domain.RIASECTraits() {
+		fmt.Fprintf(&profile, "- %s (%s): %.0f\n", interestNames[trait], domain.HollandLetter(trait), scores[trait])
+	}
+
+	return fmt.Sprintf(`Write a personal, encouraging interpretation of an interest profile from the
+Holland (RIASEC) interest inventory. The scores show how much someone enjoys
+different kinds of activities, each from 0 to 100:
+
+%s
+Their three-letter Holland code is %s (strongest interest first).
+
+Write this as a flowing, connected text in exactly four sections.
+Each section should have 3-5 sentences that flow naturally into the next.
+Do NOT use bullet points, lists, or numbering.
+Translate the interest type names naturally and do not mention the numbers.
+
+Structure your text with these four headings (translated):
+
+## What your interests say about you
+
+Describe what the combination of their top three interests has in common and how it
+might already show up in school, hobbies or free time.
+
+## Activities you'll probably enjoy
+
+Describe kinds of tasks and activities that fit this combination.
+
+## Environments that could suit you
+
+Describe settings and ways of working that fit – with people, with ideas, with
+tools, with data – without naming specific jobs.
+
+## Things to think about
+
+Ask two or three open, inviting questions that help them explore their interests further.
+
+Respond exclusively in %s.`, profile.String(), hollandCode, config.ResponseLanguage)
+}
+
+// interestNames are the English names of the RIASEC interest types
+var interestNames = map[domain.Trait]string{
+	domain.TraitRealistic:     "Realistic – hands-on, practical",
+	domain.TraitInvestigative: "Investigative – researching, analysing",
+	domain.TraitArtistic:      "Artistic – creative, expressive",
+	domain.TraitSocial:        "Social – helping, teaching",
+	domain.TraitEnterprising:  "Enterprising – leading, persuading",
+	domain.TraitConventional:  "Conventional – organising, structured",
 }
 
 // getTraitName returns the trait name in the specified language
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"math"
+	"slices"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// Careless-responding thresholds. A protocol tripping one indicator is
+// flagged suspect; tripping two or more, it is flagged invalid.
+const (
+	// longStringMinRun is the run of identical consecutive answers that counts as straightlining
+	longStringMinRun = 10
+	// lowVarianceRatio is the response SD, relative to the answer range, below which answers barely vary
+	lowVarianceRatio = 0.125
+	// inconsistencyRatio is the mean antonym deviation, relative to the answer range, that counts as inconsistent
+	inconsistencyRatio = 0.5
+	// minAntonymPairs is the number of answered antonym pairs needed to judge consistency
+	minAntonymPairs = 3
+	// minSecondsPerItem is the average answering time below which items cannot have been read
+	minSecondsPerItem = 2.0
+)
+
+// AssessResponseQuality computes careless-responding indicators for validated
+// answers. durationSeconds is the total completion time, or 0 when unknown.
+func AssessResponseQuality(answers []domain.Answer, instrument *domain.Instrument, durationSeconds float64) *domain.ResponseQuality {
+	values := make(map[int]int, len(answers))
+	for _, answer := range answers {
+		values[answer.QuestionID] = answer.Value
+	}
+
+	quality := &domain.ResponseQuality{}
+	answerRange := float64(instrument.MaxValue - instrument.MinValue)
+
+	// Long-string: longest run of identical answers in presentation order
+	run, previous := 0, 0
+	var sum, sumSquares float64
+	for _, item := range instrument.Items {
+		value, ok := values[item.ID]
+		if !ok {
+			run, previous = 0, 0
+			continue
+		}
+		if value == previous {
+			run++
+		} else {
+			run = 1
+		}
+		previous = value
+		quality.LongestRun = max(quality.LongestRun, run)
+
+		sum += float64(value)
+		sumSquares += float64(value * value)
+	}
+	if quality.LongestRun >= longStringMinRun {
+		quality.Reasons = append(quality.Reasons, domain.QualityReasonLongString)
+	}
+
+	// Intra-individual response variability
+	if n := float64(len(values)); n > 1 {
+		mean := sum / n
+		variance := (sumSquares - n*mean*mean) / (n - 1)
+		quality.ResponseSD = math.Round(math.Sqrt(math.Max(variance, 0))*100) / 100
+	}
+	if quality.ResponseSD < lowVarianceRatio*answerRange {
+		quality.Reasons = append(quality.Reasons, domain.QualityReasonLowVariance)
+	}
+
+	// Psychometric antonyms: a consistent respondent answers them on opposite
+	// sides of the scale, so each pair should sum to MinValue+MaxValue
+	var deviation float64
+	pairs := 0
+	for _, pair := range instrument.AntonymPairs() {
+		a, okA := values[pair[0]]
+		b, okB := values[pair[1]]
+		if !okA || !okB {
+			continue
+		}
+		deviation += math.Abs(float64(a + b - instrument.MinValue - instrument.MaxValue))
+		pairs++
+	}
+	if pairs >= minAntonymPairs {
+		inconsistency := math.Round(deviation/float64(pairs)*100) / 100
+		quality.Inconsistency = &inconsistency
+		if inconsistency >= inconsistencyRatio*answerRange {
+			quality.Reasons = append(quality.Reasons, domain.QualityReasonInconsistent)
+		}
+	}
+
+	// Completion speed, when the client reported timing
+	if durationSeconds > 0 && len(values) > 0 {
+		secondsPerItem := math.Round(durationSeconds/float64(len(values))*100) / 100
+		quality.SecondsPerItem = &secondsPerItem
+		if secondsPerItem < minSecondsPerItem {
+			quality.Reasons = append(quality.Reasons, domain.QualityReasonTooFast)
+		}
+	}
+
+	switch {
+	case len(quality.Reasons) == 0:
+		quality.Flag = domain.QualityOK
+	case len(quality.Reasons) == 1:
+		quality.Flag = domain.QualitySuspect
+	default:
+		quality.Flag = domain.QualityInvalid
+	}
+
+	return quality
+}
+
+// fastItemSeconds is the per-item latency below which an answer counts as rushed
+const fastItemSeconds = 1.0
+
+// submissionDuration returns the total completion time of a submission in
+// seconds: as reported, from its start and end timestamps, or as the sum of
+// item latencies when every answer carries one. It returns 0 when unknown.
+func submissionDuration(req domain.SubmitAnswersRequest) float64 {
+	if req.DurationSeconds > 0 {
+		return req.DurationSeconds
+	}
+	if req.StartedAt != nil && req.CompletedAt != nil {
+		return req.CompletedAt.Sub(*req.StartedAt).Seconds()
+	}
+
+	var totalMs int
+	for _, answer := range req.Answers {
+		if answer.LatencyMs <= 0 {
+			return 0
+		}
+		totalMs += answer.LatencyMs
+	}
+	return float64(totalMs) / 1000
+}
+
+// SummarizeTiming aggregates the stored timing of a result and its answers
+func SummarizeTiming(result *domain.PersonalityResult, answers []*domain.StoredAnswer) *domain.ResponseTiming {
+	timing := &domain.ResponseTiming{FastItemThresholdSeconds: fastItemSeconds}
+
+	var latencies []float64
+	var totalMs int
+	for _, answer := range answers {
+		if answer.LatencyMs <= 0 {
+			continue
+		}
+		seconds := float64(answer.LatencyMs) / 1000
+		latencies = append(latencies, seconds)
+		totalMs += answer.LatencyMs
+		if seconds < fastItemSeconds {
+			timing.FastItems++
+		}
+	}
+	timing.ItemsTimed = len(latencies)
+
+	if len(latencies) > 0 {
+		slices.Sort(latencies)
+		median := latencies[len(latencies)/2]
+		if len(latencies)%2 == 0 {
+			median = (latencies[len(latencies)/2-1] + median) / 2
+		}
+		median = math.Round(median*100) / 100
+		timing.MedianSecondsPerItem = &median
+	}
+
+	switch {
+	case result.StartedAt != nil && result.CompletedAt != nil:
+		total := math.Round(result.CompletedAt.Sub(*result.StartedAt).Seconds()*100) / 100
+		timing.TotalSeconds = &total
+	case len(latencies) > 0 && len(latencies) == len(answers):
+		total := float64(totalMs) / 1000
+		timing.TotalSeconds = &total
+	}
+
+	return timing
+}
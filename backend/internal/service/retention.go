@@ -0,0 +1,219 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+// ErrInvalidRetention is returned for retention policies that cannot be applied
+var ErrInvalidRetention = errors.New("invalid retention policy")
+
+// RetentionService applies the tenants' retention rules to stored results
+type RetentionService struct {
+	repo    *repository.RetentionRepository
+	results ResultStore
+	drafts  *repository.DraftRepository
+	shares  *repository.ShareRepository
+	tenants *TenantRegistry
+	dryRun  bool
+}
+
+// NewRetentionService creates a new retention service. With dryRun set,
+// every purge only reports what it would delete.
+func NewRetentionService(repo *repository.RetentionRepository, results ResultStore, drafts *repository.DraftRepository, shares *repository.ShareRepository, tenants *TenantRegistry, dryRun bool) *RetentionService {
+	return &RetentionService{
+		repo:    repo,
+		results: results,
+		drafts:  drafts,
+		shares:  shares,
+		tenants: tenants,
+		dryRun:  dryRun,
+	}
+}
+
+// Purge applies a tenant's retention rules in order and returns the report.
+// Reports of real purges are stored; dry runs only return theirs.
+func (s *RetentionService) Purge(tenant *domain.Tenant, dryRun bool) (*domain.PurgeReport, error) {
+	now := time.Now()
+	report := &domain.PurgeReport{
+		ID:       uuid.New().String(),
+		TenantID: tenant.ID,
+		DryRun:   dryRun || s.dryRun,
+		RanAt:    now,
+		Rules:    []*domain.PurgeRuleReport{},
+	}
+	if tenant.Retention == nil {
+		return report, nil
+	}
+
+	for _, rule := range tenant.Retention.Rules {
+		ruleReport := &domain.PurgeRuleReport{
+			Rule:   rule.Name,
+			Action: rule.Action,
+			Before: retentionCutoff(tenant.Retention, rule, now),
+		}
+		if err := s.applyRule(tenant, rule, report.DryRun, ruleReport); err != nil {
+			return nil, fmt.Errorf("retention rule %q: %w", rule.Name, err)
+		}
+		report.Rules = append(report.Rules, ruleReport)
+	}
+
+	if !report.DryRun {
+		if err := s.repo.Create(report); err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// applyRule deletes what a retention rule matches and fills in the counts of
+// the rule's report. A dry run only counts what a real run would delete.
+func (s *RetentionService) applyRule(tenant *domain.Tenant, rule *domain.RetentionRule, dryRun bool, report *domain.PurgeRuleReport) error {
+	filter := domain.ResultFilter{
+		Results:             rule.Results,
+		CreatedBefore:       &report.Before,
+		WithInterpretations: rule.Action == domain.RetentionActionStripInterpretations,
+	}
+
+	var deletion *domain.ResultDeletion
+	var err error
+	switch {
+	case dryRun:
+		deletion, err = s.results.CountResults(tenant.ID, filter)
+	case rule.Action == domain.RetentionActionStripInterpretations:
+		deletion, err = s.results.DeleteInterpretations(tenant.ID, filter)
+	default:
+		deletion, err = s.results.DeleteResults(tenant.ID, filter)
+	}
+	if err != nil {
+		return err
+	}
+	report.Results = deletion.Results
+	report.Interpretations = deletion.Interpretations
+
+	// Share links and drafts of deleted results are kept apart from the results
+	if len(deletion.ResultIDs) > 0 {
+		if _, err := s.shares.DeleteByResultIDs(tenant.ID, deletion.ResultIDs); err != nil {
+			return err
+		}
+		if _, err := s.drafts.DeleteByResultIDs(tenant.ID, deletion.ResultIDs); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// PurgeAll purges every tenant that has a retention policy
+func (s *RetentionService) PurgeAll(dryRun bool) ([]*domain.PurgeReport, error) {
+	var reports []*domain.PurgeReport
+	for _, tenant := range s.tenants.List() {
+		if tenant.Retention == nil {
+			continue
+		}
+		report, err := s.Purge(tenant, dryRun)
+		if err != nil {
+			return reports, fmt.Errorf("tenant %q: %w", tenant.ID, err)
+		}
+		reports = append(reports, report)
+	}
+	return reports, nil
+}
+
+// RunPeriodicPurge purges all tenants every interval until ctx is done
+func (s *RetentionService) RunPeriodicPurge(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reports, err := s.PurgeAll(false)
+			for _, report := range reports {
+				logPurgeReport(report)
+			}
+			if err != nil {
+				log.Printf("Warning: Failed to apply retention rules: %v", err)
+			}
+		}
+	}
+}
+
+// GetPurges lists the reports of a tenant's real purges
+func (s *RetentionService) GetPurges(tenant *domain.Tenant) ([]*domain.PurgeReport, error) {
+	return s.repo.GetAll(tenant.ID)
+}
+
+// logPurgeReport logs what each rule of a purge deleted
+func logPurgeReport(report *domain.PurgeReport) {
+	verb := "Deleted"
+	if report.DryRun {
+		verb = "Would delete"
+	}
+	for _, rule := range report.Rules {
+		log.Printf("Retention rule %q of tenant %s (%s, before %s): %s %d results, %d interpretations",
+			rule.Rule, report.TenantID, rule.Action, rule.Before.Format(time.DateOnly), verb, rule.Results, rule.Interpretations)
+	}
+}
+
+// retentionCutoff returns the creation time before which results match a
+// rule: the rule's age limit, or the day after the last school year ended
+func retentionCutoff(policy *domain.RetentionPolicy, rule *domain.RetentionRule, now time.Time) time.Time {
+	if !rule.AtSchoolYearEnd {
+		return now.AddDate(0, 0, -rule.AfterDays)
+	}
+
+	end, _ := parseSchoolYearEnd(policy.SchoolYearEnd)
+	cutoff := time.Date(now.Year(), end.Month(), end.Day()+1, 0, 0, 0, 0, time.Local)
+	if cutoff.After(now) {
+		cutoff = cutoff.AddDate(-1, 0, 0)
+	}
+	return cutoff
+}
+
+// parseSchoolYearEnd parses a policy's "MM-DD" school year end, defaulting
+// to domain.DefaultSchoolYearEnd
+func parseSchoolYearEnd(value string) (time.Time, error) {
+	if value == "" {
+		value = domain.DefaultSchoolYearEnd
+	}
+	return time.Parse("01-02", value)
+}
+
+// validateRetention checks that every rule of a retention policy can be applied
+func validateRetention(policy *domain.RetentionPolicy) error {
+	if _, err := parseSchoolYearEnd(policy.SchoolYearEnd); err != nil {
+		return fmt.Errorf("%w: school_year_end must be MM-DD", ErrInvalidRetention)
+	}
+
+	names := make(map[string]bool)
+	for _, rule := range policy.Rules {
+		if rule.Name == "" || names[rule.Name] {
+			return fmt.Errorf("%w: every rule needs a unique name", ErrInvalidRetention)
+		}
+		names[rule.Name] = true
+
+		switch rule.Results {
+		case "", domain.RetentionResultsAnonymous, domain.RetentionResultsCohort:
+		default:
+			return fmt.Errorf("%w: rule %q: results must be %q, %q or empty", ErrInvalidRetention, rule.Name, domain.RetentionResultsAnonymous, domain.RetentionResultsCohort)
+		}
+		switch rule.Action {
+		case domain.RetentionActionDelete, domain.RetentionActionStripInterpretations:
+		default:
+			return fmt.Errorf("%w: rule %q: action must be %q or %q", ErrInvalidRetention, rule.Name, domain.RetentionActionDelete, domain.RetentionActionStripInterpretations)
+		}
+		if rule.AfterDays < 0 || (rule.AfterDays > 0) == rule.AtSchoolYearEnd {
+			return fmt.Errorf("%w: rule %q: set either after_days or at_school_year_end", ErrInvalidRetention, rule.Name)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+var (
+	// ErrInvalidShare is returned when a share link request is invalid
+	ErrInvalidShare = errors.New("invalid share link")
+	// ErrShareInactive is returned when a share link was revoked or has expired
+	ErrShareInactive = errors.New("share link revoked or expired")
+)
+
+// tokenBytes is the amount of randomness in share, admin session and API key tokens
+const tokenBytes = 32
+
+// ShareService manages read-only share links for results
+type ShareService struct {
+	repo        *repository.ShareRepository
+	personality *PersonalityService
+}
+
+// NewShareService creates a new share service
+func NewShareService(repo *repository.ShareRepository, personality *PersonalityService) *ShareService {
+	return &ShareService{repo: repo, personality: personality}
+}
+
+// CreateShare creates a share link for a result of a tenant. The returned
+// link carries its token, which is not stored and cannot be retrieved again.
+func (s *ShareService) CreateShare(tenant *domain.Tenant, resultID string, req domain.CreateShareLinkRequest) (*domain.ShareLink, error) {
+	scope := req.Scope
+	if scope == "" {
+		scope = domain.ShareScopeScores
+	}
+	if scope != domain.ShareScopeScores && scope != domain.ShareScopeFull {
+		return nil, fmt.Errorf("%w: scope must be %q or %q", ErrInvalidShare, domain.ShareScopeScores, domain.ShareScopeFull)
+	}
+
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return nil, fmt.Errorf("%w: expires_at must be in the future", ErrInvalidShare)
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	link := &domain.ShareLink{
+		ID:        uuid.New().String(),
+		TenantID:  tenant.ID,
+		ResultID:  resultID,
+		Scope:     scope,
+		Token:     token,
+		CreatedAt: now,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if err := s.repo.Create(link, hashToken(token)); err != nil {
+		return nil, err
+	}
+	return link, nil
+}
+
+// GetShares lists the share links of a tenant's result with their access counts
+func (s *ShareService) GetShares(tenant *domain.Tenant, resultID string) ([]*domain.ShareLink, error) {
+	return s.repo.GetByResultID(tenant.ID, resultID)
+}
+
+// RevokeShare revokes a share link of a tenant's result
+func (s *ShareService) RevokeShare(tenant *domain.Tenant, resultID, shareID string) error {
+	return s.repo.Revoke(tenant.ID, shareID, resultID, time.Now())
+}
+
+// OpenShare resolves a share token of a tenant to the part of the result its
+// scope allows and counts the access
+func (s *ShareService) OpenShare(tenant *domain.Tenant, token string) (*domain.SharedResultResponse, error) {
+	link, err := s.repo.GetByTokenHash(tenant.ID, hashToken(token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !link.Active(now) {
+		return nil, ErrShareInactive
+	}
+
+	result, err := s.personality.GetResult(tenant, link.ResultID)
+	if err != nil {
+		return nil, err
+	}
+	if result == nil {
+		return nil, repository.ErrNotFound
+	}
+
+	if err := s.repo.RecordAccess(link.ID, now); err != nil {
+		return nil, err
+	}
+
+	return &domain.SharedResultResponse{
+		Scope:     link.Scope,
+		ExpiresAt: link.ExpiresAt,
+		Result:    sharedView(result, link.Scope),
+	}, nil
+}
+
+// sharedView strips a result of respondent details and, unless the scope is
+// full, of its interpretations
+func sharedView(result *domain.PersonalityResult, scope string) *domain.PersonalityResult {
+	shared := *result
+	shared.SessionID = ""
+	shared.Age = 0
+	shared.Country = ""
+	shared.Quality = nil
+	shared.StartedAt = nil
+	shared.CompletedAt = nil
+	shared.CohortID = ""
+	shared.CohortConsent = false
+	if scope != domain.ShareScopeFull {
+		shared.Interpretations = nil
+	}
+	return &shared
+}
+
+// randomToken returns a new unguessable URL-safe token
+func randomToken() (string, error) {
+	buf := make([]byte, tokenBytes)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken returns the stored form of a random bearer token
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
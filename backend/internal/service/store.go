@@ -0,0 +1,61 @@
+package service
+
+import "github.com/thielel/voca/internal/domain"
+
+// ResultStore keeps personality results with their answers, scores and
+// interpretations. repository.ResultRepository implements it for SQLite and
+// PostgreSQL and repository.MemoryResultRepository in memory; every
+// implementation must pass the storetest conformance suite, which the
+// repository package's tests run against each of them.
+//
+// Methods taking a tenant ID only see that tenant's results. Lookups of a
+// single result return repository.ErrNotFound if the tenant has no such
+// result.
+type ResultStore interface {
+	// Save stores a result and the raw answers it was calculated from
+	Save(result *domain.PersonalityResult, answers []domain.Answer) error
+	GetByID(tenantID, id string) (*domain.PersonalityResult, error)
+	// GetBySessionID returns a session's results, newest first
+	GetBySessionID(tenantID, sessionID string) ([]*domain.PersonalityResult, error)
+	// ListResults returns a page of a tenant's results matching the query
+	// with the total count, ordered by the query's sort key and then by ID.
+	// It returns repository.ErrInvalidCursor for a cursor of another order.
+	ListResults(tenantID string, query domain.ResultListQuery) (*domain.ResultPage, error)
+	// GetByCohortID returns a cohort's results, oldest first
+	GetByCohortID(tenantID, cohortID string) ([]*domain.PersonalityResult, error)
+	SetCohortConsent(tenantID, id string, consent bool) error
+
+	// SaveInterpretationVersion atomically stores interpretations as a
+	// result's next version and makes it active, keeping the previous version
+	// active if the new one cannot be stored. Traits the new version lacks
+	// keep their active interpretation, copied into it. It sets ResultID,
+	// Version and Active on the interpretations and returns the version.
+	SaveInterpretationVersion(tenantID, resultID string, interpretations []*domain.TraitInterpretation) (int, error)
+	// ActivateInterpretationVersion makes an earlier version active again. It
+	// returns repository.ErrInterpretationVersionNotFound if the result has no
+	// such version.
+	ActivateInterpretationVersion(tenantID, resultID string, version int) error
+	// GetInterpretationsByResultID returns the active version's interpretations
+	GetInterpretationsByResultID(tenantID, resultID string) (map[domain.Trait]string, error)
+	// GetInterpretationHistory returns every version's interpretations,
+	// newest version first and by trait within a version
+	GetInterpretationHistory(tenantID, resultID string) ([]*domain.TraitInterpretation, error)
+	GetByIDWithInterpretations(tenantID, id string) (*domain.PersonalityResult, error)
+	DeleteInterpretationsByResultID(tenantID, resultID string) error
+
+	// GetAnswersByResultID returns a result's raw answers by question
+	GetAnswersByResultID(tenantID, resultID string) ([]*domain.StoredAnswer, error)
+	// ScoreDistribution returns the sample size and per-scale mean and
+	// sample standard deviation of the results matching the filter,
+	// excluding protocols flagged invalid
+	ScoreDistribution(tenantID string, filter domain.NormSnapshotFilter) (int, map[domain.Trait]domain.ScaleNorm, error)
+
+	// CountResults counts what DeleteResults would delete
+	CountResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error)
+	// DeleteResults deletes the matching results with their answers, scores
+	// and every version of their interpretations, and returns the IDs of the deleted results
+	DeleteResults(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error)
+	// DeleteInterpretations deletes every version of the interpretations of
+	// the matching results, counting the results that had any
+	DeleteInterpretations(tenantID string, filter domain.ResultFilter) (*domain.ResultDeletion, error)
+}
@@ -0,0 +1,841 @@
+// Package storetest checks that a service.ResultStore behaves like the
+// SQLite result repository the services were written against, in the manner
+// of testing/fstest. Every result store must pass it:
+//
+//	if err := storetest.Run(newStore); err != nil {
+//		t.Fatal(err)
+//	}
+package storetest
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+	"github.com/thielel/voca/internal/service"
+)
+
+// check is a single conformance check run against a fresh store
+type check struct {
+	name string
+	run  func(store service.ResultStore, tenantID string) error
+}
+
+var checks = []check{
+	{"save and get", checkSaveAndGet},
+	{"not found", checkNotFound},
+	{"scores without scale rows", checkLegacyScores},
+	{"ordering", checkOrdering},
+	{"cohort consent", checkCohortConsent},
+	{"interpretations", checkInterpretations},
+	{"atomic interpretation writes", checkAtomicInterpretations},
+	{"interpretation history", checkInterpretationHistory},
+	{"score distribution", checkScoreDistribution},
+	{"result listing", checkListResults},
+	{"count and delete", checkCountAndDelete},
+}
+
+// Run runs every check against a store created by newStore and returns the
+// failures joined together, or nil if the store conforms. Each check uses a
+// tenant of its own and deletes its results afterwards, so the stores may
+// share a database with other data.
+func Run(newStore func() (service.ResultStore, error)) error {
+	var errs []error
+	for _, c := range checks {
+		store, err := newStore()
+		if err != nil {
+			return fmt.Errorf("create store: %w", err)
+		}
+
+		tenantID := "storetest-" + uuid.New().String()
+		if err := c.run(store, tenantID); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", c.name, err))
+		}
+		if _, err := store.DeleteResults(tenantID, domain.ResultFilter{}); err != nil {
+			errs = append(errs, fmt.Errorf("%s: clean up: %w", c.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// base is the creation time the checks' results are stored around. Stores
+// keep times to the second.
+func base() time.Time {
+	return time.Now().Add(-24 * time.Hour).Truncate(time.Second)
+}
+
+// newResult returns a Big Five result of a session with every scale at score
+func newResult(tenantID, sessionID string, score float64, createdAt time.Time) *domain.PersonalityResult {
+	return &domain.PersonalityResult{
+		ID:                 uuid.New().String(),
+		TenantID:           tenantID,
+		SessionID:          sessionID,
+		InstrumentID:       "ipip-50",
+		InstrumentVersion:  "1.0",
+		Extraversion:       score,
+		Agreeableness:      score,
+		Conscientiousness:  score,
+		EmotionalStability: score,
+		Openness:           score,
+		Scores: map[domain.Trait]float64{
+			domain.TraitExtraversion:       score,
+			domain.TraitAgreeableness:      score,
+			domain.TraitConscientiousness:  score,
+			domain.TraitEmotionalStability: score,
+			domain.TraitOpenness:           score,
+		},
+		CreatedAt: createdAt,
+	}
+}
+
+// answers returns n answers to questions n down to 1
+func answers(n int) []domain.Answer {
+	var list []domain.Answer
+	for q := n; q > 0; q-- {
+		list = append(list, domain.Answer{QuestionID: q, Value: q%5 + 1, LatencyMs: 1000 + q})
+	}
+	return list
+}
+
+// save stores results with answers, stopping at the first error
+func save(store service.ResultStore, results ...*domain.PersonalityResult) error {
+	for _, result := range results {
+		if err := store.Save(result, answers(3)); err != nil {
+			return fmt.Errorf("save %s: %w", result.ID, err)
+		}
+	}
+	return nil
+}
+
+// interpretations returns an interpretation of each trait for a result
+func interpretations(resultID string, traits ...domain.Trait) []*domain.TraitInterpretation {
+	var list []*domain.TraitInterpretation
+	for _, trait := range traits {
+		list = append(list, &domain.TraitInterpretation{
+			ID:             uuid.New().String(),
+			ResultID:       resultID,
+			Trait:          trait,
+			Interpretation: "about " + string(trait),
+			GeneratedAt:    time.Now(),
+		})
+	}
+	return list
+}
+
+// interpret stores an interpretation of each trait as a result's next version
+func interpret(store service.ResultStore, result *domain.PersonalityResult, traits ...domain.Trait) error {
+	_, err := store.SaveInterpretationVersion(result.TenantID, result.ID, interpretations(result.ID, traits...))
+	return err
+}
+
+// sameSecond reports whether two times show the same wall clock second
+func sameSecond(a, b time.Time) bool {
+	return a.Format(time.DateTime) == b.Format(time.DateTime)
+}
+
+// ids returns the IDs of results in order
+func ids(results []*domain.PersonalityResult) []string {
+	list := make([]string, len(results))
+	for i, result := range results {
+		list[i] = result.ID
+	}
+	return list
+}
+
+func checkSaveAndGet(store service.ResultStore, tenantID string) error {
+	startedAt := base().Add(-10 * time.Minute)
+	completedAt := base().Add(-time.Minute)
+	inconsistency := 0.75
+	result := newResult(tenantID, "session", 50, base())
+	result.Scores[domain.TraitOpenness] = 61.5
+	result.Openness = 61.5
+	result.HollandCode = "SAE"
+	result.Age = 15
+	result.Language = "en"
+	result.Country = "DE"
+	result.NormSetID = "norm-set"
+	result.Translation = "en-1"
+	result.StartedAt = &startedAt
+	result.CompletedAt = &completedAt
+	result.CohortID = "cohort"
+	result.CohortConsent = true
+	result.Quality = &domain.ResponseQuality{
+		Flag:          domain.QualitySuspect,
+		LongestRun:    7,
+		ResponseSD:    0.5,
+		Inconsistency: &inconsistency,
+		Reasons:       []string{"long run"},
+	}
+	result.Facets = []domain.FacetScore{
+		{Facet: "O2", Trait: domain.TraitOpenness, Score: 70},
+		{Facet: "E1", Trait: domain.TraitExtraversion, Score: 30},
+		{Facet: "O1", Trait: domain.TraitOpenness, Score: 55},
+	}
+	if err := store.Save(result, answers(3)); err != nil {
+		return err
+	}
+
+	got, err := store.GetByID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	switch {
+	case got.ID != result.ID, got.TenantID != tenantID, got.SessionID != result.SessionID:
+		return fmt.Errorf("got result %s of tenant %s and session %s", got.ID, got.TenantID, got.SessionID)
+	case got.InstrumentID != result.InstrumentID, got.InstrumentVersion != result.InstrumentVersion:
+		return fmt.Errorf("got instrument %s %s", got.InstrumentID, got.InstrumentVersion)
+	case got.HollandCode != result.HollandCode, got.Age != result.Age, got.Language != result.Language,
+		got.Country != result.Country, got.NormSetID != result.NormSetID, got.Translation != result.Translation:
+		return fmt.Errorf("got respondent fields %q %d %q %q %q %q", got.HollandCode, got.Age, got.Language, got.Country, got.NormSetID, got.Translation)
+	case got.CohortID != result.CohortID, !got.CohortConsent:
+		return fmt.Errorf("got cohort %q with consent %v", got.CohortID, got.CohortConsent)
+	case got.Openness != 61.5, got.Extraversion != 50:
+		return fmt.Errorf("got Big Five fields %v %v", got.Openness, got.Extraversion)
+	case !sameSecond(got.CreatedAt, result.CreatedAt):
+		return fmt.Errorf("got created at %v, want %v", got.CreatedAt, result.CreatedAt)
+	case got.StartedAt == nil || !sameSecond(*got.StartedAt, startedAt),
+		got.CompletedAt == nil || !sameSecond(*got.CompletedAt, completedAt):
+		return fmt.Errorf("got timing %v to %v", got.StartedAt, got.CompletedAt)
+	}
+	if len(got.Scores) != len(result.Scores) {
+		return fmt.Errorf("got %d scores, want %d", len(got.Scores), len(result.Scores))
+	}
+	for scale, score := range result.Scores {
+		if got.Scores[scale] != score {
+			return fmt.Errorf("got %s score %v, want %v", scale, got.Scores[scale], score)
+		}
+	}
+	if !slices.Equal(got.Facets, result.Facets) {
+		return fmt.Errorf("got facets %v, want them in stored order %v", got.Facets, result.Facets)
+	}
+	if q := got.Quality; q == nil || q.Flag != domain.QualitySuspect || q.LongestRun != 7 ||
+		q.Inconsistency == nil || *q.Inconsistency != inconsistency || !slices.Equal(q.Reasons, result.Quality.Reasons) {
+		return fmt.Errorf("got quality %+v", got.Quality)
+	}
+
+	stored, err := store.GetAnswersByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(stored) != 3 {
+		return fmt.Errorf("got %d answers, want 3", len(stored))
+	}
+	for i, answer := range stored {
+		want := domain.Answer{QuestionID: i + 1, Value: (i+1)%5 + 1, LatencyMs: 1000 + i + 1}
+		if answer.ResultID != result.ID || answer.QuestionID != want.QuestionID || answer.Value != want.Value || answer.LatencyMs != want.LatencyMs {
+			return fmt.Errorf("got answer %+v at %d, want %+v ordered by question", answer, i, want)
+		}
+	}
+
+	// Changing a returned result must not change the stored one
+	got.Scores[domain.TraitOpenness] = 0
+	again, err := store.GetByID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if again.Scores[domain.TraitOpenness] != 61.5 {
+		return errors.New("changing a returned result changed the stored result")
+	}
+	return nil
+}
+
+func checkNotFound(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 50, base())
+	if err := save(store, result); err != nil {
+		return err
+	}
+	if err := interpret(store, result, domain.TraitOpenness); err != nil {
+		return err
+	}
+	otherTenant := tenantID + "-other"
+
+	if _, err := store.GetByID(tenantID, uuid.New().String()); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("GetByID of a missing result returned %v, want ErrNotFound", err)
+	}
+	if _, err := store.GetByID(otherTenant, result.ID); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("GetByID of another tenant's result returned %v, want ErrNotFound", err)
+	}
+	if _, err := store.GetByIDWithInterpretations(otherTenant, result.ID); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("GetByIDWithInterpretations of another tenant's result returned %v, want ErrNotFound", err)
+	}
+	if err := store.SetCohortConsent(otherTenant, result.ID, true); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("SetCohortConsent of another tenant's result returned %v, want ErrNotFound", err)
+	}
+	if results, err := store.GetBySessionID(otherTenant, "session"); err != nil || len(results) != 0 {
+		return fmt.Errorf("GetBySessionID of another tenant returned %d results, %v", len(results), err)
+	}
+	if page, err := store.ListResults(otherTenant, domain.ResultListQuery{Limit: 10}); err != nil || page.Total != 0 || len(page.Results) != 0 {
+		return fmt.Errorf("ListResults of another tenant returned %+v, %v", page, err)
+	}
+	if answers, err := store.GetAnswersByResultID(otherTenant, result.ID); err != nil || len(answers) != 0 {
+		return fmt.Errorf("GetAnswersByResultID of another tenant returned %d answers, %v", len(answers), err)
+	}
+	if interpretations, err := store.GetInterpretationsByResultID(otherTenant, result.ID); err != nil || len(interpretations) != 0 {
+		return fmt.Errorf("GetInterpretationsByResultID of another tenant returned %d interpretations, %v", len(interpretations), err)
+	}
+	if err := store.DeleteInterpretationsByResultID(otherTenant, result.ID); err != nil {
+		return err
+	}
+	if deletion, err := store.DeleteResults(otherTenant, domain.ResultFilter{ID: result.ID}); err != nil || deletion.Results != 0 {
+		return fmt.Errorf("DeleteResults of another tenant deleted %v, %v", deletion, err)
+	}
+
+	got, err := store.GetByIDWithInterpretations(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(got.Interpretations) != 1 || got.CohortConsent {
+		return errors.New("another tenant changed the result")
+	}
+	return nil
+}
+
+func checkLegacyScores(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 40, base())
+	result.Scores = nil
+	if err := save(store, result); err != nil {
+		return err
+	}
+
+	got, err := store.GetByID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(got.Scores) != 5 || got.Scores[domain.TraitExtraversion] != 40 {
+		return fmt.Errorf("got scores %v, want the Big Five fields", got.Scores)
+	}
+	return nil
+}
+
+func checkOrdering(store service.ResultStore, tenantID string) error {
+	first := newResult(tenantID, "session", 50, base().Add(-2*time.Hour))
+	second := newResult(tenantID, "session", 50, base().Add(-time.Hour))
+	third := newResult(tenantID, "session", 50, base())
+	other := newResult(tenantID, "other-session", 50, base().Add(-3*time.Hour))
+	second.Quality = &domain.ResponseQuality{Flag: domain.QualitySuspect}
+	for _, result := range []*domain.PersonalityResult{first, second, third} {
+		result.CohortID = "cohort"
+	}
+	if err := save(store, second, third, other, first); err != nil {
+		return err
+	}
+
+	session, err := store.GetBySessionID(tenantID, "session")
+	if err != nil {
+		return err
+	}
+	if want := ids([]*domain.PersonalityResult{third, second, first}); !slices.Equal(ids(session), want) {
+		return fmt.Errorf("GetBySessionID returned %v, want newest first %v", ids(session), want)
+	}
+
+	all, err := store.ListResults(tenantID, domain.ResultListQuery{Limit: 10})
+	if err != nil {
+		return err
+	}
+	if want := ids([]*domain.PersonalityResult{third, second, first, other}); !slices.Equal(ids(all.Results), want) {
+		return fmt.Errorf("ListResults returned %v, want newest first %v", ids(all.Results), want)
+	}
+
+	cohort, err := store.GetByCohortID(tenantID, "cohort")
+	if err != nil {
+		return err
+	}
+	if want := ids([]*domain.PersonalityResult{first, second, third}); !slices.Equal(ids(cohort), want) {
+		return fmt.Errorf("GetByCohortID returned %v, want oldest first %v", ids(cohort), want)
+	}
+	return nil
+}
+
+func checkCohortConsent(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 50, base())
+	result.CohortID = "cohort"
+	if err := save(store, result); err != nil {
+		return err
+	}
+
+	for _, consent := range []bool{true, false} {
+		if err := store.SetCohortConsent(tenantID, result.ID, consent); err != nil {
+			return err
+		}
+		got, err := store.GetByID(tenantID, result.ID)
+		if err != nil {
+			return err
+		}
+		if got.CohortConsent != consent {
+			return fmt.Errorf("got cohort consent %v, want %v", got.CohortConsent, consent)
+		}
+	}
+	return nil
+}
+
+func checkInterpretations(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 50, base())
+	if err := save(store, result); err != nil {
+		return err
+	}
+
+	if err := interpret(store, result, domain.TraitOpenness, domain.TraitExtraversion); err != nil {
+		return err
+	}
+	interpretations, err := store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interpretations) != 2 || interpretations[domain.TraitOpenness] != "about openness" {
+		return fmt.Errorf("got interpretations %v", interpretations)
+	}
+
+	got, err := store.GetByIDWithInterpretations(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(got.Interpretations) != 2 || got.Interpretations[domain.TraitExtraversion] != "about extraversion" {
+		return fmt.Errorf("GetByIDWithInterpretations returned interpretations %v", got.Interpretations)
+	}
+	if plain, err := store.GetByID(tenantID, result.ID); err != nil || len(plain.Interpretations) != 0 {
+		return fmt.Errorf("GetByID returned interpretations %v, %v", plain.Interpretations, err)
+	}
+
+	if err := store.DeleteInterpretationsByResultID(tenantID, result.ID); err != nil {
+		return err
+	}
+	interpretations, err = store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interpretations) != 0 {
+		return fmt.Errorf("got %d interpretations after deleting them", len(interpretations))
+	}
+
+	// A result can be interpreted again after its interpretations were deleted
+	return interpret(store, result, domain.TraitOpenness)
+}
+
+func checkAtomicInterpretations(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 50, base())
+	if err := save(store, result); err != nil {
+		return err
+	}
+	if err := interpret(store, result, domain.TraitOpenness, domain.TraitExtraversion); err != nil {
+		return err
+	}
+
+	// A version that cannot be stored completely stores nothing and keeps the
+	// previous version active
+	failing := interpretations(result.ID, domain.TraitAgreeableness, domain.TraitAgreeableness)
+	if _, err := store.SaveInterpretationVersion(tenantID, result.ID, failing); err == nil {
+		return errors.New("a version interpreting a trait twice was accepted")
+	}
+	interpretations, err := store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interpretations) != 2 || interpretations[domain.TraitOpenness] != "about openness" {
+		return fmt.Errorf("got interpretations %v after a failed version, want the previous ones", interpretations)
+	}
+	if history, err := store.GetInterpretationHistory(tenantID, result.ID); err != nil || len(history) != 2 {
+		return fmt.Errorf("got %d interpretations in the history after a failed version, %v", len(history), err)
+	}
+
+	if _, err := store.SaveInterpretationVersion(tenantID+"-other", result.ID, failing[:1]); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("interpreting another tenant's result returned %v, want ErrNotFound", err)
+	}
+
+	if _, err := store.SaveInterpretationVersion(tenantID, result.ID, failing[:1]); err != nil {
+		return err
+	}
+	interpretations, err = store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interpretations) != 3 || interpretations[domain.TraitAgreeableness] != "about agreeableness" {
+		return fmt.Errorf("got interpretations %v after a new version, want it with the previous traits", interpretations)
+	}
+	return nil
+}
+
+func checkInterpretationHistory(store service.ResultStore, tenantID string) error {
+	result := newResult(tenantID, "session", 50, base())
+	if err := save(store, result); err != nil {
+		return err
+	}
+
+	first := interpretations(result.ID, domain.TraitOpenness, domain.TraitExtraversion)
+	for _, interp := range first {
+		interp.Language = "en"
+		interp.Model = "model-a"
+		interp.PromptVersion = "1"
+		interp.Temperature = 0.5
+		interp.PromptTokens = 100
+		interp.CompletionTokens = 200
+		interp.GeneratedAt = base()
+	}
+	version, err := store.SaveInterpretationVersion(tenantID, result.ID, first)
+	if err != nil {
+		return err
+	}
+	if version != 1 || first[0].Version != 1 || !first[0].Active {
+		return fmt.Errorf("first version stored as version %d (%d, active %v), want 1", version, first[0].Version, first[0].Active)
+	}
+
+	// A version lacking a trait keeps that trait's active interpretation
+	second := interpretations(result.ID, domain.TraitOpenness)
+	second[0].Interpretation = "openness again"
+	if version, err = store.SaveInterpretationVersion(tenantID, result.ID, second); err != nil {
+		return err
+	}
+	if version != 2 {
+		return fmt.Errorf("second version stored as version %d, want 2", version)
+	}
+
+	history, err := store.GetInterpretationHistory(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	var got []string
+	for _, interp := range history {
+		got = append(got, fmt.Sprintf("%d/%s/%v", interp.Version, interp.Trait, interp.Active))
+	}
+	want := []string{"2/extraversion/true", "2/openness/true", "1/extraversion/false", "1/openness/false"}
+	if !slices.Equal(got, want) {
+		return fmt.Errorf("got history %v, want %v", got, want)
+	}
+	old := history[3]
+	if old.ID != first[0].ID || old.Interpretation != "about openness" || old.Language != "en" ||
+		old.Model != "model-a" || old.PromptVersion != "1" || old.Temperature != 0.5 ||
+		old.PromptTokens != 100 || old.CompletionTokens != 200 || !sameSecond(old.GeneratedAt, base()) {
+		return fmt.Errorf("provenance was not kept: got %+v", old)
+	}
+	if kept := history[0]; kept.ID == first[1].ID || kept.Interpretation != "about extraversion" ||
+		kept.Model != "model-a" || !sameSecond(kept.GeneratedAt, base()) {
+		return fmt.Errorf("kept interpretation was not copied with its provenance: got %+v", kept)
+	}
+
+	interps, err := store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interps) != 2 || interps[domain.TraitOpenness] != "openness again" || interps[domain.TraitExtraversion] != "about extraversion" {
+		return fmt.Errorf("got interpretations %v, want version 2 with the kept extraversion", interps)
+	}
+
+	// Rolling back makes the earlier version active again
+	if err := store.ActivateInterpretationVersion(tenantID, result.ID, 1); err != nil {
+		return err
+	}
+	interps, err = store.GetInterpretationsByResultID(tenantID, result.ID)
+	if err != nil {
+		return err
+	}
+	if len(interps) != 2 || interps[domain.TraitOpenness] != "about openness" {
+		return fmt.Errorf("got interpretations %v after rolling back, want version 1", interps)
+	}
+	if history, err = store.GetInterpretationHistory(tenantID, result.ID); err != nil {
+		return err
+	}
+	if history[0].Active || history[1].Active || !history[2].Active || !history[3].Active {
+		return errors.New("rolling back did not move the active flag to version 1")
+	}
+
+	if err := store.ActivateInterpretationVersion(tenantID, result.ID, 3); !errors.Is(err, repository.ErrInterpretationVersionNotFound) {
+		return fmt.Errorf("activating a missing version returned %v, want ErrInterpretationVersionNotFound", err)
+	}
+	otherTenant := tenantID + "-other"
+	if err := store.ActivateInterpretationVersion(otherTenant, result.ID, 2); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("activating another tenant's version returned %v, want ErrNotFound", err)
+	}
+	if history, err := store.GetInterpretationHistory(otherTenant, result.ID); err != nil || len(history) != 0 {
+		return fmt.Errorf("GetInterpretationHistory of another tenant returned %d interpretations, %v", len(history), err)
+	}
+
+	// Every version counts and is deleted with the result's interpretations
+	counted, err := store.CountResults(tenantID, domain.ResultFilter{ID: result.ID})
+	if err != nil {
+		return err
+	}
+	if counted.Interpretations != 4 {
+		return fmt.Errorf("counted %d interpretations, want all 4 of both versions", counted.Interpretations)
+	}
+	deleted, err := store.DeleteInterpretations(tenantID, domain.ResultFilter{ID: result.ID})
+	if err != nil {
+		return err
+	}
+	if deleted.Results != 1 || deleted.Interpretations != 4 {
+		return fmt.Errorf("deleted %+v, want 4 interpretations of 1 result", deleted)
+	}
+	if history, err := store.GetInterpretationHistory(tenantID, result.ID); err != nil || len(history) != 0 {
+		return fmt.Errorf("got %d interpretations in the history after deleting them, %v", len(history), err)
+	}
+	return nil
+}
+
+func checkListResults(store service.ResultStore, tenantID string) error {
+	// Five results a minute apart, the first two with the same creation time
+	start := base()
+	var results []*domain.PersonalityResult
+	for i, score := range []float64{30, 70, 50, 90, 10} {
+		result := newResult(tenantID, "session", score, start.Add(time.Duration(max(i, 1))*time.Minute))
+		result.Language = "de"
+		results = append(results, result)
+	}
+	results[2].Language = "en"
+	results[3].CohortID = "cohort"
+	results[4].Quality = &domain.ResponseQuality{Flag: domain.QualitySuspect}
+	// A result stored without scale scores is sorted and filtered by its Big
+	// Five scores
+	legacy := newResult(tenantID, "session", 60, start)
+	legacy.Extraversion = 95
+	legacy.Scores = nil
+	if err := save(store, append(results, legacy)...); err != nil {
+		return err
+	}
+	if err := interpret(store, results[1], domain.TraitOpenness); err != nil {
+		return err
+	}
+
+	// Pages of two continue after each other newest first, ties broken by ID
+	newest := []*domain.PersonalityResult{results[4], results[3], results[2], results[0], results[1], legacy}
+	if results[0].ID > results[1].ID {
+		newest[3], newest[4] = results[0], results[1]
+	} else {
+		newest[3], newest[4] = results[1], results[0]
+	}
+	var listed []*domain.PersonalityResult
+	query := domain.ResultListQuery{Limit: 2}
+	for pages := 0; ; pages++ {
+		page, err := store.ListResults(tenantID, query)
+		if err != nil {
+			return err
+		}
+		if page.Total != 6 || len(page.Results) > 2 || pages > 3 {
+			return fmt.Errorf("page %d has %d of %d results", pages, len(page.Results), page.Total)
+		}
+		listed = append(listed, page.Results...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if want := ids(newest); !slices.Equal(ids(listed), want) {
+		return fmt.Errorf("pages listed %v, want newest first %v", ids(listed), want)
+	}
+
+	// A cursor only continues the order it was returned for
+	first, err := store.ListResults(tenantID, domain.ResultListQuery{Limit: 2})
+	if err != nil {
+		return err
+	}
+	if _, err := store.ListResults(tenantID, domain.ResultListQuery{Limit: 2, Ascending: true, Cursor: first.NextCursor}); !errors.Is(err, repository.ErrInvalidCursor) {
+		return fmt.Errorf("a cursor of another order returned %v, want ErrInvalidCursor", err)
+	}
+	if _, err := store.ListResults(tenantID, domain.ResultListQuery{Limit: 2, Cursor: "not a cursor"}); !errors.Is(err, repository.ErrInvalidCursor) {
+		return fmt.Errorf("a malformed cursor returned %v, want ErrInvalidCursor", err)
+	}
+
+	// Sorting by a scale lists all results
+	query = domain.ResultListQuery{Limit: 2, SortScale: domain.TraitOpenness, Ascending: true}
+	listed = nil
+	for {
+		page, err := store.ListResults(tenantID, query)
+		if err != nil {
+			return err
+		}
+		if page.Total != 6 {
+			return fmt.Errorf("sorting by openness counted %d results, want 6", page.Total)
+		}
+		listed = append(listed, page.Results...)
+		if page.NextCursor == "" {
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	lowest := []*domain.PersonalityResult{results[4], results[0], results[2], legacy, results[1], results[3]}
+	if want := ids(lowest); !slices.Equal(ids(listed), want) {
+		return fmt.Errorf("sorting by openness listed %v, want lowest first %v", ids(listed), want)
+	}
+
+	low, high := 40.0, 80.0
+	interpreted, uninterpreted := true, false
+	filters := []struct {
+		name  string
+		query domain.ResultListQuery
+		want  []*domain.PersonalityResult
+	}{
+		{"language", domain.ResultListQuery{Language: "en"}, []*domain.PersonalityResult{results[2]}},
+		{"cohort", domain.ResultListQuery{CohortID: "cohort"}, []*domain.PersonalityResult{results[3]}},
+		{"quality", domain.ResultListQuery{QualityFlag: domain.QualitySuspect}, []*domain.PersonalityResult{results[4]}},
+		{"interpreted", domain.ResultListQuery{HasInterpretations: &interpreted}, []*domain.PersonalityResult{results[1]}},
+		{"uninterpreted", domain.ResultListQuery{HasInterpretations: &uninterpreted, Language: "de", CohortID: "cohort"}, []*domain.PersonalityResult{results[3]}},
+		{"date range", domain.ResultListQuery{From: ptr(start.Add(2 * time.Minute)), To: ptr(start.Add(4 * time.Minute))}, []*domain.PersonalityResult{results[3], results[2]}},
+		{"score range", domain.ResultListQuery{Scores: []domain.ScoreRange{{Scale: domain.TraitOpenness, Min: &low, Max: &high}}}, []*domain.PersonalityResult{results[2], results[1], legacy}},
+		{"open score range", domain.ResultListQuery{Scores: []domain.ScoreRange{{Scale: domain.TraitExtraversion, Min: &high}}}, []*domain.PersonalityResult{results[3], legacy}},
+	}
+	for _, filter := range filters {
+		filter.query.Limit = 10
+		page, err := store.ListResults(tenantID, filter.query)
+		if err != nil {
+			return fmt.Errorf("%s: %w", filter.name, err)
+		}
+		if want := ids(filter.want); !slices.Equal(ids(page.Results), want) || page.Total != len(want) {
+			return fmt.Errorf("%s filter listed %v of %d, want %v", filter.name, ids(page.Results), page.Total, want)
+		}
+	}
+	return nil
+}
+
+// ptr returns a pointer to a copy of a time
+func ptr(t time.Time) *time.Time {
+	return &t
+}
+
+func checkScoreDistribution(store service.ResultStore, tenantID string) error {
+	var results []*domain.PersonalityResult
+	for i, score := range []float64{40, 50, 60} {
+		result := newResult(tenantID, "session", score, base().Add(time.Duration(i)*time.Minute))
+		result.Language = "en"
+		result.Age = 14 + i
+		results = append(results, result)
+	}
+	// A result stored without scale scores counts with its Big Five scores
+	results[0].Scores = nil
+	invalid := newResult(tenantID, "session", 100, base())
+	invalid.Language = "en"
+	invalid.Quality = &domain.ResponseQuality{Flag: domain.QualityInvalid}
+	german := newResult(tenantID, "session", 100, base())
+	german.Language = "de"
+	old := newResult(tenantID, "session", 100, base().Add(-48*time.Hour))
+	old.Language = "en"
+	otherInstrument := newResult(tenantID, "session", 100, base())
+	otherInstrument.InstrumentID = "riasec"
+	otherInstrument.Language = "en"
+	if err := save(store, append(results, invalid, german, old, otherInstrument)...); err != nil {
+		return err
+	}
+
+	from := base().Add(-time.Hour)
+	n, scales, err := store.ScoreDistribution(tenantID, domain.NormSnapshotFilter{InstrumentID: "ipip-50", Language: "en", From: &from})
+	if err != nil {
+		return err
+	}
+	norm := scales[domain.TraitExtraversion]
+	if n != 3 || len(scales) != 5 || math.Abs(norm.Mean-50) > 1e-6 || math.Abs(norm.SD-10) > 1e-6 {
+		return fmt.Errorf("got n=%d with %d scales and extraversion %+v, want n=3, 5 scales, mean 50 and SD 10", n, len(scales), norm)
+	}
+
+	to := base().Add(2 * time.Minute)
+	n, _, err = store.ScoreDistribution(tenantID, domain.NormSnapshotFilter{InstrumentID: "ipip-50", Language: "en", AgeMin: 15, AgeMax: 16, From: &from, To: &to})
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return fmt.Errorf("got n=%d for ages 15-16 before %v, want 1", n, to)
+	}
+
+	n, _, err = store.ScoreDistribution(tenantID+"-other", domain.NormSnapshotFilter{InstrumentID: "ipip-50"})
+	if err != nil {
+		return err
+	}
+	if n != 0 {
+		return fmt.Errorf("got n=%d for another tenant, want 0", n)
+	}
+	return nil
+}
+
+func checkCountAndDelete(store service.ResultStore, tenantID string) error {
+	cutoff := base().Add(-5 * 24 * time.Hour)
+	oldAnonymous := newResult(tenantID, "session-a", 50, cutoff.Add(-24*time.Hour))
+	oldCohort := newResult(tenantID, "session-b", 50, cutoff.Add(-time.Hour))
+	oldCohort.CohortID = "cohort"
+	recent := newResult(tenantID, "session-a", 50, base())
+	other := newResult(tenantID+"-other", "session-a", 50, cutoff.Add(-24*time.Hour))
+	if err := save(store, oldAnonymous, oldCohort, recent, other); err != nil {
+		return err
+	}
+	if err := interpret(store, oldAnonymous, domain.TraitOpenness, domain.TraitExtraversion); err != nil {
+		return err
+	}
+	if err := interpret(store, recent, domain.TraitOpenness); err != nil {
+		return err
+	}
+	defer store.DeleteResults(other.TenantID, domain.ResultFilter{})
+
+	counts := []struct {
+		filter                            domain.ResultFilter
+		results, answers, interpretations int
+	}{
+		{domain.ResultFilter{}, 3, 9, 3},
+		{domain.ResultFilter{CreatedBefore: &cutoff}, 2, 6, 2},
+		{domain.ResultFilter{CreatedBefore: &cutoff, Results: domain.RetentionResultsAnonymous}, 1, 3, 2},
+		{domain.ResultFilter{Results: domain.RetentionResultsCohort}, 1, 3, 0},
+		{domain.ResultFilter{WithInterpretations: true}, 2, 6, 3},
+		{domain.ResultFilter{SessionID: "session-a"}, 2, 6, 3},
+		{domain.ResultFilter{ID: recent.ID}, 1, 3, 1},
+	}
+	for _, c := range counts {
+		deletion, err := store.CountResults(tenantID, c.filter)
+		if err != nil {
+			return err
+		}
+		if deletion.Results != c.results || deletion.Answers != c.answers || deletion.Interpretations != c.interpretations {
+			return fmt.Errorf("CountResults(%+v) returned %+v, want %d results, %d answers, %d interpretations",
+				c.filter, deletion, c.results, c.answers, c.interpretations)
+		}
+	}
+
+	// Stripping interpretations keeps the results
+	stripped, err := store.DeleteInterpretations(tenantID, domain.ResultFilter{CreatedBefore: &cutoff})
+	if err != nil {
+		return err
+	}
+	if stripped.Results != 1 || stripped.Interpretations != 2 {
+		return fmt.Errorf("DeleteInterpretations returned %+v, want 1 result and 2 interpretations", stripped)
+	}
+	if got, err := store.GetByIDWithInterpretations(tenantID, oldAnonymous.ID); err != nil || len(got.Interpretations) != 0 {
+		return fmt.Errorf("stripped result has interpretations or is gone: %v", err)
+	}
+	if got, err := store.GetByIDWithInterpretations(tenantID, recent.ID); err != nil || len(got.Interpretations) != 1 {
+		return fmt.Errorf("interpretations of a recent result were deleted: %v", err)
+	}
+
+	deleted, err := store.DeleteResults(tenantID, domain.ResultFilter{CreatedBefore: &cutoff, Results: domain.RetentionResultsCohort})
+	if err != nil {
+		return err
+	}
+	if !slices.Equal(deleted.ResultIDs, []string{oldCohort.ID}) || deleted.Results != 1 || deleted.Answers != 3 {
+		return fmt.Errorf("DeleteResults of old cohort results returned %+v, want result %s with 3 answers", deleted, oldCohort.ID)
+	}
+	if _, err := store.GetByID(tenantID, oldCohort.ID); !errors.Is(err, repository.ErrNotFound) {
+		return fmt.Errorf("GetByID of a deleted result returned %v, want ErrNotFound", err)
+	}
+	if answers, err := store.GetAnswersByResultID(tenantID, oldCohort.ID); err != nil || len(answers) != 0 {
+		return fmt.Errorf("a deleted result has %d answers, %v", len(answers), err)
+	}
+
+	deleted, err = store.DeleteResults(tenantID, domain.ResultFilter{SessionID: "session-a"})
+	if err != nil {
+		return err
+	}
+	slices.Sort(deleted.ResultIDs)
+	want := []string{oldAnonymous.ID, recent.ID}
+	slices.Sort(want)
+	if !slices.Equal(deleted.ResultIDs, want) || deleted.Results != 2 || deleted.Answers != 6 || deleted.Interpretations != 1 {
+		return fmt.Errorf("DeleteResults of a session returned %+v, want results %v with 6 answers and 1 interpretation", deleted, want)
+	}
+
+	deleted, err = store.DeleteResults(tenantID, domain.ResultFilter{})
+	if err != nil {
+		return err
+	}
+	if deleted.Results != 0 || deleted.ResultIDs == nil {
+		return fmt.Errorf("DeleteResults with nothing left returned %+v, want no results and an empty ID list", deleted)
+	}
+	if _, err := store.GetByID(other.TenantID, other.ID); err != nil {
+		return fmt.Errorf("deleting a tenant's results deleted another tenant's: %v", err)
+	}
+	return nil
+}
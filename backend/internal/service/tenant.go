@@ -0,0 +1,132 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strings"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+var (
+	// ErrUnknownTenant is returned when a tenant is not configured
+	ErrUnknownTenant = errors.New("unknown tenant")
+	// ErrInterpretationsDisabled is returned when AI interpretations are
+	// requested for a tenant that turned them off
+	ErrInterpretationsDisabled = errors.New("AI interpretations are disabled for this tenant")
+)
+
+// tenantIDPattern restricts tenant IDs to URL- and header-friendly identifiers
+var tenantIDPattern = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,63}$`)
+
+// TenantRegistry holds the configured tenants and the hosts they are served
+// on. Tenants are registered at startup; the registry is read-only afterwards.
+type TenantRegistry struct {
+	tenants map[string]*domain.Tenant
+	hosts   map[string]*domain.Tenant
+	order   []string
+}
+
+// NewTenantRegistry creates a registry containing the given tenants. The
+// default tenant must be among them, since it owns all data stored before
+// tenants existed and serves requests that name no tenant.
+func NewTenantRegistry(instruments *InstrumentRegistry, tenants ...*domain.Tenant) (*TenantRegistry, error) {
+	r := &TenantRegistry{
+		tenants: make(map[string]*domain.Tenant),
+		hosts:   make(map[string]*domain.Tenant),
+	}
+	for _, tenant := range tenants {
+		if err := r.register(instruments, tenant); err != nil {
+			return nil, err
+		}
+	}
+	if _, ok := r.tenants[domain.DefaultTenantID]; !ok {
+		return nil, fmt.Errorf("tenant configuration must contain the %q tenant", domain.DefaultTenantID)
+	}
+	return r, nil
+}
+
+// register validates a tenant and adds it to the registry
+func (r *TenantRegistry) register(instruments *InstrumentRegistry, tenant *domain.Tenant) error {
+	if !tenantIDPattern.MatchString(tenant.ID) {
+		return fmt.Errorf("tenant %q: id must be lowercase letters, digits, '_' or '-'", tenant.ID)
+	}
+	if _, exists := r.tenants[tenant.ID]; exists {
+		return fmt.Errorf("tenant %q: configured twice", tenant.ID)
+	}
+	for _, id := range tenant.Instruments {
+		if _, err := instruments.Get(id); err != nil {
+			return fmt.Errorf("tenant %q: %w %q", tenant.ID, err, id)
+		}
+	}
+	if tenant.Retention != nil {
+		if err := validateRetention(tenant.Retention); err != nil {
+			return fmt.Errorf("tenant %q: %w", tenant.ID, err)
+		}
+	}
+	for _, host := range tenant.Hosts {
+		host = normalizeHost(host)
+		if other, exists := r.hosts[host]; exists {
+			return fmt.Errorf("tenant %q: host %s is already served by tenant %q", tenant.ID, host, other.ID)
+		}
+		r.hosts[host] = tenant
+	}
+
+	r.tenants[tenant.ID] = tenant
+	r.order = append(r.order, tenant.ID)
+	return nil
+}
+
+// Get returns the tenant with the given ID
+func (r *TenantRegistry) Get(id string) (*domain.Tenant, error) {
+	tenant, exists := r.tenants[id]
+	if !exists {
+		return nil, ErrUnknownTenant
+	}
+	return tenant, nil
+}
+
+// ForHost returns the tenant served on a request host, or the default tenant
+// if the host is not mapped to any tenant
+func (r *TenantRegistry) ForHost(host string) *domain.Tenant {
+	if tenant, exists := r.hosts[normalizeHost(host)]; exists {
+		return tenant
+	}
+	return r.tenants[domain.DefaultTenantID]
+}
+
+// List returns all tenants in configuration order
+func (r *TenantRegistry) List() []*domain.Tenant {
+	tenants := make([]*domain.Tenant, 0, len(r.order))
+	for _, id := range r.order {
+		tenants = append(tenants, r.tenants[id])
+	}
+	return tenants
+}
+
+// normalizeHost lowercases a host and strips its port
+func normalizeHost(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		host = h
+	}
+	return strings.ToLower(strings.TrimSpace(host))
+}
+
+// tenantInstrument returns an instrument a tenant's respondents may take.
+// An empty id means the default instrument, or the tenant's first enabled
+// instrument if the default one is not enabled.
+func tenantInstrument(instruments *InstrumentRegistry, tenant *domain.Tenant, id string) (*domain.Instrument, error) {
+	if id == "" && !tenant.InstrumentEnabled(domain.DefaultInstrumentID) {
+		id = tenant.Instruments[0]
+	}
+	instrument, err := instruments.Get(id)
+	if err != nil {
+		return nil, err
+	}
+	if !tenant.InstrumentEnabled(instrument.ID) {
+		return nil, ErrUnknownInstrument
+	}
+	return instrument, nil
+}
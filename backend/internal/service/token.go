@@ -0,0 +1,95 @@
+package service
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/thielel/voca/internal/domain"
+)
+
+var (
+	// ErrInvalidToken is returned for malformed or forged session tokens
+	ErrInvalidToken = errors.New("invalid session token")
+	// ErrTokenExpired is returned for session tokens past their expiry
+	ErrTokenExpired = errors.New("session token expired")
+)
+
+// TokenService mints and verifies signed session tokens. A token is
+// base64url(payload) "." base64url(HMAC-SHA256(payload)), where the payload
+// holds the session ID and expiry.
+type TokenService struct {
+	secret []byte
+	ttl    time.Duration
+}
+
+// tokenPayload is the signed content of a session token
+type tokenPayload struct {
+	SessionID string `json:"sid"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// NewTokenService creates a token service signing with secret. Tokens are
+// valid for ttl after they are issued.
+func NewTokenService(secret []byte, ttl time.Duration) *TokenService {
+	return &TokenService{secret: secret, ttl: ttl}
+}
+
+// Issue mints a token for a new session
+func (s *TokenService) Issue() (*domain.SessionToken, error) {
+	payload := tokenPayload{
+		SessionID: uuid.New().String(),
+		ExpiresAt: time.Now().Add(s.ttl).Unix(),
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	encoded := base64.RawURLEncoding.EncodeToString(data)
+	return &domain.SessionToken{
+		Token:     encoded + "." + base64.RawURLEncoding.EncodeToString(s.sign(encoded)),
+		SessionID: payload.SessionID,
+		ExpiresAt: time.Unix(payload.ExpiresAt, 0).UTC(),
+	}, nil
+}
+
+// Verify checks a token's signature and expiry and returns its session ID
+func (s *TokenService) Verify(token string) (string, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return "", ErrInvalidToken
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, s.sign(encoded)) {
+		return "", ErrInvalidToken
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", ErrInvalidToken
+	}
+	var payload tokenPayload
+	if err := json.Unmarshal(data, &payload); err != nil || payload.SessionID == "" {
+		return "", ErrInvalidToken
+	}
+
+	if time.Now().Unix() >= payload.ExpiresAt {
+		return "", ErrTokenExpired
+	}
+	return payload.SessionID, nil
+}
+
+// sign computes the HMAC of an encoded payload
+func (s *TokenService) sign(encoded string) []byte {
+	mac := hmac.New(sha256.New, s.secret)
+	mac.Write([]byte(encoded))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,87 @@
+package service
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thielel/voca/internal/domain"
+	"github.com/thielel/voca/internal/repository"
+)
+
+// issue mints a token or fails the test
+func issue(t *testing.T, tokens *TokenService) *domain.SessionToken {
+	t.Helper()
+	token, err := tokens.Issue()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestTokenServiceVerify(t *testing.T) {
+	tokens := NewTokenService([]byte("secret"), time.Hour)
+	token := issue(t, tokens)
+	other := issue(t, tokens)
+	payload, signature, _ := strings.Cut(token.Token, ".")
+	otherPayload, _, _ := strings.Cut(other.Token, ".")
+
+	tests := []struct {
+		name    string
+		token   string
+		want    string
+		wantErr error
+	}{
+		{"valid", token.Token, token.SessionID, nil},
+		{"other session", other.Token, other.SessionID, nil},
+		{"signed with another secret", issue(t, NewTokenService([]byte("other"), time.Hour)).Token, "", ErrInvalidToken},
+		{"payload of another session", otherPayload + "." + signature, "", ErrInvalidToken},
+		{"forged payload", base64.RawURLEncoding.EncodeToString([]byte(`{"sid":"forged","exp":9999999999}`)) + "." + signature, "", ErrInvalidToken},
+		{"forged signature", payload + "." + base64.RawURLEncoding.EncodeToString([]byte("forged")), "", ErrInvalidToken},
+		{"signature not base64", payload + ".!!", "", ErrInvalidToken},
+		{"unsigned", payload, "", ErrInvalidToken},
+		{"empty", "", "", ErrInvalidToken},
+		{"expired", issue(t, NewTokenService([]byte("secret"), -time.Second)).Token, "", ErrTokenExpired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sessionID, err := tokens.Verify(tt.token)
+			if !errors.Is(err, tt.wantErr) || sessionID != tt.want {
+				t.Errorf("Verify() = %q, %v, want %q, %v", sessionID, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckOwner(t *testing.T) {
+	repo := repository.NewMemoryResultRepository()
+	svc := NewPersonalityService(repo, nil, NewDefaultInstrumentRegistry(), NewNormRegistry(), nil, nil, false)
+	tenant := &domain.Tenant{ID: "default"}
+	if err := repo.Save(&domain.PersonalityResult{ID: "result", TenantID: tenant.ID, SessionID: "owner"}, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		tenant    *domain.Tenant
+		resultID  string
+		sessionID string
+		wantErr   error
+	}{
+		{"owner", tenant, "result", "owner", nil},
+		{"other session", tenant, "result", "other", ErrNotOwner},
+		{"unknown result", tenant, "missing", "owner", repository.ErrNotFound},
+		{"other tenant", &domain.Tenant{ID: "other"}, "result", "owner", repository.ErrNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := svc.CheckOwner(tt.tenant, tt.resultID, tt.sessionID); !errors.Is(err, tt.wantErr) {
+				t.Errorf("CheckOwner() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
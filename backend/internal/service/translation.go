@@ -0,0 +1,144 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// ErrUnknownTranslation is returned when a translation key is not registered
+var ErrUnknownTranslation = errors.New("unknown translation")
+
+// TranslationRegistry holds the item translations available for each
+// instrument. Translations are registered at startup; the registry is
+// read-only afterwards.
+type TranslationRegistry struct {
+	instruments  *InstrumentRegistry
+	translations map[string]*domain.Translation
+	current      map[string]*domain.Translation
+}
+
+// NewTranslationRegistry creates a registry containing the given translations.
+// For each instrument and language, the last registered version is served to
+// clients.
+func NewTranslationRegistry(instruments *InstrumentRegistry, translations ...*domain.Translation) (*TranslationRegistry, error) {
+	r := &TranslationRegistry{
+		instruments:  instruments,
+		translations: make(map[string]*domain.Translation),
+		current:      make(map[string]*domain.Translation),
+	}
+	for _, translation := range translations {
+		if err := r.Register(translation); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Register validates a translation against its instrument and makes it the
+// current version for its language
+func (r *TranslationRegistry) Register(translation *domain.Translation) error {
+	instrument, err := r.instruments.Get(translation.InstrumentID)
+	if err != nil {
+		return fmt.Errorf("translation %s: %w", translation.Key(), err)
+	}
+
+	for _, item := range instrument.Items {
+		if strings.TrimSpace(translation.Items[item.ID]) == "" {
+			return fmt.Errorf("translation %s: missing item %d", translation.Key(), item.ID)
+		}
+	}
+	for id := range translation.Items {
+		if _, ok := findItem(instrument, id); !ok {
+			return fmt.Errorf("translation %s: unknown item %d", translation.Key(), id)
+		}
+	}
+	for value := instrument.MinValue; value <= instrument.MaxValue; value++ {
+		if strings.TrimSpace(translation.Options[value]) == "" {
+			return fmt.Errorf("translation %s: missing response option %d", translation.Key(), value)
+		}
+	}
+
+	r.translations[translation.Key()] = translation
+	r.current[currentKey(translation.InstrumentID, translation.Language)] = translation
+	return nil
+}
+
+// Get returns the translation with the given key (instrument/language@version)
+func (r *TranslationRegistry) Get(key string) (*domain.Translation, error) {
+	translation, ok := r.translations[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownTranslation, key)
+	}
+	return translation, nil
+}
+
+// Current returns the translation served for an instrument in a language, or nil
+func (r *TranslationRegistry) Current(instrumentID, language string) *domain.Translation {
+	return r.current[currentKey(instrumentID, baseLanguage(language))]
+}
+
+// Negotiate returns the current translation for the first of the preferred
+// languages that has one. It returns nil when the source wording should be
+// used, either because it was preferred or because no translation matched.
+func (r *TranslationRegistry) Negotiate(instrumentID string, languages []string) *domain.Translation {
+	for _, language := range languages {
+		if translation := r.Current(instrumentID, language); translation != nil {
+			return translation
+		}
+		if baseLanguage(language) == domain.SourceLanguage {
+			return nil
+		}
+	}
+	return nil
+}
+
+// Localize returns the instrument's items with the translation's wording
+func Localize(instrument *domain.Instrument, translation *domain.Translation) []domain.Question {
+	if translation == nil {
+		return instrument.Items
+	}
+	questions := make([]domain.Question, len(instrument.Items))
+	for i, item := range instrument.Items {
+		questions[i] = item
+		questions[i].Text = translation.Items[item.ID]
+	}
+	return questions
+}
+
+// ResponseOptions returns the labelled answer scale of a translation, or nil
+func ResponseOptions(instrument *domain.Instrument, translation *domain.Translation) []domain.ResponseOption {
+	if translation == nil {
+		return nil
+	}
+	var options []domain.ResponseOption
+	for value := instrument.MinValue; value <= instrument.MaxValue; value++ {
+		options = append(options, domain.ResponseOption{Value: value, Label: translation.Options[value]})
+	}
+	return options
+}
+
+// findItem looks up an item of an instrument by ID
+func findItem(instrument *domain.Instrument, id int) (domain.Question, bool) {
+	for _, item := range instrument.Items {
+		if item.ID == id {
+			return item, true
+		}
+	}
+	return domain.Question{}, false
+}
+
+func currentKey(instrumentID, language string) string {
+	return instrumentID + "/" + language
+}
+
+// baseLanguage reduces a language tag such as "tr-TR" to its primary subtag
+func baseLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if i := strings.IndexAny(language, "-_"); i >= 0 {
+		language = language[:i]
+	}
+	return language
+}
@@ -0,0 +1,153 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+const (
+	// Share of all items that must be answered before a result is scored
+	minCompletionRatio = 0.9
+	// Share of a trait's items that must be answered for its score to be stable
+	minTraitCompletionRatio = 0.7
+)
+
+// Validation issue codes reported to clients
+const (
+	IssueUnknownQuestion   = "unknown_question"
+	IssueOutOfRange        = "out_of_range"
+	IssueDuplicateAnswer   = "duplicate_answer"
+	IssueMissingTraitItems = "missing_trait_items"
+	IssueIncomplete        = "incomplete"
+)
+
+// ValidationError reports every problem found in a set of submitted answers
+type ValidationError struct {
+	Issues []domain.ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	codes := make([]string, len(e.Issues))
+	for i, issue := range e.Issues {
+		codes[i] = issue.Code
+	}
+	return fmt.Sprintf("invalid answers: %s", strings.Join(codes, ", "))
+}
+
+// ValidateAnswers checks submitted answers against an instrument and
+// returns a *ValidationError listing all problems, or nil if they can be scored
+func ValidateAnswers(answers []domain.Answer, instrument *domain.Instrument) error {
+	questions := instrument.Items
+	traitItems := make(map[domain.Trait][]int)
+	var traitOrder []domain.Trait
+	for _, q := range questions {
+		if _, ok := traitItems[q.Trait]; !ok {
+			traitOrder = append(traitOrder, q.Trait)
+		}
+		traitItems[q.Trait] = append(traitItems[q.Trait], q.ID)
+	}
+
+	issues, answered := validateItems(answers, instrument)
+
+	for _, trait := range traitOrder {
+		items := traitItems[trait]
+		var missing []int
+		for _, id := range items {
+			if !answered[id] {
+				missing = append(missing, id)
+			}
+		}
+
+		required := requiredItems(len(items), minTraitCompletionRatio)
+		if len(items)-len(missing) < required {
+			issues = append(issues, domain.ValidationIssue{
+				Code:               IssueMissingTraitItems,
+				Trait:              trait,
+				MissingQuestionIDs: missing,
+				Message: fmt.Sprintf("%s has %d of %d items answered, at least %d required",
+					trait, len(items)-len(missing), len(items), required),
+			})
+		}
+	}
+
+	required := requiredItems(len(questions), minCompletionRatio)
+	if len(answered) < required {
+		issues = append(issues, domain.ValidationIssue{
+			Code: IssueIncomplete,
+			Message: fmt.Sprintf("%d of %d questions answered, at least %d required",
+				len(answered), len(questions), required),
+		})
+	}
+
+	if len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// ValidatePartialAnswers checks each answer of an incomplete answer set against
+// an instrument without requiring completeness, as for a saved draft
+func ValidatePartialAnswers(answers []domain.Answer, instrument *domain.Instrument) error {
+	if issues, _ := validateItems(answers, instrument); len(issues) > 0 {
+		return &ValidationError{Issues: issues}
+	}
+	return nil
+}
+
+// validateItems reports unknown, duplicate and out-of-range answers and
+// returns the set of validly answered question IDs
+func validateItems(answers []domain.Answer, instrument *domain.Instrument) ([]domain.ValidationIssue, map[int]bool) {
+	questionMap := make(map[int]domain.Question)
+	for _, q := range instrument.Items {
+		questionMap[q.ID] = q
+	}
+
+	var issues []domain.ValidationIssue
+	seen := make(map[int]bool)
+	answered := make(map[int]bool)
+	for _, answer := range answers {
+		question, exists := questionMap[answer.QuestionID]
+		if !exists {
+			issues = append(issues, domain.ValidationIssue{
+				Code:       IssueUnknownQuestion,
+				QuestionID: answer.QuestionID,
+				Message:    fmt.Sprintf("question %d does not exist", answer.QuestionID),
+			})
+			continue
+		}
+
+		if seen[answer.QuestionID] {
+			issues = append(issues, domain.ValidationIssue{
+				Code:       IssueDuplicateAnswer,
+				QuestionID: answer.QuestionID,
+				Trait:      question.Trait,
+				Message:    fmt.Sprintf("question %d was answered more than once", answer.QuestionID),
+			})
+			continue
+		}
+		seen[answer.QuestionID] = true
+
+		if answer.Value < instrument.MinValue || answer.Value > instrument.MaxValue {
+			issues = append(issues, domain.ValidationIssue{
+				Code:       IssueOutOfRange,
+				QuestionID: answer.QuestionID,
+				Trait:      question.Trait,
+				Message: fmt.Sprintf("value %d for question %d is outside %d-%d",
+					answer.Value, answer.QuestionID, instrument.MinValue, instrument.MaxValue),
+			})
+			continue
+		}
+
+		answered[answer.QuestionID] = true
+	}
+
+	return issues, answered
+}
+
+// requiredItems returns the minimum number of answered items for a completion ratio
+func requiredItems(total int, ratio float64) int {
+	return int(math.Ceil(float64(total) * ratio))
+}
@@ -0,0 +1,167 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/thielel/voca/internal/domain"
+)
+
+// answerAll answers every item of an instrument with a neutral value except
+// the skipped ones
+func answerAll(instrument *domain.Instrument, skip ...int) []domain.Answer {
+	var answers []domain.Answer
+	for _, item := range instrument.Items {
+		if !slices.Contains(skip, item.ID) {
+			answers = append(answers, domain.Answer{QuestionID: item.ID, Value: 3})
+		}
+	}
+	return answers
+}
+
+// traitItems returns the IDs of an instrument's items of a trait
+func traitItems(instrument *domain.Instrument, trait domain.Trait) []int {
+	var ids []int
+	for _, item := range instrument.Items {
+		if item.Trait == trait {
+			ids = append(ids, item.ID)
+		}
+	}
+	return ids
+}
+
+// issueKeys describes validation issues as code/question/trait for comparison
+func issueKeys(err error) []string {
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		return nil
+	}
+	keys := make([]string, len(validationErr.Issues))
+	for i, issue := range validationErr.Issues {
+		keys[i] = fmt.Sprintf("%s/%d/%s", issue.Code, issue.QuestionID, issue.Trait)
+	}
+	return keys
+}
+
+func TestValidateAnswers(t *testing.T) {
+	instrument := domain.IPIP50()
+	extraversion := traitItems(instrument, domain.TraitExtraversion)
+
+	// Two items of each trait, 10 of 50 in all
+	var spread []int
+	for _, scale := range instrument.Scales {
+		spread = append(spread, traitItems(instrument, scale.ID)[:2]...)
+	}
+
+	tests := []struct {
+		name    string
+		answers []domain.Answer
+		want    []string
+	}{
+		{
+			name:    "complete",
+			answers: answerAll(instrument),
+		},
+		{
+			name:    "duplicate",
+			answers: append(answerAll(instrument), domain.Answer{QuestionID: 1, Value: 4}),
+			want:    []string{"duplicate_answer/1/" + string(instrument.Items[0].Trait)},
+		},
+		{
+			name:    "out of range",
+			answers: append(answerAll(instrument, 1), domain.Answer{QuestionID: 1, Value: 9}),
+			want:    []string{"out_of_range/1/" + string(instrument.Items[0].Trait)},
+		},
+		{
+			name: "duplicate of out of range",
+			answers: append([]domain.Answer{{QuestionID: 1, Value: 9}},
+				append(answerAll(instrument, 1), domain.Answer{QuestionID: 1, Value: 3})...),
+			want: []string{
+				"out_of_range/1/" + string(instrument.Items[0].Trait),
+				"duplicate_answer/1/" + string(instrument.Items[0].Trait),
+			},
+		},
+		{
+			name:    "below minimum",
+			answers: append(answerAll(instrument, 1), domain.Answer{QuestionID: 1, Value: 0}),
+			want:    []string{"out_of_range/1/" + string(instrument.Items[0].Trait)},
+		},
+		{
+			name:    "unknown question",
+			answers: append(answerAll(instrument), domain.Answer{QuestionID: 999, Value: 3}),
+			want:    []string{"unknown_question/999/"},
+		},
+		{
+			name:    "trait incomplete",
+			answers: answerAll(instrument, extraversion[:4]...),
+			want:    []string{"missing_trait_items/0/" + string(domain.TraitExtraversion)},
+		},
+		{
+			name:    "incomplete",
+			answers: answerAll(instrument, spread...),
+			want:    []string{"incomplete/0/"},
+		},
+		{
+			name:    "empty",
+			answers: nil,
+			want: []string{
+				"missing_trait_items/0/" + string(instrument.Scales[0].ID),
+				"missing_trait_items/0/" + string(instrument.Scales[1].ID),
+				"missing_trait_items/0/" + string(instrument.Scales[2].ID),
+				"missing_trait_items/0/" + string(instrument.Scales[3].ID),
+				"missing_trait_items/0/" + string(instrument.Scales[4].ID),
+				"incomplete/0/",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAnswers(tt.answers, instrument)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidateAnswers() = %v, want nil", err)
+				}
+				return
+			}
+			if got := issueKeys(err); !slices.Equal(got, tt.want) {
+				t.Errorf("ValidateAnswers() issues = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidatePartialAnswers(t *testing.T) {
+	instrument := domain.IPIP50()
+	trait := string(instrument.Items[0].Trait)
+
+	tests := []struct {
+		name    string
+		answers []domain.Answer
+		want    []string
+	}{
+		{"partial", []domain.Answer{{QuestionID: 1, Value: 3}, {QuestionID: 2, Value: 5}}, nil},
+		{"empty", nil, nil},
+		{"out of range", []domain.Answer{{QuestionID: 1, Value: 6}}, []string{"out_of_range/1/" + trait}},
+		{"duplicate of out of range", []domain.Answer{{QuestionID: 1, Value: 9}, {QuestionID: 1, Value: 3}},
+			[]string{"out_of_range/1/" + trait, "duplicate_answer/1/" + trait}},
+		{"unknown question", []domain.Answer{{QuestionID: -1, Value: 3}}, []string{"unknown_question/-1/"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePartialAnswers(tt.answers, instrument)
+			if tt.want == nil {
+				if err != nil {
+					t.Fatalf("ValidatePartialAnswers() = %v, want nil", err)
+				}
+				return
+			}
+			if got := issueKeys(err); !slices.Equal(got, tt.want) {
+				t.Errorf("ValidatePartialAnswers() issues = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
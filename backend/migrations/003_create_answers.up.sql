@@ -0,0 +1,11 @@
+-- Create answers table for SQLite
+CREATE TABLE IF NOT EXISTS answers (
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    question_id INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    answered_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Create index on result_id for faster lookups
+CREATE INDEX IF NOT EXISTS idx_answers_result_id 
+ON answers(result_id);
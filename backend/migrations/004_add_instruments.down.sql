@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS result_scores;
+
+ALTER TABLE personality_results DROP COLUMN instrument_version;
+ALTER TABLE personality_results DROP COLUMN instrument_id;
@@ -0,0 +1,11 @@
+-- Record which instrument produced each result
+ALTER TABLE personality_results ADD COLUMN instrument_id TEXT NOT NULL DEFAULT 'ipip-50';
+ALTER TABLE personality_results ADD COLUMN instrument_version TEXT NOT NULL DEFAULT '1.0';
+
+-- Create result_scores table for SQLite (one row per scale)
+CREATE TABLE IF NOT EXISTS result_scores (
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    scale TEXT NOT NULL,
+    score REAL NOT NULL,
+    PRIMARY KEY (result_id, scale)
+);
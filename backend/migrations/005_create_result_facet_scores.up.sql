@@ -0,0 +1,8 @@
+-- Create result_facet_scores table for SQLite (one row per facet)
+CREATE TABLE IF NOT EXISTS result_facet_scores (
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    facet TEXT NOT NULL,
+    trait TEXT NOT NULL,
+    score REAL NOT NULL,
+    PRIMARY KEY (result_id, facet)
+);
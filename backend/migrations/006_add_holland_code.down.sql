@@ -0,0 +1 @@
+ALTER TABLE personality_results DROP COLUMN holland_code;
@@ -0,0 +1,2 @@
+-- Store the three-letter Holland code of interest inventory results
+ALTER TABLE personality_results ADD COLUMN holland_code TEXT NOT NULL DEFAULT '';
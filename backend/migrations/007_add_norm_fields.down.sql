@@ -0,0 +1,4 @@
+ALTER TABLE personality_results DROP COLUMN norm_set_id;
+ALTER TABLE personality_results DROP COLUMN country;
+ALTER TABLE personality_results DROP COLUMN language;
+ALTER TABLE personality_results DROP COLUMN age;
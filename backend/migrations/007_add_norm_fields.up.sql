@@ -0,0 +1,5 @@
+-- Respondent details used to select a norm group, and the norm set version applied
+ALTER TABLE personality_results ADD COLUMN age INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE personality_results ADD COLUMN language TEXT NOT NULL DEFAULT '';
+ALTER TABLE personality_results ADD COLUMN country TEXT NOT NULL DEFAULT '';
+ALTER TABLE personality_results ADD COLUMN norm_set_id TEXT NOT NULL DEFAULT '';
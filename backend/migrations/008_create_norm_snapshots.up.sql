@@ -0,0 +1,14 @@
+-- Create norm_snapshots table for SQLite (empirical norms computed from stored results)
+CREATE TABLE IF NOT EXISTS norm_snapshots (
+    name TEXT PRIMARY KEY,
+    instrument_id TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    age_min INTEGER NOT NULL DEFAULT 0,
+    age_max INTEGER NOT NULL DEFAULT 0,
+    from_date TEXT NOT NULL DEFAULT '',
+    to_date TEXT NOT NULL DEFAULT '',
+    n INTEGER NOT NULL DEFAULT 0,
+    scales TEXT NOT NULL DEFAULT '{}',
+    computed_at TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
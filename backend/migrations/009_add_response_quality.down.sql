@@ -0,0 +1,2 @@
+ALTER TABLE personality_results DROP COLUMN quality;
+ALTER TABLE personality_results DROP COLUMN quality_flag;
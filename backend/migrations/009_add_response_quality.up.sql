@@ -0,0 +1,3 @@
+-- Careless-responding flag and the indicators it was derived from (JSON)
+ALTER TABLE personality_results ADD COLUMN quality_flag TEXT NOT NULL DEFAULT '';
+ALTER TABLE personality_results ADD COLUMN quality TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+ALTER TABLE answers DROP COLUMN latency_ms;
+ALTER TABLE personality_results DROP COLUMN completed_at;
+ALTER TABLE personality_results DROP COLUMN started_at;
@@ -0,0 +1,4 @@
+-- Client-reported timing: questionnaire start/end and per-item latency
+ALTER TABLE personality_results ADD COLUMN started_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE personality_results ADD COLUMN completed_at TEXT NOT NULL DEFAULT '';
+ALTER TABLE answers ADD COLUMN latency_ms INTEGER NOT NULL DEFAULT 0;
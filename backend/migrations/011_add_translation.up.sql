@@ -0,0 +1,2 @@
+-- Translation (instrument/language@version) the items were shown in
+ALTER TABLE personality_results ADD COLUMN translation TEXT NOT NULL DEFAULT '';
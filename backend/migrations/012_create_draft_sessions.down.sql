@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS draft_answers;
+DROP TABLE IF EXISTS draft_sessions;
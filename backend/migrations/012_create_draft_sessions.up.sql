@@ -0,0 +1,25 @@
+-- Create draft_sessions and draft_answers tables for SQLite (resumable unfinished questionnaires)
+CREATE TABLE IF NOT EXISTS draft_sessions (
+    id TEXT PRIMARY KEY,
+    resume_code TEXT NOT NULL UNIQUE,
+    session_id TEXT NOT NULL,
+    instrument_id TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    translation TEXT NOT NULL DEFAULT '',
+    age INTEGER NOT NULL DEFAULT 0,
+    country TEXT NOT NULL DEFAULT '',
+    status TEXT NOT NULL DEFAULT 'in_progress',
+    result_id TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    updated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    expires_at TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS draft_answers (
+    draft_id TEXT NOT NULL REFERENCES draft_sessions(id),
+    question_id INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    answered_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (draft_id, question_id)
+);
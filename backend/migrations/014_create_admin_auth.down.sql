@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS admin_sessions;
+DROP TABLE IF EXISTS api_keys;
+DROP TABLE IF EXISTS admin_users;
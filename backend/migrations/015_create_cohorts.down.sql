@@ -0,0 +1,9 @@
+DROP INDEX IF EXISTS idx_personality_results_cohort_id;
+
+ALTER TABLE draft_sessions DROP COLUMN cohort_consent;
+ALTER TABLE draft_sessions DROP COLUMN cohort_id;
+ALTER TABLE personality_results DROP COLUMN cohort_consent;
+ALTER TABLE personality_results DROP COLUMN cohort_id;
+
+DROP TABLE IF EXISTS cohorts;
+DROP TABLE IF EXISTS organizations;
@@ -0,0 +1,25 @@
+-- Create organizations and cohorts tables for SQLite (class-wide testing with join codes)
+CREATE TABLE IF NOT EXISTS organizations (
+    id TEXT PRIMARY KEY,
+    name TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE TABLE IF NOT EXISTS cohorts (
+    id TEXT PRIMARY KEY,
+    organization_id TEXT NOT NULL REFERENCES organizations(id),
+    name TEXT NOT NULL,
+    instrument_id TEXT NOT NULL,
+    join_code TEXT NOT NULL UNIQUE,
+    created_by TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+-- Tag results and draft sessions with their cohort and the respondent's consent
+ALTER TABLE personality_results ADD COLUMN cohort_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE personality_results ADD COLUMN cohort_consent INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE draft_sessions ADD COLUMN cohort_id TEXT NOT NULL DEFAULT '';
+ALTER TABLE draft_sessions ADD COLUMN cohort_consent INTEGER NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_cohort_id
+ON personality_results(cohort_id);
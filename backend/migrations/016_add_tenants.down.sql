@@ -0,0 +1,35 @@
+-- Only the default tenant's norm snapshots survive, since names were unique
+-- across the deployment before tenants. Other tenants' rows stay in the
+-- remaining tables and become indistinguishable from the default tenant's.
+DROP INDEX IF EXISTS idx_personality_results_tenant_id;
+
+ALTER TABLE norm_snapshots RENAME TO norm_snapshots_tenants;
+
+CREATE TABLE norm_snapshots (
+    name TEXT PRIMARY KEY,
+    instrument_id TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    age_min INTEGER NOT NULL DEFAULT 0,
+    age_max INTEGER NOT NULL DEFAULT 0,
+    from_date TEXT NOT NULL DEFAULT '',
+    to_date TEXT NOT NULL DEFAULT '',
+    n INTEGER NOT NULL DEFAULT 0,
+    scales TEXT NOT NULL DEFAULT '{}',
+    computed_at TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+INSERT INTO norm_snapshots (name, instrument_id, language, age_min, age_max, from_date, to_date, n, scales, computed_at, created_at)
+SELECT name, instrument_id, language, age_min, age_max, from_date, to_date, n, scales, computed_at, created_at
+FROM norm_snapshots_tenants
+WHERE tenant_id = 'default';
+
+DROP TABLE norm_snapshots_tenants;
+
+ALTER TABLE cohorts DROP COLUMN tenant_id;
+ALTER TABLE organizations DROP COLUMN tenant_id;
+ALTER TABLE api_keys DROP COLUMN tenant_id;
+ALTER TABLE admin_users DROP COLUMN tenant_id;
+ALTER TABLE share_links DROP COLUMN tenant_id;
+ALTER TABLE draft_sessions DROP COLUMN tenant_id;
+ALTER TABLE personality_results DROP COLUMN tenant_id;
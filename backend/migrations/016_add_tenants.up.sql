@@ -0,0 +1,38 @@
+-- Assign tenant-owned rows to a tenant for SQLite (multi-school deployments).
+-- Rows stored before tenants existed belong to the default tenant.
+ALTER TABLE personality_results ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE draft_sessions ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE share_links ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE admin_users ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE api_keys ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE organizations ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+ALTER TABLE cohorts ADD COLUMN tenant_id TEXT NOT NULL DEFAULT 'default';
+
+-- Snapshot names are unique per tenant, which SQLite can only express by
+-- rebuilding the table with a new primary key
+ALTER TABLE norm_snapshots RENAME TO norm_snapshots_old;
+
+CREATE TABLE norm_snapshots (
+    tenant_id TEXT NOT NULL DEFAULT 'default',
+    name TEXT NOT NULL,
+    instrument_id TEXT NOT NULL,
+    language TEXT NOT NULL DEFAULT '',
+    age_min INTEGER NOT NULL DEFAULT 0,
+    age_max INTEGER NOT NULL DEFAULT 0,
+    from_date TEXT NOT NULL DEFAULT '',
+    to_date TEXT NOT NULL DEFAULT '',
+    n INTEGER NOT NULL DEFAULT 0,
+    scales TEXT NOT NULL DEFAULT '{}',
+    computed_at TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    PRIMARY KEY (tenant_id, name)
+);
+
+INSERT INTO norm_snapshots (name, instrument_id, language, age_min, age_max, from_date, to_date, n, scales, computed_at, created_at)
+SELECT name, instrument_id, language, age_min, age_max, from_date, to_date, n, scales, computed_at, created_at
+FROM norm_snapshots_old;
+
+DROP TABLE norm_snapshots_old;
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_tenant_id
+ON personality_results(tenant_id, created_at DESC);
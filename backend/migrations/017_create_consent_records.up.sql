@@ -0,0 +1,21 @@
+-- Create consent_records table for SQLite (student and guardian consent)
+CREATE TABLE IF NOT EXISTS consent_records (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL DEFAULT 'default',
+    session_id TEXT NOT NULL,
+    text TEXT NOT NULL,
+    purposes TEXT NOT NULL,
+    age INTEGER NOT NULL DEFAULT 0,
+    guardian_required INTEGER NOT NULL DEFAULT 0,
+    guardian_code_hash TEXT NOT NULL DEFAULT '',
+    guardian_expires_at TEXT NOT NULL DEFAULT '',
+    guardian_confirmed_at TEXT NOT NULL DEFAULT '',
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    withdrawn_at TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX IF NOT EXISTS idx_consent_records_session_id
+ON consent_records(tenant_id, session_id);
+
+CREATE INDEX IF NOT EXISTS idx_consent_records_guardian_code_hash
+ON consent_records(guardian_code_hash);
@@ -0,0 +1,18 @@
+-- Create erasures table for SQLite (tombstones of hard-deleted respondent data)
+CREATE TABLE IF NOT EXISTS erasures (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL DEFAULT 'default',
+    subject TEXT NOT NULL,
+    subject_id TEXT NOT NULL,
+    requested_by TEXT NOT NULL,
+    results INTEGER NOT NULL DEFAULT 0,
+    answers INTEGER NOT NULL DEFAULT 0,
+    interpretations INTEGER NOT NULL DEFAULT 0,
+    share_links INTEGER NOT NULL DEFAULT 0,
+    drafts INTEGER NOT NULL DEFAULT 0,
+    consent_records INTEGER NOT NULL DEFAULT 0,
+    erased_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_erasures_tenant_id
+ON erasures(tenant_id, erased_at DESC);
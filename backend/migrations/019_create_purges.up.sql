@@ -0,0 +1,10 @@
+-- Create purges table for SQLite (reports of retention rule runs)
+CREATE TABLE IF NOT EXISTS purges (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL DEFAULT 'default',
+    rules TEXT NOT NULL,
+    ran_at TEXT NOT NULL DEFAULT (datetime('now'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_purges_tenant_id
+ON purges(tenant_id, ran_at DESC);
@@ -0,0 +1,24 @@
+-- Only the active version of each interpretation survives, since a result
+-- had a single interpretation per trait before versions.
+ALTER TABLE trait_interpretations RENAME TO trait_interpretations_versions;
+
+DROP INDEX IF EXISTS idx_trait_interpretations_result_id;
+
+CREATE TABLE trait_interpretations (
+    id TEXT PRIMARY KEY,
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    trait TEXT NOT NULL,
+    interpretation TEXT NOT NULL,
+    created_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE(result_id, trait)
+);
+
+INSERT INTO trait_interpretations (id, result_id, trait, interpretation, created_at)
+SELECT id, result_id, trait, interpretation, generated_at
+FROM trait_interpretations_versions
+WHERE active = 1;
+
+DROP TABLE trait_interpretations_versions;
+
+CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id
+ON trait_interpretations(result_id);
@@ -0,0 +1,33 @@
+-- Keep every generated interpretation as a version for SQLite. Regenerating
+-- adds a new version and makes it active instead of replacing the previous
+-- text, and each version records how it was generated. SQLite can only drop
+-- the UNIQUE(result_id, trait) constraint by rebuilding the table.
+ALTER TABLE trait_interpretations RENAME TO trait_interpretations_old;
+
+DROP INDEX IF EXISTS idx_trait_interpretations_result_id;
+
+CREATE TABLE trait_interpretations (
+    id TEXT PRIMARY KEY,
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    version INTEGER NOT NULL DEFAULT 1,
+    trait TEXT NOT NULL,
+    interpretation TEXT NOT NULL,
+    active INTEGER NOT NULL DEFAULT 1,
+    language TEXT NOT NULL DEFAULT '',
+    model TEXT NOT NULL DEFAULT '',
+    prompt_version TEXT NOT NULL DEFAULT '',
+    temperature REAL NOT NULL DEFAULT 0,
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    generated_at TEXT NOT NULL DEFAULT (datetime('now')),
+    UNIQUE(result_id, version, trait)
+);
+
+INSERT INTO trait_interpretations (id, result_id, trait, interpretation, generated_at)
+SELECT id, result_id, trait, interpretation, created_at
+FROM trait_interpretations_old;
+
+DROP TABLE trait_interpretations_old;
+
+CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id
+ON trait_interpretations(result_id, active);
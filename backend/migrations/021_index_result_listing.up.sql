@@ -0,0 +1,19 @@
+-- Index the admin result listing for SQLite. Pages are read in
+-- (created_at, id) order within a tenant, optionally filtered by response
+-- quality, language or cohort, or in score order within a scale.
+DROP INDEX IF EXISTS idx_personality_results_tenant_id;
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_tenant_id
+ON personality_results(tenant_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_quality_flag
+ON personality_results(tenant_id, quality_flag, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_language
+ON personality_results(tenant_id, language, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_tenant_cohort_id
+ON personality_results(tenant_id, cohort_id, created_at, id);
+
+CREATE INDEX IF NOT EXISTS idx_result_scores_scale
+ON result_scores(scale, score, result_id);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_erasures_subject;
+ALTER TABLE erasures DROP COLUMN result_ids;
+ALTER TABLE erasures DROP COLUMN status;
@@ -0,0 +1,8 @@
+-- Erasure tombstones are stored as pending before anything is deleted and
+-- completed afterwards. A pending erasure keeps the IDs of the results it
+-- deleted, so repeating it can delete what is kept about them elsewhere.
+ALTER TABLE erasures ADD COLUMN status TEXT NOT NULL DEFAULT 'completed';
+ALTER TABLE erasures ADD COLUMN result_ids TEXT NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_erasures_subject
+ON erasures(tenant_id, subject, subject_id, status);
@@ -0,0 +1,2 @@
+-- The backfilled scale rows equal the Big Five columns they were copied
+-- from and cannot be told apart from rows stored with results, so they stay.
@@ -0,0 +1,17 @@
+// Package migrations embeds the numbered SQL migrations of the database
+// schema. Each version has a NNN_name.up.sql file and a NNN_name.down.sql
+// file that reverts it.
+package migrations
+
+import "embed"
+
+// FS holds the migration files of the SQLite database
+//
+//go:embed *.sql
+var FS embed.FS
+
+// PostgresFS holds the migration files of the PostgreSQL result store in its
+// postgres directory
+//
+//go:embed postgres/*.sql
+var PostgresFS embed.FS
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS trait_interpretations;
+DROP TABLE IF EXISTS answers;
+DROP TABLE IF EXISTS result_facet_scores;
+DROP TABLE IF EXISTS result_scores;
+DROP TABLE IF EXISTS personality_results;
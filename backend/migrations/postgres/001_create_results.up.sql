@@ -0,0 +1,76 @@
+-- Create the result tables for PostgreSQL. Only results are kept in
+-- PostgreSQL; timestamps are stored as text like in SQLite.
+CREATE TABLE IF NOT EXISTS personality_results (
+    id TEXT PRIMARY KEY,
+    tenant_id TEXT NOT NULL DEFAULT 'default',
+    session_id TEXT NOT NULL,
+    instrument_id TEXT NOT NULL DEFAULT 'ipip-50',
+    instrument_version TEXT NOT NULL DEFAULT '1.0',
+    holland_code TEXT NOT NULL DEFAULT '',
+    age INTEGER NOT NULL DEFAULT 0,
+    language TEXT NOT NULL DEFAULT '',
+    country TEXT NOT NULL DEFAULT '',
+    norm_set_id TEXT NOT NULL DEFAULT '',
+    translation TEXT NOT NULL DEFAULT '',
+    quality_flag TEXT NOT NULL DEFAULT '',
+    quality TEXT NOT NULL DEFAULT '',
+    started_at TEXT NOT NULL DEFAULT '',
+    completed_at TEXT NOT NULL DEFAULT '',
+    cohort_id TEXT NOT NULL DEFAULT '',
+    cohort_consent BOOLEAN NOT NULL DEFAULT FALSE,
+    extraversion DOUBLE PRECISION NOT NULL,
+    agreeableness DOUBLE PRECISION NOT NULL,
+    conscientiousness DOUBLE PRECISION NOT NULL,
+    emotional_stability DOUBLE PRECISION NOT NULL,
+    openness DOUBLE PRECISION NOT NULL,
+    created_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_session_id
+ON personality_results(tenant_id, session_id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_cohort_id
+ON personality_results(tenant_id, cohort_id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_tenant_id
+ON personality_results(tenant_id, created_at DESC);
+
+CREATE TABLE IF NOT EXISTS result_scores (
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    scale TEXT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (result_id, scale)
+);
+
+-- seq keeps facets in the order they were stored, like rowid in SQLite
+CREATE TABLE IF NOT EXISTS result_facet_scores (
+    seq BIGSERIAL,
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    facet TEXT NOT NULL,
+    trait TEXT NOT NULL,
+    score DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (result_id, facet)
+);
+
+CREATE TABLE IF NOT EXISTS answers (
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    question_id INTEGER NOT NULL,
+    value INTEGER NOT NULL,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    answered_at TEXT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_answers_result_id
+ON answers(result_id);
+
+CREATE TABLE IF NOT EXISTS trait_interpretations (
+    id TEXT PRIMARY KEY,
+    result_id TEXT NOT NULL REFERENCES personality_results(id),
+    trait TEXT NOT NULL,
+    interpretation TEXT NOT NULL,
+    created_at TEXT NOT NULL,
+    UNIQUE(result_id, trait)
+);
+
+CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id
+ON trait_interpretations(result_id);
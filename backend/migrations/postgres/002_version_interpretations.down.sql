@@ -0,0 +1,19 @@
+-- Only the active version of each interpretation survives, since a result
+-- had a single interpretation per trait before versions.
+DELETE FROM trait_interpretations WHERE NOT active;
+
+DROP INDEX IF EXISTS idx_trait_interpretations_result_id;
+ALTER TABLE trait_interpretations DROP CONSTRAINT IF EXISTS trait_interpretations_result_id_version_trait_key;
+ALTER TABLE trait_interpretations DROP COLUMN completion_tokens;
+ALTER TABLE trait_interpretations DROP COLUMN prompt_tokens;
+ALTER TABLE trait_interpretations DROP COLUMN temperature;
+ALTER TABLE trait_interpretations DROP COLUMN prompt_version;
+ALTER TABLE trait_interpretations DROP COLUMN model;
+ALTER TABLE trait_interpretations DROP COLUMN language;
+ALTER TABLE trait_interpretations DROP COLUMN active;
+ALTER TABLE trait_interpretations DROP COLUMN version;
+ALTER TABLE trait_interpretations RENAME COLUMN generated_at TO created_at;
+ALTER TABLE trait_interpretations ADD CONSTRAINT trait_interpretations_result_id_trait_key UNIQUE (result_id, trait);
+
+CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id
+ON trait_interpretations(result_id);
@@ -0,0 +1,18 @@
+-- Keep every generated interpretation as a version for PostgreSQL.
+-- Regenerating adds a new version and makes it active instead of replacing
+-- the previous text, and each version records how it was generated.
+ALTER TABLE trait_interpretations DROP CONSTRAINT IF EXISTS trait_interpretations_result_id_trait_key;
+ALTER TABLE trait_interpretations RENAME COLUMN created_at TO generated_at;
+ALTER TABLE trait_interpretations ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE trait_interpretations ADD COLUMN active BOOLEAN NOT NULL DEFAULT TRUE;
+ALTER TABLE trait_interpretations ADD COLUMN language TEXT NOT NULL DEFAULT '';
+ALTER TABLE trait_interpretations ADD COLUMN model TEXT NOT NULL DEFAULT '';
+ALTER TABLE trait_interpretations ADD COLUMN prompt_version TEXT NOT NULL DEFAULT '';
+ALTER TABLE trait_interpretations ADD COLUMN temperature DOUBLE PRECISION NOT NULL DEFAULT 0;
+ALTER TABLE trait_interpretations ADD COLUMN prompt_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE trait_interpretations ADD COLUMN completion_tokens INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE trait_interpretations ADD CONSTRAINT trait_interpretations_result_id_version_trait_key UNIQUE (result_id, version, trait);
+
+DROP INDEX IF EXISTS idx_trait_interpretations_result_id;
+CREATE INDEX IF NOT EXISTS idx_trait_interpretations_result_id
+ON trait_interpretations(result_id, active);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_result_scores_scale;
+DROP INDEX IF EXISTS idx_personality_results_cohort_id;
+DROP INDEX IF EXISTS idx_personality_results_language;
+DROP INDEX IF EXISTS idx_personality_results_quality_flag;
+DROP INDEX IF EXISTS idx_personality_results_tenant_id;
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_cohort_id
+ON personality_results(tenant_id, cohort_id);
+
+CREATE INDEX IF NOT EXISTS idx_personality_results_tenant_id
+ON personality_results(tenant_id, created_at DESC);
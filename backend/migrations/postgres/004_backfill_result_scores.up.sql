@@ -0,0 +1,20 @@
+-- Score results stored before per-scale scores from their Big Five columns.
+-- Results that already have scale rows, like interest profiles, are skipped.
+INSERT INTO result_scores (result_id, scale, score)
+SELECT p.id, s.scale,
+    CASE s.scale
+        WHEN 'extraversion' THEN p.extraversion
+        WHEN 'agreeableness' THEN p.agreeableness
+        WHEN 'conscientiousness' THEN p.conscientiousness
+        WHEN 'emotional_stability' THEN p.emotional_stability
+        ELSE p.openness
+    END
+FROM personality_results p
+CROSS JOIN (
+    SELECT 'extraversion' AS scale
+    UNION ALL SELECT 'agreeableness'
+    UNION ALL SELECT 'conscientiousness'
+    UNION ALL SELECT 'emotional_stability'
+    UNION ALL SELECT 'openness'
+) s
+WHERE NOT EXISTS (SELECT 1 FROM result_scores r WHERE r.result_id = p.id);
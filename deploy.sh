@@ -8,6 +8,8 @@ PROJECT_ID="${GCP_PROJECT_ID:-voca-486110}"
 REGION="${GCP_REGION:-europe-west1}"
 BACKEND_SERVICE="voca-api"
 OPENAI_API_KEY="${OPENAI_API_KEY:-}"
+SESSION_SECRET="${SESSION_SECRET:-}"
+CORS_ORIGINS="${CORS_ORIGINS:-https://${PROJECT_ID}.web.app,https://${PROJECT_ID}.firebaseapp.com}"
 
 echo "🚀 Deploying Voca to Google Cloud"
 echo "   Project: $PROJECT_ID"
@@ -24,12 +26,18 @@ deploy_backend() {
         echo "   Set it with: export OPENAI_API_KEY=sk-..."
     fi
 
+    # Session tokens must be signed with a stable secret in production
+    if [ -z "$SESSION_SECRET" ]; then
+        echo "❌ SESSION_SECRET not set. Generate one with: export SESSION_SECRET=\$(openssl rand -hex 32)"
+        exit 1
+    fi
+
     gcloud run deploy $BACKEND_SERVICE \
         --source . \
         --project $PROJECT_ID \
         --region $REGION \
         --allow-unauthenticated \
-        --set-env-vars="ENVIRONMENT=production,DATABASE_PATH=/data/voca.db,OPENAI_API_KEY=$OPENAI_API_KEY" \
+        --set-env-vars="^;^ENVIRONMENT=production;DATABASE_PATH=/data/voca.db;OPENAI_API_KEY=$OPENAI_API_KEY;SESSION_SECRET=$SESSION_SECRET;CORS_ORIGINS=$CORS_ORIGINS" \
         --execution-environment gen2 \
         --cpu 1 \
         --memory 512Mi \
@@ -9,27 +9,87 @@ export interface AdminResult {
   created_at: string
 }
 
+// A page of the admin result listing
+interface AdminResultPage {
+  results: AdminResult[]
+  total: number
+  next_cursor?: string
+}
+
 export const useAdmin = () => {
   const config = useRuntimeConfig()
   const results = ref<AdminResult[]>([])
+  // Count of all results, of which results holds the pages loaded so far
+  const total = ref(0)
+  const nextCursor = ref<string | null>(null)
   const isLoading = ref(false)
   const error = ref<string | null>(null)
+  // Set when the admin API rejects the request for a missing or expired sign-in
+  const needsLogin = ref(false)
 
-  const fetchResults = async () => {
+  // Loads the first page, or with more the page after the ones loaded
+  const fetchResults = async (more = false) => {
     isLoading.value = true
     error.value = null
 
     try {
-      const data = await $fetch<AdminResult[]>(`${config.public.apiUrl}/api/admin/results`)
-      results.value = data || []
+      // The admin session cookie is sent cross-origin to the API
+      const data = await $fetch<AdminResultPage>(`${config.public.apiUrl}/api/admin/results`, {
+        query: more && nextCursor.value ? { cursor: nextCursor.value } : {},
+        credentials: 'include'
+      })
+      results.value = more ? [...results.value, ...data.results] : data.results
+      total.value = data.total
+      nextCursor.value = data.next_cursor || null
+      needsLogin.value = false
     } catch (e) {
-      error.value = 'Fehler beim Laden der Ergebnisse'
-      console.error('Failed to fetch results:', e)
+      const status = (e as { statusCode?: number }).statusCode
+      if (status === 401) {
+        needsLogin.value = true
+      } else {
+        error.value = status === 403
+          ? 'Keine Berechtigung für Einzelergebnisse'
+          : 'Fehler beim Laden der Ergebnisse'
+        console.error('Failed to fetch results:', e)
+      }
     } finally {
       isLoading.value = false
     }
   }
 
+  const login = async (username: string, password: string) => {
+    error.value = null
+
+    try {
+      await $fetch(`${config.public.apiUrl}/api/admin/login`, {
+        method: 'POST',
+        body: { username, password },
+        credentials: 'include'
+      })
+      needsLogin.value = false
+      await fetchResults()
+    } catch (e) {
+      error.value = 'Anmeldung fehlgeschlagen'
+      console.error('Failed to sign in:', e)
+    }
+  }
+
+  const loadMore = () => fetchResults(true)
+
+  const logout = async () => {
+    try {
+      await $fetch(`${config.public.apiUrl}/api/admin/logout`, {
+        method: 'POST',
+        credentials: 'include'
+      })
+    } finally {
+      results.value = []
+      total.value = 0
+      nextCursor.value = null
+      needsLogin.value = true
+    }
+  }
+
   const formatDate = (dateString: string) => {
     if (!dateString) return '-'
     const date = new Date(dateString)
@@ -48,9 +108,15 @@ export const useAdmin = () => {
 
   return {
     results,
+    total,
+    nextCursor,
     isLoading,
     error,
+    needsLogin,
     fetchResults,
+    loadMore,
+    login,
+    logout,
     formatDate,
     truncateId
   }
@@ -0,0 +1,81 @@
+export interface ConsentText {
+  key: string
+  language: string
+  title: string
+  purposes: Record<string, string>
+  guardian: string
+  guardian_consent_age: number
+}
+
+export interface ConsentRecord {
+  id: string
+  purposes: string[]
+  age: number
+  guardian_required: boolean
+  guardian_code?: string
+  status: 'active' | 'pending_guardian' | 'expired' | 'withdrawn'
+}
+
+/**
+ * Consent of the session's respondent. Results are only stored with
+ * storage consent and only interpreted with ai_interpretation consent;
+ * respondents below the guardian consent age wait for a guardian to confirm.
+ */
+export const useConsent = () => {
+  const config = useRuntimeConfig()
+  const { getSessionToken } = useSession()
+  const text = useState<ConsentText | null>('consentText', () => null)
+  const record = useState<ConsentRecord | null>('consentRecord', () => null)
+  // The guardian code is only returned when consent is granted, so it is kept
+  // for the rest of the visit
+  const guardianCode = useState<string | null>('guardianCode', () => null)
+
+  const isActive = computed(() => record.value?.status === 'active')
+  const isPendingGuardian = computed(() => record.value?.status === 'pending_guardian')
+
+  // Loads the consent form in the respondent's language
+  const loadText = async (language: string) => {
+    text.value = await $fetch<ConsentText>(`${config.public.apiUrl}/api/consent/text`, {
+      headers: { 'Accept-Language': language },
+    })
+  }
+
+  // Loads the session's latest consent record, if there is one
+  const loadRecord = async () => {
+    const sessionToken = await getSessionToken()
+    try {
+      record.value = await $fetch<ConsentRecord>(`${config.public.apiUrl}/api/consent`, {
+        headers: { 'X-Session-Token': sessionToken },
+      })
+    } catch (e) {
+      if ((e as { statusCode?: number }).statusCode !== 404) throw e
+      record.value = null
+    }
+  }
+
+  // Records the respondent's consent to the form loaded by loadText
+  const grant = async (age: number, interpretation: boolean) => {
+    const sessionToken = await getSessionToken()
+    record.value = await $fetch<ConsentRecord>(`${config.public.apiUrl}/api/consent`, {
+      method: 'POST',
+      headers: { 'X-Session-Token': sessionToken },
+      body: {
+        text: text.value?.key,
+        purposes: interpretation ? ['storage', 'ai_interpretation'] : ['storage'],
+        age,
+      },
+    })
+    guardianCode.value = record.value.guardian_code || null
+  }
+
+  return {
+    text,
+    record,
+    guardianCode,
+    isActive,
+    isPendingGuardian,
+    loadText,
+    loadRecord,
+    grant,
+  }
+}
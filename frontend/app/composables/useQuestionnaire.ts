@@ -4,6 +4,8 @@ export type { Question, Answer, PersonalityResult }
 
 export const useQuestionnaire = () => {
   const config = useRuntimeConfig()
+  const { getSessionToken } = useSession()
+  const route = useRoute()
   const currentQuestionIndex = useState<number>('currentQuestionIndex', () => 0)
   const answers = useState<Answer[]>('answers', () => [])
   const result = useState<PersonalityResult | null>('result', () => null)
@@ -49,8 +51,8 @@ export const useQuestionnaire = () => {
     isLoading.value = true
 
     try {
-      // Generate a session ID for this submission
-      const sessionId = crypto.randomUUID()
+      // Results are bound to the session of this token
+      const sessionToken = await getSessionToken()
 
       // Convert answers to backend format (question_id instead of questionId)
       const backendAnswers = answers.value.map(a => ({
@@ -70,10 +72,14 @@ export const useQuestionnaire = () => {
         }
       }>(`${config.public.apiUrl}/api/results`, {
         method: 'POST',
+        headers: {
+          'X-Session-Token': sessionToken,
+        },
         body: {
-          session_id: sessionId,
           answers: backendAnswers,
           language: language || 'de', // Pass language for background AI generation
+          // Class links carry the cohort's join code as ?cohort=
+          cohort_code: typeof route.query.cohort === 'string' ? route.query.cohort : undefined,
         },
       })
 
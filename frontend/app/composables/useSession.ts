@@ -0,0 +1,50 @@
+interface StoredSession {
+  token: string
+  session_id: string
+  expires_at: string
+}
+
+const STORAGE_KEY = 'voca-session'
+
+/**
+ * Server-issued session token. Results are bound to the session that
+ * submitted them and can only be read back with its token.
+ */
+export const useSession = () => {
+  const config = useRuntimeConfig()
+
+  const getStoredSession = (): StoredSession | null => {
+    if (!import.meta.client) return null
+    try {
+      const session = JSON.parse(localStorage.getItem(STORAGE_KEY) || 'null') as StoredSession | null
+      if (session && new Date(session.expires_at).getTime() > Date.now()) {
+        return session
+      }
+    } catch {
+      // Ignore malformed storage and request a new token
+    }
+    return null
+  }
+
+  // Returns the stored session token, requesting a new one if needed
+  const getSessionToken = async (): Promise<string> => {
+    const stored = getStoredSession()
+    if (stored) return stored.token
+
+    const session = await $fetch<StoredSession>(`${config.public.apiUrl}/api/session-tokens`, {
+      method: 'POST',
+    })
+    localStorage.setItem(STORAGE_KEY, JSON.stringify(session))
+    return session.token
+  }
+
+  const sessionHeaders = (): Record<string, string> => {
+    const stored = getStoredSession()
+    return stored ? { 'X-Session-Token': stored.token } : {}
+  }
+
+  return {
+    getSessionToken,
+    sessionHeaders,
+  }
+}